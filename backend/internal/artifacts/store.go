@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package artifacts
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/policyhub/internal/config"
+)
+
+// stagingDir holds artifacts while they are written; it lives inside the store so that committing
+// is a rename, and it is never served
+const stagingDir = ".staging"
+
+// ErrExists is returned when committing over an artifact that is already stored
+var ErrExists = errors.New("artifact already exists")
+
+// Store keeps artifacts in a directory laid out as <policy>/<version>/<file>. Artifacts are written
+// to a staging location first and moved into place in one step, and stored artifacts are never
+// overwritten.
+type Store struct {
+	dir     string
+	baseURL string
+}
+
+// NewStore creates an artifact store; it is disabled when no directory is configured
+func NewStore(cfg *config.ArtifactsConfig) *Store {
+	return &Store{
+		dir:     cfg.Dir,
+		baseURL: cfg.BaseURL,
+	}
+}
+
+// Enabled reports whether artifacts can be stored
+func (s *Store) Enabled() bool {
+	return s.dir != ""
+}
+
+// Addressable reports whether stored artifacts have public URLs
+func (s *Store) Addressable() bool {
+	return s.dir != "" && s.baseURL != ""
+}
+
+// URL returns the public URL of a stored path
+func (s *Store) URL(elem ...string) string {
+	escaped := make([]string, len(elem))
+	for i, e := range elem {
+		escaped[i] = url.PathEscape(e)
+	}
+	return s.baseURL + "/" + strings.Join(escaped, "/")
+}
+
+// TempDir creates a staging directory for artifacts about to be committed
+func (s *Store) TempDir() (string, error) {
+	if err := os.MkdirAll(filepath.Join(s.dir, stagingDir), 0o755); err != nil {
+		return "", err
+	}
+	return os.MkdirTemp(filepath.Join(s.dir, stagingDir), "stage-")
+}
+
+// Commit moves a staged file or directory to a stored path. It fails with ErrExists when the path is
+// already taken.
+func (s *Store) Commit(staged string, elem ...string) error {
+	for _, e := range elem {
+		if e == "" || e == "." || e == ".." || strings.ContainsAny(e, `/\`) || strings.HasPrefix(e, ".") {
+			return fmt.Errorf("invalid artifact path element %q", e)
+		}
+	}
+	target := s.path(elem...)
+	if _, err := os.Lstat(target); err == nil {
+		return ErrExists
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(staged, target)
+}
+
+// Remove deletes a stored path and everything below it
+func (s *Store) Remove(elem ...string) error {
+	return os.RemoveAll(s.path(elem...))
+}
+
+// Open opens a stored file by its slash-separated path. Directories, hidden and staged files are
+// reported as not existing.
+func (s *Store) Open(name string) (*os.File, fs.FileInfo, error) {
+	if !s.Enabled() || !fs.ValidPath(name) || hasHiddenElem(name) {
+		return nil, nil, fs.ErrNotExist
+	}
+	root, err := os.OpenRoot(s.dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer root.Close()
+
+	f, err := root.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	if !info.Mode().IsRegular() {
+		f.Close()
+		return nil, nil, fs.ErrNotExist
+	}
+	return f, info, nil
+}
+
+func (s *Store) path(elem ...string) string {
+	return filepath.Join(append([]string{s.dir}, elem...)...)
+}
+
+func hasHiddenElem(name string) bool {
+	for _, elem := range strings.Split(path.Clean(name), "/") {
+		if strings.HasPrefix(elem, ".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package bundle
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/wso2/policyhub/internal/policy"
+)
+
+// ConstraintLatest selects only the latest version of each policy
+const ConstraintLatest = "latest"
+
+var (
+	versionPattern  = regexp.MustCompile(policy.VersionRegex)
+	wildcardPattern = regexp.MustCompile(`^(\d+)(?:\.(\d+))?\.[x*]$`)
+)
+
+// Constraint matches policy versions. It is either "latest" or a comma-separated list of conditions
+// that must all hold: exact versions (1.2.3), wildcards (1.x, 1.2.x), comparisons (>=1.0.0, <2.0.0),
+// caret ranges (^1.2.0) and tilde ranges (~1.2.0).
+type Constraint struct {
+	latest     bool
+	conditions []condition
+}
+
+// condition compares a version against a bound with one of =, !=, <, <=, >, >=
+type condition struct {
+	op    string
+	bound string // semver form, with the "v" prefix
+}
+
+// ParseConstraint parses a version constraint; an empty constraint matches every version
+func ParseConstraint(s string) (*Constraint, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return &Constraint{}, nil
+	}
+	if s == ConstraintLatest {
+		return &Constraint{latest: true}, nil
+	}
+
+	c := &Constraint{}
+	for _, term := range strings.Split(s, ",") {
+		conds, err := parseTerm(strings.TrimSpace(term))
+		if err != nil {
+			return nil, err
+		}
+		c.conditions = append(c.conditions, conds...)
+	}
+	return c, nil
+}
+
+// Match reports whether a policy version satisfies the constraint
+func (c *Constraint) Match(v *policy.PolicyVersion) bool {
+	if c.latest {
+		return v.IsLatest
+	}
+	sv := "v" + v.Version
+	for _, cond := range c.conditions {
+		if !cond.match(sv) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c condition) match(v string) bool {
+	cmp := semver.Compare(v, c.bound)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default: // ">="
+		return cmp >= 0
+	}
+}
+
+// parseTerm expands one constraint term into the conditions it stands for
+func parseTerm(term string) ([]condition, error) {
+	if term == "" {
+		return nil, fmt.Errorf("empty version constraint term")
+	}
+
+	if m := wildcardPattern.FindStringSubmatch(term); m != nil {
+		major, _ := strconv.Atoi(m[1])
+		if m[2] == "" {
+			return rangeOf(major, 0, 0, major+1, 0, 0), nil
+		}
+		minor, _ := strconv.Atoi(m[2])
+		return rangeOf(major, minor, 0, major, minor+1, 0), nil
+	}
+
+	for _, op := range []string{">=", "<=", "!=", ">", "<", "=", "^", "~"} {
+		if !strings.HasPrefix(term, op) {
+			continue
+		}
+		version := strings.TrimSpace(strings.TrimPrefix(term, op))
+		major, minor, patch, err := parseVersion(version)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case "^":
+			// Changes that do not modify the left-most non-zero component
+			if major > 0 {
+				return rangeOf(major, minor, patch, major+1, 0, 0), nil
+			}
+			if minor > 0 {
+				return rangeOf(major, minor, patch, 0, minor+1, 0), nil
+			}
+			return rangeOf(major, minor, patch, 0, 0, patch+1), nil
+		case "~":
+			return rangeOf(major, minor, patch, major, minor+1, 0), nil
+		default:
+			return []condition{{op: op, bound: "v" + version}}, nil
+		}
+	}
+
+	if _, _, _, err := parseVersion(term); err != nil {
+		return nil, err
+	}
+	return []condition{{op: "=", bound: "v" + term}}, nil
+}
+
+// parseVersion splits a MAJOR.MINOR.PATCH version into its numeric components
+func parseVersion(version string) (major, minor, patch int, err error) {
+	if !versionPattern.MatchString(version) {
+		return 0, 0, 0, fmt.Errorf("invalid version %q in constraint (expected MAJOR.MINOR.PATCH)", version)
+	}
+	parts := strings.Split(version, ".")
+	major, _ = strconv.Atoi(parts[0])
+	minor, _ = strconv.Atoi(parts[1])
+	patch, _ = strconv.Atoi(parts[2])
+	return major, minor, patch, nil
+}
+
+// rangeOf returns the conditions for the half-open range [from, to)
+func rangeOf(fromMajor, fromMinor, fromPatch, toMajor, toMinor, toPatch int) []condition {
+	return []condition{
+		{op: ">=", bound: fmt.Sprintf("v%d.%d.%d", fromMajor, fromMinor, fromPatch)},
+		{op: "<", bound: fmt.Sprintf("v%d.%d.%d", toMajor, toMinor, toPatch)},
+	}
+}
@@ -0,0 +1,360 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/fetch"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/policy"
+	syncPkg "github.com/wso2/policyhub/internal/sync"
+	"github.com/wso2/policyhub/internal/validation"
+)
+
+var artifactNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Exporter builds signed offline bundles of catalog content
+type Exporter struct {
+	policyService   *policy.Service
+	syncService     *syncPkg.Service
+	signingKey      ed25519.PrivateKey
+	artifactTimeout time.Duration
+	maxArtifactSize int64
+	logger          *logging.Logger
+}
+
+// NewExporter creates a new bundle exporter. Artifacts are mirrored with the fetchers of the sync
+// service, under its fetch restrictions. Exports are refused until a signing key is configured.
+func NewExporter(policyService *policy.Service, syncService *syncPkg.Service, cfg *config.BundleConfig, logger *logging.Logger) (*Exporter, error) {
+	e := &Exporter{
+		policyService:   policyService,
+		syncService:     syncService,
+		artifactTimeout: time.Duration(cfg.ArtifactTimeoutSeconds) * time.Second,
+		maxArtifactSize: int64(cfg.MaxArtifactSizeMB) << 20,
+		logger:          logger,
+	}
+	if cfg.SigningKeyFile != "" {
+		key, err := LoadSigningKey(cfg.SigningKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		e.signingKey = key
+	}
+	return e, nil
+}
+
+// Bundle is a prepared export: its content is collected and signed, and artifacts are staged on disk
+// until the bundle is closed
+type Bundle struct {
+	createdAt  time.Time
+	index      []byte
+	signature  []byte
+	entries    []entry
+	stagingDir string
+	summary    Summary
+}
+
+// entry is a bundle file held in memory (data) or staged on disk (file)
+type entry struct {
+	path string
+	data []byte
+	file string
+	size int64
+}
+
+// Prepare collects the selected versions with their definitions, docs and, when requested, mirrored
+// artifacts, and signs the resulting index. Everything is fetched before the bundle is written so that
+// failures surface before any output is produced. The caller must close the bundle.
+func (e *Exporter) Prepare(ctx context.Context, sel Selection) (*Bundle, error) {
+	if e.signingKey == nil {
+		return nil, errs.BundleSigningNotConfigured()
+	}
+	for _, name := range sel.PolicyNames {
+		if err := validation.ValidatePolicyName(name); err != nil {
+			return nil, err
+		}
+	}
+	constraint, err := ParseConstraint(sel.VersionConstraint)
+	if err != nil {
+		return nil, errs.NewValidationError("invalid version constraint", map[string]any{"error": err.Error()})
+	}
+
+	candidates, err := e.policyService.SelectPolicyVersions(ctx, policy.VersionSelection{
+		PolicyNames: sel.PolicyNames,
+		Provider:    sel.Provider,
+		Platform:    sel.Platform,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var versions []*policy.PolicyVersion
+	for _, v := range candidates {
+		if constraint.Match(v) {
+			versions = append(versions, v)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, errs.NewNotFoundError(errs.CodePolicyVersionNotFound, "No policy versions match the selection", nil)
+	}
+
+	stagingDir, err := os.MkdirTemp("", "policyhub-bundle-")
+	if err != nil {
+		return nil, errs.NewInternalError("failed to create bundle staging directory", map[string]any{"error": err.Error()})
+	}
+	b := &Bundle{
+		createdAt:  time.Now().UTC().Truncate(time.Second),
+		stagingDir: stagingDir,
+	}
+
+	index := Index{
+		Format:    FormatVersion,
+		CreatedAt: b.createdAt,
+		Generator: "PolicyHub",
+		Selection: sel,
+		Versions:  make([]IndexVersion, 0, len(versions)),
+	}
+	for _, v := range versions {
+		files, err := e.addVersion(ctx, b, v, sel.Artifacts)
+		if err != nil {
+			b.Close()
+			return nil, err
+		}
+		index.Versions = append(index.Versions, IndexVersion{PolicyName: v.PolicyName, Version: v.Version, Files: files})
+		b.summary.Files += len(files)
+	}
+
+	b.index, err = json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		b.Close()
+		return nil, errs.NewInternalError("failed to encode bundle index", map[string]any{"error": err.Error()})
+	}
+	b.signature, b.summary.KeyID, err = signIndex(e.signingKey, b.index)
+	if err != nil {
+		b.Close()
+		return nil, errs.NewInternalError("failed to sign bundle index", map[string]any{"error": err.Error()})
+	}
+	b.summary.Versions = len(versions)
+
+	e.logger.Info("Prepared bundle",
+		zap.Int("versions", b.summary.Versions),
+		zap.Int("files", b.summary.Files),
+		zap.Int("artifacts", b.summary.Artifacts),
+		zap.String("keyId", b.summary.KeyID),
+	)
+	return b, nil
+}
+
+// addVersion adds the entries of one policy version to the bundle and returns their index records
+func (e *Exporter) addVersion(ctx context.Context, b *Bundle, v *policy.PolicyVersion, artifacts bool) ([]IndexFile, error) {
+	dir := path.Join(PoliciesDir, v.PolicyName, v.Version)
+	var files []IndexFile
+
+	docs, err := e.policyService.ListPolicyDocs(ctx, v.ID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Page < docs[j].Page })
+
+	record := toVersionRecord(v)
+	if artifacts && v.DownloadURL != nil && *v.DownloadURL != "" {
+		artifactPath := path.Join(dir, ArtifactsDir, artifactName(*v.DownloadURL))
+		file, err := e.mirrorArtifact(ctx, b, v, artifactPath)
+		if err != nil {
+			return nil, err
+		}
+		record.ArtifactPath = artifactPath
+		files = append(files, file)
+		b.summary.Artifacts++
+	}
+
+	metadata, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return nil, errs.NewInternalError("failed to encode version metadata", map[string]any{"error": err.Error()})
+	}
+	files = append(files,
+		b.addData(path.Join(dir, MetadataFile), KindMetadata, "", metadata),
+		b.addData(path.Join(dir, DefinitionFile), KindDefinition, "", []byte(v.DefinitionYAML)),
+	)
+	for _, doc := range docs {
+		files = append(files, b.addData(path.Join(dir, DocsDir, doc.Page+".md"), KindDoc, doc.Page, []byte(doc.ContentMd)))
+	}
+	return files, nil
+}
+
+// mirrorArtifact downloads a version's artifact into the staging directory, verifying it against the
+// checksum the version was published with when that checksum is a SHA-256 digest
+func (e *Exporter) mirrorArtifact(ctx context.Context, b *Bundle, v *policy.PolicyVersion, entryPath string) (IndexFile, error) {
+	artifactURL := *v.DownloadURL
+	e.logger.Debug("Mirroring artifact", zap.String("url", artifactURL))
+
+	ctx, cancel := context.WithTimeout(ctx, e.artifactTimeout)
+	defer cancel()
+
+	sourceType := ""
+	if v.SourceType != nil {
+		sourceType = *v.SourceType
+	}
+	body, err := e.syncService.OpenArtifact(ctx, sourceType, artifactURL, e.maxArtifactSize)
+	if err != nil {
+		return IndexFile{}, err
+	}
+	defer body.Close()
+
+	out, err := os.CreateTemp(b.stagingDir, "artifact-")
+	if err != nil {
+		return IndexFile{}, errs.NewInternalError("failed to stage artifact", map[string]any{"error": err.Error()})
+	}
+	defer out.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(out, hash), body)
+	if err != nil {
+		if errors.Is(err, fetch.ErrTooLarge) {
+			return IndexFile{}, errs.SyncFetchRejected(artifactURL, fmt.Errorf("artifact exceeds %d bytes", e.maxArtifactSize))
+		}
+		return IndexFile{}, errs.SyncFetchFailed(artifactURL, err)
+	}
+	digest := hex.EncodeToString(hash.Sum(nil))
+
+	if expected, ok := v.Checksum.SHA256(); ok && expected != digest {
+		return IndexFile{}, errs.ChecksumMismatch(artifactURL, expected, digest)
+	}
+
+	b.entries = append(b.entries, entry{path: entryPath, file: out.Name(), size: size})
+	return IndexFile{Path: entryPath, Kind: KindArtifact, Size: size, SHA256: digest}, nil
+}
+
+// addData adds an in-memory entry to the bundle and returns its index record
+func (b *Bundle) addData(entryPath, kind, page string, data []byte) IndexFile {
+	sum := sha256.Sum256(data)
+	b.entries = append(b.entries, entry{path: entryPath, data: data, size: int64(len(data))})
+	return IndexFile{Path: entryPath, Kind: kind, Page: page, Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])}
+}
+
+// Summary describes the prepared bundle
+func (b *Bundle) Summary() Summary {
+	return b.summary
+}
+
+// Write writes the bundle as a gzip-compressed tar archive. The index and its signature come first
+// so that readers can verify the archive while streaming it.
+func (b *Bundle) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	if err := b.writeEntry(tw, entry{path: IndexPath, data: b.index, size: int64(len(b.index))}); err != nil {
+		return err
+	}
+	if err := b.writeEntry(tw, entry{path: SignaturePath, data: b.signature, size: int64(len(b.signature))}); err != nil {
+		return err
+	}
+	for _, ent := range b.entries {
+		if err := b.writeEntry(tw, ent); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func (b *Bundle) writeEntry(tw *tar.Writer, ent entry) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     ent.path,
+		Mode:     0o644,
+		Size:     ent.size,
+		ModTime:  b.createdAt,
+	}); err != nil {
+		return err
+	}
+	if ent.file == "" {
+		_, err := tw.Write(ent.data)
+		return err
+	}
+
+	f, err := os.Open(ent.file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// Close removes the staged artifacts
+func (b *Bundle) Close() error {
+	return os.RemoveAll(b.stagingDir)
+}
+
+// toVersionRecord converts a policy version to its bundle metadata
+func toVersionRecord(v *policy.PolicyVersion) *VersionRecord {
+	record := &VersionRecord{
+		PolicyName:         v.PolicyName,
+		Version:            v.Version,
+		IsLatest:           v.IsLatest,
+		DisplayName:        v.DisplayName,
+		Provider:           v.Provider,
+		Description:        v.Description,
+		Categories:         nonNil(v.Categories),
+		Tags:               nonNil(v.Tags),
+		LogoPath:           v.LogoPath,
+		BannerPath:         v.BannerPath,
+		IconPath:           v.IconPath,
+		SupportedPlatforms: nonNil(v.SupportedPlatforms),
+		SourceType:         v.SourceType,
+		DownloadURL:        v.DownloadURL,
+		Checksum:           v.Checksum,
+	}
+	if v.ReleaseDate != nil {
+		date := v.ReleaseDate.Format("2006-01-02")
+		record.ReleaseDate = &date
+	}
+	return record
+}
+
+// artifactName derives the bundle file name of an artifact from its download URL
+func artifactName(downloadURL string) string {
+	if u, err := url.Parse(downloadURL); err == nil {
+		if name := path.Base(u.Path); artifactNamePattern.MatchString(name) {
+			return name
+		}
+	}
+	return "artifact"
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,483 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/wso2/policyhub/internal/artifacts"
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/policy"
+	"github.com/wso2/policyhub/internal/validation"
+)
+
+// Size limits for the bundle entries that are held in memory
+const (
+	maxIndexSize     = 16 << 20
+	maxSignatureSize = 64 << 10
+	maxDocumentSize  = 16 << 20
+)
+
+var pagePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Importer loads bundles into the catalog without any outbound requests
+type Importer struct {
+	policyService *policy.Service
+	verifyKey     ed25519.PublicKey
+	artifacts     *artifacts.Store
+	maxSize       int64
+	logger        *logging.Logger
+}
+
+// NewImporter creates a new bundle importer. Signatures are only enforced when a verification key is
+// configured, and mirrored artifacts are only kept when the artifact store is enabled.
+func NewImporter(policyService *policy.Service, store *artifacts.Store, cfg *config.BundleConfig, logger *logging.Logger) (*Importer, error) {
+	i := &Importer{
+		policyService: policyService,
+		artifacts:     store,
+		maxSize:       int64(cfg.MaxImportSizeMB) << 20,
+		logger:        logger,
+	}
+	if cfg.VerifyKeyFile != "" {
+		key, err := LoadVerifyKey(cfg.VerifyKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		i.verifyKey = key
+	}
+	return i, nil
+}
+
+// MaxSize returns the largest bundle accepted for import over HTTP
+func (i *Importer) MaxSize() int64 {
+	return i.maxSize
+}
+
+// pendingVersion collects the entries of one bundled version while the archive is read
+type pendingVersion struct {
+	entry          IndexVersion
+	files          map[string]IndexFile
+	seen           map[string]bool
+	metadata       []byte
+	definition     []byte
+	docs           []*policy.PolicyDoc
+	artifactName   string
+	artifactDigest string
+	artifactFile   string // Staged copy of the artifact, when artifacts are kept
+	reason         string // Why the version is rejected; the first problem found wins
+}
+
+func (pv *pendingVersion) reject(reason string) {
+	if pv.reason == "" {
+		pv.reason = reason
+	}
+}
+
+// Import reads a bundle archive, verifies its signature and every entry against the index checksums,
+// and adds the versions the catalog does not have yet in a single transaction. Problems with the
+// archive as a whole fail the import; problems with one version only reject that version.
+func (i *Importer) Import(ctx context.Context, r io.Reader, dryRun bool) (*ImportReport, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errs.InvalidBundle("Bundle is not a gzip-compressed archive", map[string]any{"error": err.Error()})
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != IndexPath {
+		return nil, errs.InvalidBundle("Bundle does not start with an index", map[string]any{"expected": IndexPath})
+	}
+	indexBytes, err := readEntry(tr, hdr, maxIndexSize)
+	if err != nil {
+		return nil, errs.InvalidBundle("Bundle index cannot be read", map[string]any{"error": err.Error()})
+	}
+	var index Index
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		return nil, errs.InvalidBundle("Bundle index is not valid JSON", map[string]any{"error": err.Error()})
+	}
+	if index.Format != FormatVersion {
+		return nil, errs.InvalidBundle("Unsupported bundle format", map[string]any{"format": index.Format, "supported": FormatVersion})
+	}
+
+	var signature []byte
+	hdr, err = tr.Next()
+	if err == nil && hdr.Name == SignaturePath {
+		if signature, err = readEntry(tr, hdr, maxSignatureSize); err != nil {
+			return nil, errs.InvalidBundle("Bundle signature cannot be read", map[string]any{"error": err.Error()})
+		}
+		hdr, err = tr.Next()
+	}
+	report := &ImportReport{DryRun: dryRun}
+	if err := i.checkSignature(report, indexBytes, signature); err != nil {
+		return nil, err
+	}
+
+	versions, byPath, planErr := planVersions(&index)
+	if planErr != nil {
+		return nil, planErr
+	}
+
+	stagingDir := ""
+	if i.artifacts.Enabled() && !dryRun {
+		var stageErr error
+		if stagingDir, stageErr = i.artifacts.TempDir(); stageErr != nil {
+			return nil, errs.NewInternalError("failed to stage artifacts", map[string]any{"error": stageErr.Error()})
+		}
+		defer os.RemoveAll(stagingDir)
+	}
+
+	for ; err == nil; hdr, err = tr.Next() {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+		pv, ok := byPath[hdr.Name]
+		if !ok {
+			return nil, errs.InvalidBundle("Bundle contains an entry that is not listed in its index", map[string]any{"path": hdr.Name})
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return nil, errs.InvalidBundle("Bundle entries must be regular files", map[string]any{"path": hdr.Name})
+		}
+		if readErr := readVersionEntry(tr, hdr, pv, stagingDir); readErr != nil {
+			return nil, readErr
+		}
+	}
+	if err != io.EOF {
+		return nil, errs.InvalidBundle("Bundle archive is corrupt", map[string]any{"error": err.Error()})
+	}
+
+	if err := i.settle(ctx, versions, report); err != nil {
+		return nil, err
+	}
+
+	i.logger.Info("Imported bundle",
+		zap.Bool("dryRun", dryRun),
+		zap.String("keyId", report.KeyID),
+		zap.Bool("signatureVerified", report.SignatureVerified),
+		zap.Int("added", len(report.Added)),
+		zap.Int("skipped", len(report.Skipped)),
+		zap.Int("rejected", len(report.Rejected)),
+	)
+	return report, nil
+}
+
+// checkSignature records the signing key and, when a verification key is configured, requires a valid signature
+func (i *Importer) checkSignature(report *ImportReport, index, signature []byte) error {
+	if signature != nil {
+		var sig Signature
+		if json.Unmarshal(signature, &sig) == nil {
+			report.KeyID = sig.KeyID
+		}
+	}
+	if i.verifyKey == nil {
+		return nil
+	}
+	if signature == nil {
+		return errs.InvalidBundle("Bundle is not signed", nil)
+	}
+	if err := verifyIndex(i.verifyKey, index, signature); err != nil {
+		return errs.InvalidBundle("Bundle signature is invalid", map[string]any{"error": err.Error()})
+	}
+	report.SignatureVerified = true
+	return nil
+}
+
+// planVersions checks the layout of the index and maps each listed path to its version. Entries that
+// do not fit the layout reject their version; a path listed twice makes the whole index unusable.
+func planVersions(index *Index) ([]*pendingVersion, map[string]*pendingVersion, error) {
+	versions := make([]*pendingVersion, 0, len(index.Versions))
+	byPath := make(map[string]*pendingVersion)
+
+	for _, entry := range index.Versions {
+		pv := &pendingVersion{
+			entry: entry,
+			files: make(map[string]IndexFile, len(entry.Files)),
+			seen:  make(map[string]bool, len(entry.Files)),
+		}
+		versions = append(versions, pv)
+
+		if err := validation.ValidatePolicyName(entry.PolicyName); err != nil {
+			pv.reject(err.Message)
+		}
+		if err := validation.ValidateVersion(entry.Version); err != nil {
+			pv.reject(err.Message)
+		}
+
+		dir := path.Join(PoliciesDir, entry.PolicyName, entry.Version)
+		var metadata, definition, artifacts int
+		for _, file := range entry.Files {
+			if _, dup := byPath[file.Path]; dup {
+				return nil, nil, errs.InvalidBundle("Bundle index lists an entry more than once", map[string]any{"path": file.Path})
+			}
+			byPath[file.Path] = pv
+			pv.files[file.Path] = file
+
+			switch {
+			case file.Kind == KindMetadata && file.Path == path.Join(dir, MetadataFile):
+				metadata++
+			case file.Kind == KindDefinition && file.Path == path.Join(dir, DefinitionFile):
+				definition++
+			case file.Kind == KindDoc && pagePattern.MatchString(file.Page) && file.Path == path.Join(dir, DocsDir, file.Page+".md"):
+			case file.Kind == KindArtifact && path.Dir(file.Path) == path.Join(dir, ArtifactsDir) && artifactNamePattern.MatchString(path.Base(file.Path)):
+				artifacts++
+				pv.artifactName = path.Base(file.Path)
+			default:
+				pv.reject(fmt.Sprintf("unexpected %s entry %s", file.Kind, file.Path))
+			}
+		}
+		if metadata != 1 || definition != 1 {
+			pv.reject("index must list exactly one metadata and one definition entry")
+		}
+		if artifacts > 1 {
+			pv.reject("index lists more than one artifact")
+		}
+	}
+	return versions, byPath, nil
+}
+
+// readVersionEntry reads one entry of a version and checks it against the index
+func readVersionEntry(tr *tar.Reader, hdr *tar.Header, pv *pendingVersion, stagingDir string) error {
+	if pv.seen[hdr.Name] {
+		return errs.InvalidBundle("Bundle contains an entry more than once", map[string]any{"path": hdr.Name})
+	}
+	pv.seen[hdr.Name] = true
+
+	file := pv.files[hdr.Name]
+	if hdr.Size != file.Size {
+		pv.reject(fmt.Sprintf("%s is %d bytes, index lists %d", hdr.Name, hdr.Size, file.Size))
+		return nil
+	}
+	if file.Kind != KindArtifact && file.Size > maxDocumentSize {
+		pv.reject(fmt.Sprintf("%s exceeds %d bytes", hdr.Name, maxDocumentSize))
+		return nil
+	}
+
+	hash := sha256.New()
+	var data []byte
+	if file.Kind == KindArtifact {
+		var w io.Writer = io.Discard
+		if stagingDir != "" {
+			f, err := os.CreateTemp(stagingDir, "artifact-")
+			if err != nil {
+				return errs.NewInternalError("failed to stage artifact", map[string]any{"error": err.Error()})
+			}
+			defer f.Close()
+			w = f
+			pv.artifactFile = f.Name()
+		}
+		if _, err := io.Copy(io.MultiWriter(w, hash), tr); err != nil {
+			return errs.InvalidBundle("Bundle archive is corrupt", map[string]any{"path": hdr.Name, "error": err.Error()})
+		}
+	} else {
+		var err error
+		if data, err = io.ReadAll(io.TeeReader(tr, hash)); err != nil {
+			return errs.InvalidBundle("Bundle archive is corrupt", map[string]any{"path": hdr.Name, "error": err.Error()})
+		}
+	}
+
+	digest := hex.EncodeToString(hash.Sum(nil))
+	if digest != strings.ToLower(file.SHA256) {
+		pv.reject("checksum mismatch for " + hdr.Name)
+		return nil
+	}
+
+	switch file.Kind {
+	case KindMetadata:
+		pv.metadata = data
+	case KindDefinition:
+		pv.definition = data
+	case KindDoc:
+		pv.docs = append(pv.docs, &policy.PolicyDoc{Page: file.Page, ContentMd: string(data)})
+	case KindArtifact:
+		pv.artifactDigest = digest
+	}
+	return nil
+}
+
+// settle sorts the read versions into rejected, skipped and added ones and, unless this is a dry run,
+// stores the added versions
+func (i *Importer) settle(ctx context.Context, versions []*pendingVersion, report *ImportReport) error {
+	var accepted []*policy.VersionWithDocs
+	var stored []*pendingVersion
+	listed := make(map[string]bool, len(versions))
+
+	for _, pv := range versions {
+		item := ImportItem{PolicyName: pv.entry.PolicyName, Version: pv.entry.Version}
+		key := item.PolicyName + "@" + item.Version
+		if listed[key] {
+			pv.reject("version is listed more than once in the bundle")
+		}
+		listed[key] = true
+
+		for _, file := range pv.entry.Files {
+			if !pv.seen[file.Path] {
+				pv.reject("missing entry " + file.Path)
+			}
+		}
+		var prepared *policy.VersionWithDocs
+		if pv.reason == "" {
+			var reason string
+			if prepared, reason = i.build(pv); reason != "" {
+				pv.reject(reason)
+			}
+		}
+		if pv.reason != "" {
+			item.Reason = pv.reason
+			report.Rejected = append(report.Rejected, item)
+			continue
+		}
+
+		exists, err := i.policyService.PolicyVersionExists(ctx, item.PolicyName, item.Version)
+		if err != nil {
+			return err
+		}
+		if exists {
+			item.Reason = "version already exists"
+			report.Skipped = append(report.Skipped, item)
+			continue
+		}
+		accepted = append(accepted, prepared)
+		stored = append(stored, pv)
+		report.Added = append(report.Added, item)
+	}
+
+	if report.DryRun || len(accepted) == 0 {
+		return nil
+	}
+
+	created, err := i.policyService.CreatePolicyVersionsWithDocs(ctx, accepted)
+	if err != nil {
+		return err
+	}
+
+	// Versions are inserted in order, so the last one marked latest for a policy remains latest
+	latestSeen := make(map[string]bool)
+	for idx := len(created) - 1; idx >= 0; idx-- {
+		v := created[idx]
+		if v.IsLatest && !latestSeen[v.PolicyName] {
+			report.Added[idx].IsLatest = true
+			latestSeen[v.PolicyName] = true
+		}
+	}
+
+	for _, pv := range stored {
+		if pv.artifactFile != "" {
+			i.storeArtifact(pv)
+		}
+	}
+	return nil
+}
+
+// build converts a fully read version into the records to insert, or returns why it cannot be imported
+func (i *Importer) build(pv *pendingVersion) (*policy.VersionWithDocs, string) {
+	var record VersionRecord
+	if err := json.Unmarshal(pv.metadata, &record); err != nil {
+		return nil, "metadata is not valid JSON: " + err.Error()
+	}
+	if record.PolicyName != pv.entry.PolicyName || record.Version != pv.entry.Version {
+		return nil, "metadata does not match the index"
+	}
+	if record.DisplayName == "" || record.Provider == "" {
+		return nil, "metadata must include displayName and provider"
+	}
+	if record.Description != nil {
+		if err := validation.ValidateDescription(*record.Description); err != nil {
+			return nil, err.Message
+		}
+	}
+	if err := validation.ValidateCategories(record.Categories); err != nil {
+		return nil, err.Message
+	}
+	if err := validation.ValidatePlatforms(record.SupportedPlatforms); err != nil {
+		return nil, err.Message
+	}
+	if err := validation.ValidateTags(record.Tags); err != nil {
+		return nil, err.Message
+	}
+	var definition interface{}
+	if err := yaml.Unmarshal(pv.definition, &definition); err != nil {
+		return nil, "invalid policy definition YAML: " + err.Error()
+	}
+
+	if expected, ok := record.Checksum.SHA256(); ok && pv.artifactDigest != "" && expected != pv.artifactDigest {
+		return nil, "artifact does not match the published checksum"
+	}
+
+	version := &policy.PolicyVersion{
+		PolicyName:         record.PolicyName,
+		Version:            record.Version,
+		DisplayName:        record.DisplayName,
+		Provider:           record.Provider,
+		Description:        record.Description,
+		Categories:         record.Categories,
+		Tags:               record.Tags,
+		LogoPath:           record.LogoPath,
+		BannerPath:         record.BannerPath,
+		IconPath:           record.IconPath,
+		SupportedPlatforms: record.SupportedPlatforms,
+		DefinitionYAML:     string(pv.definition),
+		SourceType:         record.SourceType,
+		DownloadURL:        record.DownloadURL,
+		Checksum:           record.Checksum,
+	}
+	if record.ReleaseDate != nil {
+		date, err := time.Parse("2006-01-02", *record.ReleaseDate)
+		if err != nil {
+			return nil, "invalid release date: " + *record.ReleaseDate
+		}
+		version.ReleaseDate = &date
+	}
+	if pv.artifactFile != "" && i.artifacts.Addressable() {
+		downloadURL := i.artifacts.URL(record.PolicyName, record.Version, pv.artifactName)
+		version.DownloadURL = &downloadURL
+	}
+
+	return &policy.VersionWithDocs{Version: version, Docs: pv.docs}, ""
+}
+
+// storeArtifact moves a staged artifact to <name>/<version>/<file> in the artifact store. The version
+// is already stored, so a failure is logged rather than failing the import.
+func (i *Importer) storeArtifact(pv *pendingVersion) {
+	if err := i.artifacts.Commit(pv.artifactFile, pv.entry.PolicyName, pv.entry.Version, pv.artifactName); err != nil {
+		i.logger.Error("Failed to store imported artifact",
+			zap.String("policyName", pv.entry.PolicyName),
+			zap.String("version", pv.entry.Version),
+			zap.Error(err))
+	}
+}
+
+// readEntry reads a tar entry that must not exceed limit bytes
+func readEntry(tr *tar.Reader, hdr *tar.Header, limit int64) ([]byte, error) {
+	if hdr.Size > limit {
+		return nil, fmt.Errorf("%s exceeds %d bytes", hdr.Name, limit)
+	}
+	return io.ReadAll(tr)
+}
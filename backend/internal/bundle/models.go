@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package bundle
+
+import (
+	"time"
+
+	"github.com/wso2/policyhub/internal/policy"
+)
+
+// FormatVersion is the bundle layout version written to and accepted from bundle indexes
+const FormatVersion = 1
+
+// Well-known bundle entries; every other entry lives under PoliciesDir/<name>/<version>/
+const (
+	IndexPath     = "index.json"
+	SignaturePath = "index.json.sig"
+	PoliciesDir   = "policies"
+
+	MetadataFile   = "metadata.json"
+	DefinitionFile = "policy-definition.yaml"
+	DocsDir        = "docs"
+	ArtifactsDir   = "artifacts"
+)
+
+// Kinds of files listed in a bundle index
+const (
+	KindMetadata   = "metadata"
+	KindDefinition = "definition"
+	KindDoc        = "doc"
+	KindArtifact   = "artifact"
+)
+
+// SignatureAlgorithm is the algorithm used to sign bundle indexes
+const SignatureAlgorithm = "ed25519"
+
+// Selection selects the policy versions to export; empty criteria match every version
+type Selection struct {
+	PolicyNames       []string `json:"policyNames,omitempty"`
+	Provider          string   `json:"provider,omitempty"`
+	Platform          string   `json:"platform,omitempty"`
+	VersionConstraint string   `json:"versionConstraint,omitempty"`
+	// Artifacts mirrors each version's download artifact into the bundle
+	Artifacts bool `json:"artifacts"`
+}
+
+// Index is the table of contents of a bundle; it lists every other entry with its digest and is signed
+type Index struct {
+	Format    int            `json:"format"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Generator string         `json:"generator"`
+	Selection Selection      `json:"selection"`
+	Versions  []IndexVersion `json:"versions"`
+}
+
+// IndexVersion lists the files of one bundled policy version
+type IndexVersion struct {
+	PolicyName string      `json:"policyName"`
+	Version    string      `json:"version"`
+	Files      []IndexFile `json:"files"`
+}
+
+// IndexFile describes a bundle entry; SHA256 is the hex digest of its content
+type IndexFile struct {
+	Path   string `json:"path"`
+	Kind   string `json:"kind"`
+	Page   string `json:"page,omitempty"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Signature is the content of the index signature entry
+type Signature struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"keyId"`
+	Value     string `json:"value"` // Base64-encoded signature over the exact index bytes
+}
+
+// VersionRecord is the metadata entry of a bundled version. Logo, banner and icon paths are kept as
+// references; the artifact path points at the mirrored artifact when the bundle carries one.
+type VersionRecord struct {
+	PolicyName         string           `json:"policyName"`
+	Version            string           `json:"version"`
+	IsLatest           bool             `json:"isLatest"`
+	DisplayName        string           `json:"displayName"`
+	Provider           string           `json:"provider"`
+	Description        *string          `json:"description,omitempty"`
+	Categories         []string         `json:"categories"`
+	Tags               []string         `json:"tags"`
+	LogoPath           *string          `json:"logoPath,omitempty"`
+	BannerPath         *string          `json:"bannerPath,omitempty"`
+	IconPath           *string          `json:"iconPath,omitempty"`
+	SupportedPlatforms []string         `json:"supportedPlatforms"`
+	ReleaseDate        *string          `json:"releaseDate,omitempty"` // YYYY-MM-DD
+	SourceType         *string          `json:"sourceType,omitempty"`
+	DownloadURL        *string          `json:"downloadUrl,omitempty"`
+	Checksum           *policy.Checksum `json:"checksum,omitempty"`
+	ArtifactPath       string           `json:"artifactPath,omitempty"`
+}
+
+// Summary describes a prepared bundle
+type Summary struct {
+	Versions  int
+	Files     int
+	Artifacts int
+	KeyID     string
+}
+
+// ImportReport describes the outcome of importing a bundle; in a dry run Added lists the versions
+// that would be added
+type ImportReport struct {
+	DryRun bool
+	// KeyID identifies the key the index is signed with; empty for unsigned bundles
+	KeyID string
+	// SignatureVerified reports whether the signature was checked against the configured key
+	SignatureVerified bool
+	Added             []ImportItem
+	Skipped           []ImportItem
+	Rejected          []ImportItem
+}
+
+// ImportItem is the outcome for one bundled version
+type ImportItem struct {
+	PolicyName string
+	Version    string
+	IsLatest   bool   // Set for added versions once they are stored
+	Reason     string // Why the version was skipped or rejected
+}
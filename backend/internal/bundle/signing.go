@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package bundle
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// LoadSigningKey reads a PEM-encoded PKCS #8 Ed25519 private key
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %s: %w", path, err)
+	}
+	signingKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is not an Ed25519 key", path)
+	}
+	return signingKey, nil
+}
+
+// LoadVerifyKey reads a PEM-encoded PKIX Ed25519 public key
+func LoadVerifyKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse verification key %s: %w", path, err)
+	}
+	verifyKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("verification key %s is not an Ed25519 key", path)
+	}
+	return verifyKey, nil
+}
+
+// KeyID identifies a public key by the first 8 bytes of its SHA-256 digest
+func KeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
+
+// signIndex signs the exact bytes of an index and returns the encoded signature entry
+func signIndex(key ed25519.PrivateKey, index []byte) ([]byte, string, error) {
+	keyID := KeyID(key.Public().(ed25519.PublicKey))
+	sig, err := json.MarshalIndent(Signature{
+		Algorithm: SignatureAlgorithm,
+		KeyID:     keyID,
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(key, index)),
+	}, "", "  ")
+	if err != nil {
+		return nil, "", err
+	}
+	return sig, keyID, nil
+}
+
+// verifyIndex checks a signature entry against the exact bytes of an index
+func verifyIndex(key ed25519.PublicKey, index, signature []byte) error {
+	var sig Signature
+	if err := json.Unmarshal(signature, &sig); err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+	if sig.Algorithm != SignatureAlgorithm {
+		return fmt.Errorf("unsupported signature algorithm %q", sig.Algorithm)
+	}
+	if sig.KeyID != KeyID(key) {
+		return fmt.Errorf("signed with key %s, expected %s", sig.KeyID, KeyID(key))
+	}
+	value, err := base64.StdEncoding.DecodeString(sig.Value)
+	if err != nil {
+		return fmt.Errorf("malformed signature value: %w", err)
+	}
+	if !ed25519.Verify(key, index, value) {
+		return fmt.Errorf("signature does not match index")
+	}
+	return nil
+}
+
+// readPEM reads the first PEM block of a file
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key file %s does not contain a PEM block", path)
+	}
+	return block, nil
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package changes
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Limits on the number of changes returned per request
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// Change is one catalog mutation recorded in the change log. Its ID is the feed cursor.
+type Change struct {
+	ID         int64
+	Type       string
+	PolicyName string
+	Version    string
+	Data       json.RawMessage
+	CreatedAt  time.Time
+}
+
+// ChangeFilters holds the cursor and filtering criteria for reading the change log
+type ChangeFilters struct {
+	Since      int64 // Return changes after this cursor
+	PolicyName string
+	Types      []string
+	Limit      int
+}
+
+// Page is a slice of the change log. Cursor is the position to resume from; it can advance past
+// changes that were filtered out, so clients should always continue from it.
+type Page struct {
+	Changes []*Change
+	Cursor  int64
+	HasMore bool
+}
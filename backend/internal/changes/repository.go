@@ -0,0 +1,20 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package changes
+
+import "context"
+
+// Repository defines the interface for reading the change log
+type Repository interface {
+	// GetLatestChangeID returns the ID of the most recent change, or 0 when the log is empty
+	GetLatestChangeID(ctx context.Context) (int64, error)
+	// ListChanges returns up to limit changes with filters.Since < ID <= upTo in ascending order
+	ListChanges(ctx context.Context, filters ChangeFilters, upTo int64, limit int) ([]*Change, error)
+}
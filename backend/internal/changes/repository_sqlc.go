@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package changes
+
+import (
+	"context"
+
+	"github.com/wso2/policyhub/internal/db"
+	"github.com/wso2/policyhub/internal/db/sqlc"
+	"github.com/wso2/policyhub/internal/errs"
+)
+
+// SQLCRepository implements Repository using sqlc-generated code
+type SQLCRepository struct {
+	db      *db.DB
+	queries *sqlc.Queries
+}
+
+// NewSQLCRepository creates a new SQLC-based change log repository
+func NewSQLCRepository(database *db.DB) Repository {
+	return &SQLCRepository{
+		db:      database,
+		queries: sqlc.New(database.Pool),
+	}
+}
+
+func (r *SQLCRepository) GetLatestChangeID(ctx context.Context) (int64, error) {
+	id, err := r.queries.GetLatestChangeID(ctx)
+	if err != nil {
+		return 0, errs.NewDatabaseError("failed to get latest change", map[string]any{"error": err.Error()})
+	}
+	return id, nil
+}
+
+func (r *SQLCRepository) ListChanges(ctx context.Context, filters ChangeFilters, upTo int64, limit int) ([]*Change, error) {
+	types := filters.Types
+	if types == nil {
+		types = []string{}
+	}
+
+	rows, err := r.queries.ListChanges(ctx, sqlc.ListChangesParams{
+		ID:      filters.Since,
+		ID_2:    upTo,
+		Column3: filters.PolicyName,
+		Column4: types,
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list changes", map[string]any{"error": err.Error()})
+	}
+
+	changes := make([]*Change, 0, len(rows))
+	for _, row := range rows {
+		changes = append(changes, &Change{
+			ID:         row.ID,
+			Type:       row.ChangeType,
+			PolicyName: row.PolicyName,
+			Version:    row.Version,
+			Data:       row.Payload,
+			CreatedAt:  row.CreatedAt.Time,
+		})
+	}
+	return changes, nil
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package changes
+
+import (
+	"context"
+	"time"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/policy"
+	"github.com/wso2/policyhub/internal/validation"
+)
+
+// Service handles change feed business logic
+type Service struct {
+	repo      Repository
+	watcher   *Watcher
+	maxWait   time.Duration
+	heartbeat time.Duration
+	logger    *logging.Logger
+}
+
+// NewService creates a new change feed service
+func NewService(repo Repository, watcher *Watcher, cfg *config.ChangesConfig, logger *logging.Logger) *Service {
+	return &Service{
+		repo:      repo,
+		watcher:   watcher,
+		maxWait:   time.Duration(cfg.MaxWaitSeconds) * time.Second,
+		heartbeat: time.Duration(cfg.HeartbeatSeconds) * time.Second,
+		logger:    logger,
+	}
+}
+
+// MaxWait returns the upper bound for a long-poll wait
+func (s *Service) MaxWait() time.Duration {
+	return s.maxWait
+}
+
+// Heartbeat returns the interval between keep-alives on idle event streams
+func (s *Service) Heartbeat() time.Duration {
+	return s.heartbeat
+}
+
+// Done is closed when the feed shuts down; clients waiting on changes should disconnect
+func (s *Service) Done() <-chan struct{} {
+	return s.watcher.Done()
+}
+
+// ListChanges returns the changes after filters.Since. When there are none and wait is positive,
+// it waits up to wait (capped at the configured maximum) for new changes before returning an empty page.
+func (s *Service) ListChanges(ctx context.Context, filters ChangeFilters, wait time.Duration) (*Page, error) {
+	if err := s.validateFilters(&filters); err != nil {
+		return nil, err
+	}
+
+	page, err := s.fetch(ctx, filters)
+	if err != nil || len(page.Changes) > 0 || wait <= 0 {
+		return page, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, min(wait, s.maxWait))
+	defer cancel()
+
+	// Changes that do not match the filters wake the wait too; keep waiting until one matches
+	for s.watcher.Wait(waitCtx, page.Cursor) {
+		filters.Since = page.Cursor
+		page, err = s.fetch(ctx, filters)
+		if err != nil || len(page.Changes) > 0 {
+			return page, err
+		}
+	}
+	return page, nil
+}
+
+// fetch reads one page of changes. Changes are bounded by the head of the log read beforehand,
+// so that an empty page can safely move the cursor to the head.
+func (s *Service) fetch(ctx context.Context, filters ChangeFilters) (*Page, error) {
+	latest, err := s.repo.GetLatestChangeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if filters.Since > latest {
+		return nil, errs.NewValidationError("cursor is ahead of the change log", map[string]any{
+			"since":  filters.Since,
+			"latest": latest,
+		})
+	}
+	if filters.Since == latest {
+		return &Page{Changes: []*Change{}, Cursor: latest}, nil
+	}
+
+	changes, err := s.repo.ListChanges(ctx, filters, latest, filters.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Changes: changes, Cursor: latest}
+	if len(changes) > filters.Limit {
+		page.Changes = changes[:filters.Limit]
+		page.Cursor = page.Changes[filters.Limit-1].ID
+		page.HasMore = true
+	}
+	return page, nil
+}
+
+func (s *Service) validateFilters(filters *ChangeFilters) error {
+	if filters.Since < 0 {
+		return errs.NewValidationError("invalid cursor", map[string]any{"since": filters.Since})
+	}
+	if filters.Limit == 0 {
+		filters.Limit = DefaultLimit
+	}
+	if filters.Limit < 1 || filters.Limit > MaxLimit {
+		return errs.NewValidationError("invalid limit", map[string]any{
+			"min":      1,
+			"max":      MaxLimit,
+			"provided": filters.Limit,
+		})
+	}
+	if filters.PolicyName != "" {
+		if err := validation.ValidatePolicyName(filters.PolicyName); err != nil {
+			return err
+		}
+	}
+
+	validTypes := policy.ValidChangeTypes()
+	for _, changeType := range filters.Types {
+		if !validTypes[changeType] {
+			return errs.NewValidationError("invalid change type", map[string]any{
+				"allowed_values": []string{policy.ChangeVersionCreated, policy.ChangeMetadataUpdated, policy.ChangeDocsChanged, policy.ChangeStateChanged},
+				"provided":       changeType,
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package changes
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/logging"
+)
+
+// Watcher tracks the head of the change log and wakes clients waiting for new changes.
+// It polls the database so that changes written by any instance are noticed, and only while clients wait.
+type Watcher struct {
+	repo         Repository
+	logger       *logging.Logger
+	pollInterval time.Duration
+
+	mu      sync.Mutex
+	latest  int64
+	changed chan struct{} // Closed and replaced whenever latest advances
+
+	waiters atomic.Int32
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewWatcher creates a new change log watcher; call Start to begin polling
+func NewWatcher(repo Repository, cfg *config.ChangesConfig, logger *logging.Logger) *Watcher {
+	return &Watcher{
+		repo:         repo,
+		logger:       logger,
+		pollInterval: time.Duration(cfg.PollIntervalSeconds) * time.Second,
+		changed:      make(chan struct{}),
+		stop:         make(chan struct{}),
+	}
+}
+
+// Start launches the polling loop
+func (w *Watcher) Start() {
+	w.wg.Add(1)
+	go w.run()
+}
+
+// Stop ends the polling loop and releases all waiting clients
+func (w *Watcher) Stop() {
+	close(w.stop)
+	w.wg.Wait()
+	w.logger.Info("Change feed watcher stopped")
+}
+
+// Done is closed once the watcher has been stopped
+func (w *Watcher) Done() <-chan struct{} {
+	return w.stop
+}
+
+// Wait blocks until a change after cursor is known, reporting false if ctx ends or the watcher stops first
+func (w *Watcher) Wait(ctx context.Context, cursor int64) bool {
+	w.waiters.Add(1)
+	defer w.waiters.Add(-1)
+
+	for {
+		w.mu.Lock()
+		latest, changed := w.latest, w.changed
+		w.mu.Unlock()
+
+		if latest > cursor {
+			return true
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		case <-w.stop:
+			return false
+		}
+	}
+}
+
+func (w *Watcher) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+
+		if w.waiters.Load() == 0 {
+			continue
+		}
+
+		latest, err := w.repo.GetLatestChangeID(context.Background())
+		if err != nil {
+			w.logger.Error("Failed to poll change log", zap.Error(err))
+			continue
+		}
+
+		w.mu.Lock()
+		if latest > w.latest {
+			w.latest = latest
+			close(w.changed)
+			w.changed = make(chan struct{})
+		}
+		w.mu.Unlock()
+	}
+}
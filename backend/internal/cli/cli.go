@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/db"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/policy"
+)
+
+// command is a policyhub subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+var commands = []command{
+	{name: "export", summary: "Export catalog content as a signed offline bundle", run: runExport},
+	{name: "import", summary: "Import an offline bundle into the catalog", run: runImport},
+	{name: "publish", summary: "Validate, package and publish a local policy project", run: runPublish},
+}
+
+// Run runs the subcommand named by args[0] and returns the process exit code
+func Run(args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(os.Stdout)
+		return 0
+	}
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:])
+		}
+	}
+	fmt.Fprintf(os.Stderr, "policyhub: unknown command %q\n\n", args[0])
+	usage(os.Stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: policyhub [command] [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Without a command the API server is started. Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'policyhub <command> -h' for the flags of a command.")
+}
+
+// env holds the services a command runs against; commands share the server's configuration
+type env struct {
+	cfg           *config.Config
+	logger        *logging.Logger
+	database      *db.DB
+	policyService *policy.Service
+}
+
+// connect loads the configuration and connects to the catalog database
+func connect() (*env, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return connectWith(cfg)
+}
+
+// loadConfig loads the server's configuration
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// connectWith connects to the catalog database of a loaded configuration. Commands log warnings and
+// errors only so that their own output stays readable.
+func connectWith(cfg *config.Config) (*env, error) {
+	logger, err := logging.NewLogger("warn", cfg.Logging.Format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	database, err := db.NewDB(&cfg.Database, logger)
+	if err != nil {
+		logger.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	tagNormalizer := policy.NewTagNormalizer(cfg.Catalog.TagSynonyms)
+	if err := db.CreateSchema(database.Pool, tagNormalizer, logger.Logger); err != nil {
+		database.Close()
+		logger.Close()
+		return nil, fmt.Errorf("failed to create database schema: %w", err)
+	}
+
+	policyRepo := policy.NewSQLCRepository(database)
+	return &env{
+		cfg:           cfg,
+		logger:        logger,
+		database:      database,
+		policyService: policy.NewService(policyRepo, tagNormalizer, logger),
+	}, nil
+}
+
+func (e *env) close() {
+	e.database.Close()
+	e.logger.Close()
+}
+
+// fail reports a command error and returns the failure exit code; application errors include their details
+func fail(name string, err error) int {
+	var appErr *errs.AppError
+	if errors.As(err, &appErr) {
+		fmt.Fprintf(os.Stderr, "policyhub %s: %s (%s)\n", name, appErr.Message, appErr.Code)
+		for key, value := range appErr.Details {
+			fmt.Fprintf(os.Stderr, "  %s: %v\n", key, value)
+		}
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "policyhub %s: %v\n", name, err)
+	return 1
+}
+
+// splitList splits a comma-separated flag value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// interruptible returns a context that is cancelled when the command is interrupted
+func interruptible() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/wso2/policyhub/internal/artifacts"
+	"github.com/wso2/policyhub/internal/bundle"
+	"github.com/wso2/policyhub/internal/sync"
+)
+
+// runExport writes the selected catalog content to a signed bundle file
+func runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	output := fs.String("o", "policyhub-bundle.tar.gz", "bundle file to write")
+	policies := fs.String("policies", "", "comma-separated policy names to export (default all)")
+	provider := fs.String("provider", "", "export only policies of this provider")
+	platform := fs.String("platform", "", "export only versions supporting this platform")
+	versions := fs.String("versions", "", "version constraint, e.g. latest, 1.x, ^1.2.0 or >=1.0.0,<2.0.0")
+	noArtifacts := fs.Bool("no-artifacts", false, "reference download artifacts instead of mirroring them")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: policyhub export [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	e, err := connect()
+	if err != nil {
+		return fail("export", err)
+	}
+	defer e.close()
+
+	syncService := sync.NewService(e.policyService, artifacts.NewStore(&e.cfg.Artifacts), &e.cfg.Sync, &e.cfg.Fetch, e.logger)
+	exporter, err := bundle.NewExporter(e.policyService, syncService, &e.cfg.Bundle, e.logger)
+	if err != nil {
+		return fail("export", err)
+	}
+
+	ctx, cancel := interruptible()
+	defer cancel()
+
+	b, err := exporter.Prepare(ctx, bundle.Selection{
+		PolicyNames:       splitList(*policies),
+		Provider:          *provider,
+		Platform:          *platform,
+		VersionConstraint: *versions,
+		Artifacts:         !*noArtifacts,
+	})
+	if err != nil {
+		return fail("export", err)
+	}
+	defer b.Close()
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return fail("export", err)
+	}
+	if err := b.Write(f); err != nil {
+		f.Close()
+		os.Remove(*output)
+		return fail("export", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(*output)
+		return fail("export", err)
+	}
+
+	summary := b.Summary()
+	fmt.Printf("Exported %d versions (%d files, %d artifacts) to %s, signed with key %s\n",
+		summary.Versions, summary.Files, summary.Artifacts, *output, summary.KeyID)
+	return 0
+}
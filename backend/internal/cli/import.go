@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/wso2/policyhub/internal/artifacts"
+	"github.com/wso2/policyhub/internal/bundle"
+)
+
+// runImport loads a bundle file into the catalog
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "verify the bundle and report what would be imported without writing anything")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: policyhub import [flags] <bundle.tar.gz>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fail("import", err)
+	}
+	defer f.Close()
+
+	e, err := connect()
+	if err != nil {
+		return fail("import", err)
+	}
+	defer e.close()
+
+	importer, err := bundle.NewImporter(e.policyService, artifacts.NewStore(&e.cfg.Artifacts), &e.cfg.Bundle, e.logger)
+	if err != nil {
+		return fail("import", err)
+	}
+
+	ctx, cancel := interruptible()
+	defer cancel()
+
+	report, err := importer.Import(ctx, f, *dryRun)
+	if err != nil {
+		return fail("import", err)
+	}
+
+	switch {
+	case report.SignatureVerified:
+		fmt.Printf("Signature verified (key %s)\n", report.KeyID)
+	case report.KeyID != "":
+		fmt.Printf("Signed with key %s; not verified because BUNDLE_VERIFY_KEY_FILE is unset\n", report.KeyID)
+	default:
+		fmt.Println("Bundle is not signed")
+	}
+	verb := "Added"
+	if report.DryRun {
+		verb = "Would add"
+	}
+	for _, item := range report.Added {
+		latest := ""
+		if item.IsLatest {
+			latest = " (latest)"
+		}
+		fmt.Printf("  %-9s %s %s%s\n", "added", item.PolicyName, item.Version, latest)
+	}
+	for _, item := range report.Skipped {
+		fmt.Printf("  %-9s %s %s: %s\n", "skipped", item.PolicyName, item.Version, item.Reason)
+	}
+	for _, item := range report.Rejected {
+		fmt.Printf("  %-9s %s %s: %s\n", "rejected", item.PolicyName, item.Version, item.Reason)
+	}
+	fmt.Printf("%s %d versions; skipped %d duplicates; rejected %d\n",
+		verb, len(report.Added), len(report.Skipped), len(report.Rejected))
+
+	if len(report.Rejected) > 0 {
+		return 1
+	}
+	return 0
+}
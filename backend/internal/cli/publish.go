@@ -0,0 +1,235 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/policyhub/internal/artifacts"
+	"github.com/wso2/policyhub/internal/policy"
+	"github.com/wso2/policyhub/internal/project"
+	"github.com/wso2/policyhub/internal/sync"
+)
+
+// runPublish validates, packages and publishes a local policy project directory
+func runPublish(args []string) int {
+	fs := flag.NewFlagSet("publish", flag.ContinueOnError)
+	name := fs.String("name", "", "policy name (default: name in metadata.json)")
+	version := fs.String("version", "", "policy version (default: version in metadata.json)")
+	definition := fs.String("definition", "", "definition file, relative to the project (default: policy-definition.yaml, .yml or .json)")
+	sourceType := fs.String("source-type", "local", "source type recorded for the version")
+	downloadURL := fs.String("download-url", "", "URL the package is downloaded from (default: under ARTIFACTS_BASE_URL)")
+	assetsBaseURL := fs.String("assets-base-url", "", "URL the assets directory is served at; images/ references in docs are rewritten to it")
+	output := fs.String("o", "", "package file to write (default: <name>-<version>.tar.gz)")
+	dryRun := fs.Bool("dry-run", false, "validate and package without writing the package or publishing")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: policyhub publish [flags] <project-dir>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	dir := fs.Arg(0)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fail("publish", err)
+	}
+
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return fail("publish", err)
+	}
+	defer root.Close()
+
+	proj, err := project.Read(root.FS(), *definition)
+	if err != nil {
+		return fail("publish", err)
+	}
+
+	req := &sync.SyncRequest{
+		PolicyName:     valueOr(*name, proj.Metadata.Name),
+		Version:        valueOr(*version, proj.Metadata.Version),
+		SourceType:     *sourceType,
+		DownloadURL:    *downloadURL,
+		Metadata:       &proj.Metadata.PolicyMetadata,
+		AssetsBaseURL:  *assetsBaseURL,
+		DefinitionYAML: string(proj.Definition),
+		InlineDocs:     proj.Docs,
+	}
+	packageName := req.PolicyName + "-" + req.Version + ".tar.gz"
+
+	// Without a download URL the package is published to the hub's artifact store
+	var store *artifacts.Store
+	if req.DownloadURL == "" {
+		if s := artifacts.NewStore(&cfg.Artifacts); s.Addressable() {
+			store = s
+			req.DownloadURL = store.URL(req.PolicyName, req.Version, packageName)
+		}
+	}
+
+	// Validate everything locally before packaging
+	if appErr := req.Validate(); appErr != nil {
+		if req.DownloadURL == "" {
+			fmt.Fprintln(os.Stderr, "Pass --download-url, or set ARTIFACTS_DIR and ARTIFACTS_BASE_URL to publish the package to the hub")
+		}
+		return fail("publish", appErr)
+	}
+
+	pkgFile := valueOr(*output, packageName)
+	var pkg *os.File
+	var w io.Writer = io.Discard
+	if !*dryRun {
+		if pkg, err = os.CreateTemp(filepath.Dir(pkgFile), ".policyhub-package-"); err != nil {
+			return fail("publish", err)
+		}
+		defer os.Remove(pkg.Name())
+		defer pkg.Close()
+		w = pkg
+	}
+	digest, size, err := project.Pack(root.FS(), w, skipPath(dir, pkgFile))
+	if err != nil {
+		return fail("publish", err)
+	}
+	req.Checksum = &policy.Checksum{Algorithm: "sha256", Value: digest}
+
+	printPublishReport(req, proj, pkgFile, size, *dryRun)
+	if *dryRun {
+		fmt.Println("Dry run: the project is valid; nothing was written or published")
+		return 0
+	}
+
+	e, err := connectWith(cfg)
+	if err != nil {
+		return fail("publish", err)
+	}
+	defer e.close()
+
+	if err := pkg.Close(); err != nil {
+		return fail("publish", err)
+	}
+	if err := os.Rename(pkg.Name(), pkgFile); err != nil {
+		return fail("publish", err)
+	}
+	// A package already in the store is left alone; re-publishing is only accepted when its
+	// checksum matches the stored version
+	stored := false
+	if store != nil {
+		err := storePackage(store, pkgFile, req.PolicyName, req.Version, packageName)
+		if err != nil && !errors.Is(err, artifacts.ErrExists) {
+			return fail("publish", err)
+		}
+		stored = err == nil
+	}
+
+	ctx, cancel := interruptible()
+	defer cancel()
+
+	syncService := sync.NewService(e.policyService, artifacts.NewStore(&e.cfg.Artifacts), &e.cfg.Sync, &e.cfg.Fetch, e.logger)
+	result, err := syncService.SyncPolicy(ctx, req)
+	if err != nil {
+		if stored {
+			store.Remove(req.PolicyName, req.Version, packageName)
+		}
+		return fail("publish", err)
+	}
+	if result.Status == sync.StatusUnchanged {
+		fmt.Printf("%s %s is already published with identical content\n", req.PolicyName, req.Version)
+		return 0
+	}
+	fmt.Printf("Published %s %s\n", req.PolicyName, req.Version)
+	return 0
+}
+
+// printPublishReport describes what is published
+func printPublishReport(req *sync.SyncRequest, proj *project.Project, pkgFile string, size int64, dryRun bool) {
+	pages := proj.Pages()
+	fmt.Printf("Policy:      %s %s (%s)\n", req.PolicyName, req.Version, req.Metadata.DisplayName)
+	fmt.Printf("Provider:    %s\n", req.Metadata.Provider)
+	fmt.Printf("Categories:  %s\n", strings.Join(req.Metadata.Categories, ", "))
+	fmt.Printf("Platforms:   %s\n", strings.Join(req.Metadata.SupportedPlatforms, ", "))
+	fmt.Printf("Definition:  %s (%d bytes)\n", proj.DefinitionFile, len(proj.Definition))
+	fmt.Printf("Docs:        %d pages %s\n", len(pages), strings.Join(pages, ", "))
+	fmt.Printf("Assets:      %d files\n", len(proj.Assets))
+	if dryRun {
+		fmt.Printf("Package:     %d bytes, sha256 %s\n", size, req.Checksum.Value)
+	} else {
+		fmt.Printf("Package:     %s (%d bytes, sha256 %s)\n", pkgFile, size, req.Checksum.Value)
+	}
+	fmt.Printf("Download:    %s\n", req.DownloadURL)
+}
+
+// skipPath leaves the package being written out of the package when it lies inside the project
+func skipPath(dir, file string) func(string) bool {
+	absDir, err1 := filepath.Abs(dir)
+	absFile, err2 := filepath.Abs(file)
+	if err1 != nil || err2 != nil {
+		return nil
+	}
+	rel, err := filepath.Rel(absDir, absFile)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil
+	}
+	rel = filepath.ToSlash(rel)
+	return func(name string) bool { return name == rel }
+}
+
+// storePackage copies a package into the artifact store as <name>/<version>/<file>
+func storePackage(store *artifacts.Store, pkgFile, policyName, version, packageName string) error {
+	stagingDir, err := store.TempDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	staged := filepath.Join(stagingDir, packageName)
+	if err := copyFile(pkgFile, staged); err != nil {
+		return err
+	}
+	if err := store.Commit(staged, policyName, version, packageName); err != nil {
+		return fmt.Errorf("failed to store package: %w", err)
+	}
+	return nil
+}
+
+// copyFile copies a file
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func valueOr(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
@@ -10,8 +10,12 @@
 package config
 
 import (
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -20,10 +24,23 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	CORS     CORSConfig
-	Logging  LoggingConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	CORS      CORSConfig
+	Logging   LoggingConfig
+	Catalog   CatalogConfig
+	Stats     StatsConfig
+	Sync      SyncConfig
+	Fetch     FetchConfig
+	SyncJobs  SyncJobsConfig
+	Crawler   CrawlerConfig
+	Reconcile ReconcileConfig
+	Webhook   WebhookConfig
+	Events    EventsConfig
+	Changes   ChangesConfig
+	Feeds     FeedsConfig
+	Bundle    BundleConfig
+	Artifacts ArtifactsConfig
 }
 
 // ServerConfig holds server-related configuration
@@ -50,12 +67,182 @@ type CORSConfig struct {
 	AllowOrigins []string
 }
 
+// CatalogConfig holds catalog content configuration
+type CatalogConfig struct {
+	// TagSynonyms maps alternative tag spellings to their canonical tag
+	TagSynonyms map[string]string
+}
+
+// StatsConfig holds usage statistics configuration
+type StatsConfig struct {
+	Enabled              bool
+	BufferSize           int // Maximum number of events queued before new ones are dropped
+	BatchSize            int // Number of distinct counters that triggers an early flush
+	FlushIntervalSeconds int
+}
+
+// SyncConfig holds policy synchronization configuration
+type SyncConfig struct {
+	BatchConcurrency int // Number of batch items fetched in parallel
+
+	// Package publishing limits
+	MaxPackageSizeMB      int
+	MaxExtractedSizeMB    int // Total size of the files extracted from one package
+	PackageTimeoutSeconds int // Time limit for downloading a package from a URL
+}
+
+// FetchConfig restricts outbound fetches of caller-provided sync URLs
+type FetchConfig struct {
+	AllowedHosts         []string       // Hosts that may be fetched from; "*.example.com" matches example.com and its subdomains. Empty allows any host
+	AllowPrivateNetworks bool           // Allow loopback, private and link-local targets; for local development only
+	FileRoots            []string       // Directories that file:// URLs and local git repositories may be read from; empty disables them
+	MaxRedirects         int            // Redirects followed per fetch
+	MaxBodySizeKB        int            // Response body limit for media types without their own limit
+	MaxBodySizesKB       map[string]int // Response body limits per media type
+	GenericMediaTypes    []string       // Media types accepted for any fetched content besides the expected ones, e.g. text/plain
+	Concurrency          int            // Doc pages of one sync fetched in parallel
+	Retries              int            // Retries of a fetch that failed with a transient error
+	RetryBackoffMs       int            // Delay before the first retry; doubled per retry, with jitter
+
+	ProxyURL        string // HTTP(S) proxy for fetches; empty connects directly
+	CABundleFile    string // PEM file of CA certificates trusted in addition to the system roots
+	CABundle        []byte
+	CredentialsFile string // JSON file listing credentials per host
+	Credentials     []FetchCredential
+}
+
+// Types of fetch credentials
+const (
+	CredentialBearer = "bearer" // Authorization: Bearer <secret>
+	CredentialBasic  = "basic"  // Basic auth with the username and the secret as password
+	CredentialHeader = "header" // The secret as the value of a custom header
+)
+
+// FetchCredential authenticates fetches from one host. The secret is read from an environment
+// variable or a file when the configuration is loaded.
+type FetchCredential struct {
+	Host       string `json:"host"` // Host name; "*.example.com" matches example.com and its subdomains
+	Type       string `json:"type"`
+	Username   string `json:"username,omitempty"` // For basic credentials
+	Header     string `json:"header,omitempty"`   // Header name for header credentials
+	SecretEnv  string `json:"secretEnv,omitempty"`
+	SecretFile string `json:"secretFile,omitempty"`
+	Secret     Secret `json:"-"`
+}
+
+// Secret is a credential value. It is redacted when formatted or marshalled so that it cannot
+// reach logs by accident.
+type Secret string
+
+func (Secret) String() string   { return "[REDACTED]" }
+func (Secret) GoString() string { return "[REDACTED]" }
+
+func (Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"[REDACTED]"`), nil
+}
+
+// SyncJobsConfig holds asynchronous sync job configuration
+type SyncJobsConfig struct {
+	Workers               int
+	MaxAttempts           int
+	InitialBackoffSeconds int // Delay before the first retry; doubled for every further attempt
+	MaxBackoffSeconds     int
+	PollIntervalSeconds   int
+	TimeoutSeconds        int // Running jobs older than this are considered abandoned and requeued
+}
+
+// CrawlerConfig holds catalog crawler configuration
+type CrawlerConfig struct {
+	Source              string // HTTP(S) base URL or local directory of the policy repository; empty disables the crawler
+	IndexFile           string // Manifest file name, relative to Source
+	DownloadURLTemplate string // Fallback download URL with {name} and {version} placeholders
+	SourceType          string // Source type recorded for versions whose manifest entry has none
+	IntervalMinutes     int    // Scheduled crawl interval; 0 crawls on demand only
+}
+
+// ReconcileConfig holds configuration of the reconciler that refreshes synced versions from their sources
+type ReconcileConfig struct {
+	IntervalMinutes int  // Scheduled reconciliation interval; 0 reconciles on demand only
+	BatchSize       int  // Versions reconciled per run, least recently reconciled first
+	CheckArtifacts  bool // Download artifacts with a SHA-256 checksum to detect drift
+}
+
+// WebhookConfig holds inbound repository webhook configuration
+type WebhookConfig struct {
+	Secret      string // HMAC secret for X-Hub-Signature-256; empty disables the webhook
+	SourcesFile string // JSON file listing the repositories accepted by the webhook
+	Sources     []WebhookSource
+}
+
+// WebhookSource maps release tags of one repository to policy versions.
+// URL templates may use the {repository}, {tag}, {name} and {version} placeholders.
+type WebhookSource struct {
+	Repository            string            `json:"repository"`           // owner/name
+	PolicyName            string            `json:"policyName,omitempty"` // Fixed policy name for single-policy repositories
+	TagPattern            string            `json:"tagPattern,omitempty"` // Regular expression with "name" and "version" groups
+	SourceType            string            `json:"sourceType,omitempty"`
+	MetadataURLTemplate   string            `json:"metadataUrl"`
+	DefinitionURLTemplate string            `json:"definitionUrl"`
+	DownloadURLTemplate   string            `json:"downloadUrl"`
+	AssetsURLTemplate     string            `json:"assetsBaseUrl,omitempty"`
+	DocsURLTemplates      map[string]string `json:"docs,omitempty"` // Page name to URL template
+}
+
+// Default tag patterns for multi-policy (<name>-v<version>) and single-policy (v<version>) repositories
+const (
+	DefaultWebhookTagPattern       = `^(?P<name>[a-zA-Z0-9_-]+)-v?(?P<version>\d+\.\d+\.\d+)$`
+	DefaultWebhookSingleTagPattern = `^v?(?P<version>\d+\.\d+\.\d+)$`
+)
+
+// EventsConfig holds outbound webhook delivery configuration
+type EventsConfig struct {
+	Workers                int
+	MaxAttempts            int
+	InitialBackoffSeconds  int // Delay before the first retry; doubled for every further attempt
+	MaxBackoffSeconds      int
+	PollIntervalSeconds    int
+	DeliveryTimeoutSeconds int
+	AllowPrivateNetworks   bool // Allow subscriptions to loopback, private and link-local targets; for local development only
+}
+
 // LoggingConfig holds logging-related configuration
 func (d *DatabaseConfig) DSN() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		d.Host, d.Port, d.User, d.Password, d.Name, d.SSLMode)
 }
 
+// ChangesConfig holds change feed configuration
+type ChangesConfig struct {
+	PollIntervalSeconds int // Interval at which waiting feed clients check for new changes
+	MaxWaitSeconds      int // Upper bound for a long-poll wait
+	HeartbeatSeconds    int // Interval between keep-alive comments on idle event streams
+}
+
+// FeedsConfig holds release feed configuration
+type FeedsConfig struct {
+	APIURL        string // Public base URL of the API that self links and API entry links are built from
+	SiteURL       string // Base URL of the web portal that entries link to; API URLs are used when empty
+	Entries       int
+	MaxAgeSeconds int // Cache lifetime announced to feed readers and proxies
+}
+
+// BundleConfig holds offline catalog bundle configuration
+type BundleConfig struct {
+	SigningKeyFile         string // PEM-encoded Ed25519 private key (PKCS #8) that signs exported bundle indexes
+	ArtifactTimeoutSeconds int
+	MaxArtifactSizeMB      int
+
+	// Import settings
+	VerifyKeyFile   string // PEM-encoded Ed25519 public key; imported bundles must carry a valid signature by it
+	MaxImportSizeMB int
+}
+
+// ArtifactsConfig holds configuration of the artifact store for published packages and imported artifacts
+type ArtifactsConfig struct {
+	Dir     string // Directory artifacts are stored in; the hub serves it under /api/v1/artifacts
+	BaseURL string // Public URL of the stored artifacts; download URLs of stored artifacts are built from it
+}
+
 // LoggingConfig holds logging-related configuration
 type LoggingConfig struct {
 	Level  string
@@ -90,6 +277,120 @@ func Load() (*Config, error) {
 			Level:  getEnv("LOG_LEVEL", "debug"),
 			Format: getEnv("LOG_FORMAT", "json"),
 		},
+		Catalog: CatalogConfig{
+			TagSynonyms: parseKeyValuePairs(getEnv("TAG_SYNONYMS", "")),
+		},
+		Stats: StatsConfig{
+			Enabled:              getEnvAsBool("STATS_ENABLED", true),
+			BufferSize:           getEnvAsInt("STATS_BUFFER_SIZE", 10000),
+			BatchSize:            getEnvAsInt("STATS_BATCH_SIZE", 500),
+			FlushIntervalSeconds: getEnvAsInt("STATS_FLUSH_INTERVAL_SECONDS", 10),
+		},
+		Sync: SyncConfig{
+			BatchConcurrency:      getEnvAsInt("SYNC_BATCH_CONCURRENCY", 4),
+			MaxPackageSizeMB:      getEnvAsInt("SYNC_MAX_PACKAGE_SIZE_MB", 50),
+			MaxExtractedSizeMB:    getEnvAsInt("SYNC_MAX_EXTRACTED_SIZE_MB", 200),
+			PackageTimeoutSeconds: getEnvAsInt("SYNC_PACKAGE_TIMEOUT_SECONDS", 60),
+		},
+		Fetch: FetchConfig{
+			AllowedHosts:         parseList(getEnv("SYNC_FETCH_ALLOWED_HOSTS", "")),
+			AllowPrivateNetworks: getEnvAsBool("SYNC_FETCH_ALLOW_PRIVATE_NETWORKS", false),
+			FileRoots:            parseList(getEnv("SYNC_FETCH_FILE_ROOTS", "")),
+			MaxRedirects:         getEnvAsInt("SYNC_FETCH_MAX_REDIRECTS", 5),
+			MaxBodySizeKB:        getEnvAsInt("SYNC_FETCH_MAX_BODY_SIZE_KB", 1024),
+			MaxBodySizesKB:       parseSizes(getEnv("SYNC_FETCH_MAX_BODY_SIZES_KB", "")),
+			GenericMediaTypes:    parseList(getEnv("SYNC_FETCH_GENERIC_MEDIA_TYPES", "")),
+			Concurrency:          getEnvAsInt("SYNC_FETCH_CONCURRENCY", 4),
+			Retries:              getEnvAsInt("SYNC_FETCH_RETRIES", 2),
+			RetryBackoffMs:       getEnvAsInt("SYNC_FETCH_RETRY_BACKOFF_MS", 250),
+			ProxyURL:             getEnv("SYNC_FETCH_PROXY_URL", ""),
+			CABundleFile:         getEnv("SYNC_FETCH_CA_BUNDLE_FILE", ""),
+			CredentialsFile:      getEnv("SYNC_FETCH_CREDENTIALS_FILE", ""),
+		},
+		SyncJobs: SyncJobsConfig{
+			Workers:               getEnvAsInt("SYNC_WORKERS", 4),
+			MaxAttempts:           getEnvAsInt("SYNC_MAX_ATTEMPTS", 5),
+			InitialBackoffSeconds: getEnvAsInt("SYNC_RETRY_INITIAL_BACKOFF_SECONDS", 5),
+			MaxBackoffSeconds:     getEnvAsInt("SYNC_RETRY_MAX_BACKOFF_SECONDS", 300),
+			PollIntervalSeconds:   getEnvAsInt("SYNC_POLL_INTERVAL_SECONDS", 2),
+			TimeoutSeconds:        getEnvAsInt("SYNC_JOB_TIMEOUT_SECONDS", 300),
+		},
+		Crawler: CrawlerConfig{
+			Source:              getEnv("CRAWLER_SOURCE", ""),
+			IndexFile:           getEnv("CRAWLER_INDEX_FILE", "index.json"),
+			DownloadURLTemplate: getEnv("CRAWLER_DOWNLOAD_URL_TEMPLATE", ""),
+			SourceType:          getEnv("CRAWLER_SOURCE_TYPE", "index"),
+			IntervalMinutes:     getEnvAsInt("CRAWLER_INTERVAL_MINUTES", 0),
+		},
+		Reconcile: ReconcileConfig{
+			IntervalMinutes: getEnvAsInt("RECONCILE_INTERVAL_MINUTES", 60),
+			BatchSize:       getEnvAsInt("RECONCILE_BATCH_SIZE", 100),
+			CheckArtifacts:  getEnvAsBool("RECONCILE_CHECK_ARTIFACTS", true),
+		},
+		Webhook: WebhookConfig{
+			Secret:      getEnv("WEBHOOK_SECRET", ""),
+			SourcesFile: getEnv("WEBHOOK_SOURCES_FILE", ""),
+		},
+		Events: EventsConfig{
+			Workers:                getEnvAsInt("EVENTS_WORKERS", 2),
+			MaxAttempts:            getEnvAsInt("EVENTS_MAX_ATTEMPTS", 8),
+			InitialBackoffSeconds:  getEnvAsInt("EVENTS_RETRY_INITIAL_BACKOFF_SECONDS", 10),
+			MaxBackoffSeconds:      getEnvAsInt("EVENTS_RETRY_MAX_BACKOFF_SECONDS", 3600),
+			PollIntervalSeconds:    getEnvAsInt("EVENTS_POLL_INTERVAL_SECONDS", 2),
+			DeliveryTimeoutSeconds: getEnvAsInt("EVENTS_DELIVERY_TIMEOUT_SECONDS", 10),
+			AllowPrivateNetworks:   getEnvAsBool("EVENTS_ALLOW_PRIVATE_NETWORKS", false),
+		},
+		Changes: ChangesConfig{
+			PollIntervalSeconds: getEnvAsInt("CHANGES_POLL_INTERVAL_SECONDS", 1),
+			MaxWaitSeconds:      getEnvAsInt("CHANGES_MAX_WAIT_SECONDS", 30),
+			HeartbeatSeconds:    getEnvAsInt("CHANGES_HEARTBEAT_SECONDS", 15),
+		},
+		Feeds: FeedsConfig{
+			APIURL:        strings.TrimSuffix(getEnv("FEEDS_API_URL", ""), "/"),
+			SiteURL:       strings.TrimSuffix(getEnv("FEEDS_SITE_URL", ""), "/"),
+			Entries:       getEnvAsInt("FEEDS_ENTRIES", 50),
+			MaxAgeSeconds: getEnvAsInt("FEEDS_MAX_AGE_SECONDS", 300),
+		},
+		Artifacts: ArtifactsConfig{
+			Dir:     getEnv("ARTIFACTS_DIR", ""),
+			BaseURL: strings.TrimSuffix(getEnv("ARTIFACTS_BASE_URL", ""), "/"),
+		},
+		Bundle: BundleConfig{
+			SigningKeyFile:         getEnv("BUNDLE_SIGNING_KEY_FILE", ""),
+			ArtifactTimeoutSeconds: getEnvAsInt("BUNDLE_ARTIFACT_TIMEOUT_SECONDS", 120),
+			MaxArtifactSizeMB:      getEnvAsInt("BUNDLE_MAX_ARTIFACT_SIZE_MB", 100),
+			VerifyKeyFile:          getEnv("BUNDLE_VERIFY_KEY_FILE", ""),
+			MaxImportSizeMB:        getEnvAsInt("BUNDLE_MAX_IMPORT_SIZE_MB", 1024),
+		},
+	}
+
+	// Feed links never come from request headers, so they default to the local listener
+	if cfg.Feeds.APIURL == "" {
+		cfg.Feeds.APIURL = fmt.Sprintf("http://localhost:%d/api/v1", cfg.Server.Port)
+	}
+
+	if cfg.Webhook.SourcesFile != "" {
+		sources, err := loadWebhookSources(cfg.Webhook.SourcesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load webhook sources: %w", err)
+		}
+		cfg.Webhook.Sources = sources
+	}
+
+	if cfg.Fetch.CABundleFile != "" {
+		bundle, err := os.ReadFile(cfg.Fetch.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load sync fetch CA bundle: %w", err)
+		}
+		cfg.Fetch.CABundle = bundle
+	}
+
+	if cfg.Fetch.CredentialsFile != "" {
+		credentials, err := loadFetchCredentials(cfg.Fetch.CredentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load sync fetch credentials: %w", err)
+		}
+		cfg.Fetch.Credentials = credentials
 	}
 
 	// Validate configuration
@@ -150,9 +451,297 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid log format: %s (must be json or console)", c.Logging.Format)
 	}
 
+	// Validate stats configuration
+	if c.Stats.BufferSize < 1 {
+		return fmt.Errorf("invalid stats buffer size: %d (must be at least 1)", c.Stats.BufferSize)
+	}
+	if c.Stats.BatchSize < 1 {
+		return fmt.Errorf("invalid stats batch size: %d (must be at least 1)", c.Stats.BatchSize)
+	}
+	if c.Stats.FlushIntervalSeconds < 1 {
+		return fmt.Errorf("invalid stats flush interval: %d (must be at least 1 second)", c.Stats.FlushIntervalSeconds)
+	}
+
+	// Validate sync configuration
+	if c.Sync.BatchConcurrency < 1 {
+		return fmt.Errorf("invalid sync batch concurrency: %d (must be at least 1)", c.Sync.BatchConcurrency)
+	}
+	if c.Sync.MaxPackageSizeMB < 1 {
+		return fmt.Errorf("invalid sync max package size: %d (must be at least 1 MB)", c.Sync.MaxPackageSizeMB)
+	}
+	if c.Sync.MaxExtractedSizeMB < c.Sync.MaxPackageSizeMB {
+		return fmt.Errorf("invalid sync max extracted size: %d (must be at least the max package size)", c.Sync.MaxExtractedSizeMB)
+	}
+	if c.Sync.PackageTimeoutSeconds < 1 {
+		return fmt.Errorf("invalid sync package timeout: %d (must be at least 1 second)", c.Sync.PackageTimeoutSeconds)
+	}
+
+	// Validate fetch configuration
+	for _, host := range c.Fetch.AllowedHosts {
+		if !validHostPattern(host) {
+			return fmt.Errorf("invalid sync fetch allowed host: %s (wildcards are only allowed as a leading \"*.\")", host)
+		}
+	}
+	if c.Fetch.MaxRedirects < 0 {
+		return fmt.Errorf("invalid sync fetch max redirects: %d (must not be negative)", c.Fetch.MaxRedirects)
+	}
+	if c.Fetch.MaxBodySizeKB < 1 {
+		return fmt.Errorf("invalid sync fetch max body size: %d (must be at least 1 KB)", c.Fetch.MaxBodySizeKB)
+	}
+	for mediaType, size := range c.Fetch.MaxBodySizesKB {
+		if size < 1 {
+			return fmt.Errorf("invalid sync fetch max body size for %s (must be at least 1 KB)", mediaType)
+		}
+	}
+
+	if c.Fetch.Concurrency < 1 {
+		return fmt.Errorf("invalid sync fetch concurrency: %d (must be at least 1)", c.Fetch.Concurrency)
+	}
+	if c.Fetch.Retries < 0 {
+		return fmt.Errorf("invalid sync fetch retries: %d (must not be negative)", c.Fetch.Retries)
+	}
+	if c.Fetch.RetryBackoffMs < 1 {
+		return fmt.Errorf("invalid sync fetch retry backoff: %d (must be at least 1 ms)", c.Fetch.RetryBackoffMs)
+	}
+	if c.Fetch.ProxyURL != "" {
+		if u, err := url.Parse(c.Fetch.ProxyURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			// The URL is not printed as it may hold proxy credentials
+			return fmt.Errorf("invalid sync fetch proxy URL (must be an absolute http or https URL)")
+		}
+	}
+	if c.Fetch.CABundle != nil && !x509.NewCertPool().AppendCertsFromPEM(c.Fetch.CABundle) {
+		return fmt.Errorf("invalid sync fetch CA bundle: %s (no PEM certificates found)", c.Fetch.CABundleFile)
+	}
+	for i, credential := range c.Fetch.Credentials {
+		if err := credential.validate(); err != nil {
+			return fmt.Errorf("invalid sync fetch credential %d (%s): %w", i+1, credential.Host, err)
+		}
+	}
+
+	// Validate sync job configuration
+	if c.SyncJobs.Workers < 1 {
+		return fmt.Errorf("invalid sync workers: %d (must be at least 1)", c.SyncJobs.Workers)
+	}
+	if c.SyncJobs.MaxAttempts < 1 {
+		return fmt.Errorf("invalid sync max attempts: %d (must be at least 1)", c.SyncJobs.MaxAttempts)
+	}
+	if c.SyncJobs.InitialBackoffSeconds < 1 || c.SyncJobs.MaxBackoffSeconds < c.SyncJobs.InitialBackoffSeconds {
+		return fmt.Errorf("invalid sync retry backoff: initial %ds, max %ds", c.SyncJobs.InitialBackoffSeconds, c.SyncJobs.MaxBackoffSeconds)
+	}
+	if c.SyncJobs.PollIntervalSeconds < 1 {
+		return fmt.Errorf("invalid sync poll interval: %d (must be at least 1 second)", c.SyncJobs.PollIntervalSeconds)
+	}
+	if c.SyncJobs.TimeoutSeconds < 1 {
+		return fmt.Errorf("invalid sync job timeout: %d (must be at least 1 second)", c.SyncJobs.TimeoutSeconds)
+	}
+
+	// Validate crawler configuration
+	if c.Crawler.IntervalMinutes < 0 {
+		return fmt.Errorf("invalid crawler interval: %d (must be non-negative)", c.Crawler.IntervalMinutes)
+	}
+
+	// Validate reconciler configuration
+	if c.Reconcile.IntervalMinutes < 0 {
+		return fmt.Errorf("invalid reconcile interval: %d (must be non-negative)", c.Reconcile.IntervalMinutes)
+	}
+	if c.Reconcile.BatchSize < 1 {
+		return fmt.Errorf("invalid reconcile batch size: %d (must be at least 1)", c.Reconcile.BatchSize)
+	}
+
+	// Validate outbound webhook delivery configuration
+	if c.Events.Workers < 1 {
+		return fmt.Errorf("invalid events workers: %d (must be at least 1)", c.Events.Workers)
+	}
+	if c.Events.MaxAttempts < 1 {
+		return fmt.Errorf("invalid events max attempts: %d (must be at least 1)", c.Events.MaxAttempts)
+	}
+	if c.Events.InitialBackoffSeconds < 1 || c.Events.MaxBackoffSeconds < c.Events.InitialBackoffSeconds {
+		return fmt.Errorf("invalid events retry backoff: initial %ds, max %ds", c.Events.InitialBackoffSeconds, c.Events.MaxBackoffSeconds)
+	}
+	if c.Events.PollIntervalSeconds < 1 {
+		return fmt.Errorf("invalid events poll interval: %d (must be at least 1 second)", c.Events.PollIntervalSeconds)
+	}
+	if c.Events.DeliveryTimeoutSeconds < 1 {
+		return fmt.Errorf("invalid events delivery timeout: %d (must be at least 1 second)", c.Events.DeliveryTimeoutSeconds)
+	}
+
+	// Validate change feed configuration
+	if c.Changes.PollIntervalSeconds < 1 {
+		return fmt.Errorf("invalid changes poll interval: %d (must be at least 1 second)", c.Changes.PollIntervalSeconds)
+	}
+	if c.Changes.MaxWaitSeconds < 1 {
+		return fmt.Errorf("invalid changes max wait: %d (must be at least 1 second)", c.Changes.MaxWaitSeconds)
+	}
+	if c.Changes.HeartbeatSeconds < 1 {
+		return fmt.Errorf("invalid changes heartbeat interval: %d (must be at least 1 second)", c.Changes.HeartbeatSeconds)
+	}
+
+	// Validate release feed configuration
+	if u, err := url.Parse(c.Feeds.APIURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid feeds API URL: %s (must be an absolute http(s) URL)", c.Feeds.APIURL)
+	}
+	if c.Feeds.SiteURL != "" {
+		if u, err := url.Parse(c.Feeds.SiteURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid feeds site URL: %s (must be an absolute http(s) URL)", c.Feeds.SiteURL)
+		}
+	}
+	if c.Feeds.Entries < 1 || c.Feeds.Entries > 500 {
+		return fmt.Errorf("invalid feeds entries: %d (must be between 1 and 500)", c.Feeds.Entries)
+	}
+	if c.Feeds.MaxAgeSeconds < 0 {
+		return fmt.Errorf("invalid feeds max age: %d (must be non-negative)", c.Feeds.MaxAgeSeconds)
+	}
+
+	// Validate bundle configuration
+	if c.Bundle.ArtifactTimeoutSeconds < 1 {
+		return fmt.Errorf("invalid bundle artifact timeout: %d (must be at least 1 second)", c.Bundle.ArtifactTimeoutSeconds)
+	}
+	if c.Bundle.MaxArtifactSizeMB < 1 {
+		return fmt.Errorf("invalid bundle max artifact size: %d (must be at least 1 MB)", c.Bundle.MaxArtifactSizeMB)
+	}
+	if c.Bundle.MaxImportSizeMB < 1 {
+		return fmt.Errorf("invalid bundle max import size: %d (must be at least 1 MB)", c.Bundle.MaxImportSizeMB)
+	}
+
+	// Validate artifact store configuration
+	if c.Artifacts.BaseURL != "" {
+		if c.Artifacts.Dir == "" {
+			return fmt.Errorf("artifacts base URL requires an artifacts directory")
+		}
+		if u, err := url.Parse(c.Artifacts.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid artifacts base URL: %s (must be an absolute http or https URL)", c.Artifacts.BaseURL)
+		}
+	}
+
+	// Validate webhook sources
+	for _, source := range c.Webhook.Sources {
+		if err := source.validate(); err != nil {
+			return fmt.Errorf("invalid webhook source %q: %w", source.Repository, err)
+		}
+	}
+
+	return nil
+}
+
+// validate checks that a webhook source can map tags to complete sync requests
+func (s *WebhookSource) validate() error {
+	if s.Repository == "" {
+		return fmt.Errorf("repository is required")
+	}
+	if s.MetadataURLTemplate == "" || s.DefinitionURLTemplate == "" || s.DownloadURLTemplate == "" {
+		return fmt.Errorf("metadataUrl, definitionUrl and downloadUrl are required")
+	}
+
+	pattern, err := regexp.Compile(s.TagPattern)
+	if err != nil {
+		return fmt.Errorf("invalid tag pattern: %w", err)
+	}
+	if pattern.SubexpIndex("version") < 0 {
+		return fmt.Errorf("tag pattern must have a \"version\" group")
+	}
+	if s.PolicyName == "" && pattern.SubexpIndex("name") < 0 {
+		return fmt.Errorf("tag pattern must have a \"name\" group unless policyName is set")
+	}
+
 	return nil
 }
 
+// validate checks that a fetch credential is complete. Errors never include the secret.
+func (c *FetchCredential) validate() error {
+	if c.Host == "" {
+		return fmt.Errorf("host is required")
+	}
+	if !validHostPattern(c.Host) {
+		return fmt.Errorf("wildcards are only allowed as a leading \"*.\"")
+	}
+	switch c.Type {
+	case CredentialBearer:
+	case CredentialBasic:
+		if c.Username == "" {
+			return fmt.Errorf("basic credentials need a username")
+		}
+	case CredentialHeader:
+		if !headerNamePattern.MatchString(c.Header) {
+			return fmt.Errorf("header credentials need a valid header name")
+		}
+	default:
+		return fmt.Errorf("unknown type %q (must be bearer, basic or header)", c.Type)
+	}
+	if c.Secret == "" {
+		return fmt.Errorf("secret is empty")
+	}
+	return nil
+}
+
+// validHostPattern reports whether a host pattern is a host name or "*." followed by a domain
+func validHostPattern(pattern string) bool {
+	domain := strings.TrimPrefix(pattern, "*.")
+	return domain != "" && !strings.Contains(domain, "*")
+}
+
+// headerNamePattern matches HTTP header names
+var headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// loadFetchCredentials reads the fetch credential list and resolves every secret from its
+// environment variable or file
+func loadFetchCredentials(path string) ([]FetchCredential, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var credentials []FetchCredential
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return nil, err
+	}
+
+	for i := range credentials {
+		c := &credentials[i]
+		c.Host = strings.ToLower(c.Host)
+		switch {
+		case (c.SecretEnv == "") == (c.SecretFile == ""):
+			return nil, fmt.Errorf("credential %d (%s): exactly one of secretEnv or secretFile is required", i+1, c.Host)
+		case c.SecretEnv != "":
+			c.Secret = Secret(os.Getenv(c.SecretEnv))
+		default:
+			secret, err := os.ReadFile(c.SecretFile)
+			if err != nil {
+				return nil, fmt.Errorf("credential %d (%s): %w", i+1, c.Host, err)
+			}
+			// Secret files commonly end with a newline
+			c.Secret = Secret(strings.TrimRight(string(secret), "\r\n"))
+		}
+	}
+
+	return credentials, nil
+}
+
+// loadWebhookSources reads the webhook source list and applies default tag patterns
+func loadWebhookSources(path string) ([]WebhookSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []WebhookSource
+	if err := json.Unmarshal(data, &sources); err != nil {
+		return nil, err
+	}
+
+	for i := range sources {
+		if sources[i].TagPattern != "" {
+			continue
+		}
+		if sources[i].PolicyName != "" {
+			sources[i].TagPattern = DefaultWebhookSingleTagPattern
+		} else {
+			sources[i].TagPattern = DefaultWebhookTagPattern
+		}
+	}
+
+	return sources, nil
+}
+
 // getEnv gets an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -175,6 +764,19 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
+// getEnvAsBool gets an environment variable as a boolean or returns a default value
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 // parseAllowOrigins parses CORS allowed origins from environment variable
 func parseAllowOrigins(originsStr string) []string {
 	if originsStr == "" || originsStr == "*" {
@@ -189,3 +791,42 @@ func parseAllowOrigins(originsStr string) []string {
 
 	return origins
 }
+
+// parseList parses a comma-separated list, dropping empty entries
+func parseList(listStr string) []string {
+	var items []string
+	for _, item := range strings.Split(listStr, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// parseSizes parses comma-separated media type=size pairs. Sizes that are not integers are kept
+// as 0 so that validation reports them.
+func parseSizes(sizesStr string) map[string]int {
+	sizes := make(map[string]int)
+	for key, value := range parseKeyValuePairs(sizesStr) {
+		size, _ := strconv.Atoi(value)
+		sizes[strings.ToLower(key)] = size
+	}
+
+	return sizes
+}
+
+// parseKeyValuePairs parses comma-separated key=value pairs, skipping malformed entries
+func parseKeyValuePairs(pairsStr string) map[string]string {
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(pairsStr, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" {
+			continue
+		}
+		pairs[key] = value
+	}
+
+	return pairs
+}
@@ -0,0 +1,297 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/policy"
+	"github.com/wso2/policyhub/internal/runs"
+	syncPkg "github.com/wso2/policyhub/internal/sync"
+)
+
+// Crawler syncs the policy versions listed in a repository index that are missing from the catalog
+type Crawler struct {
+	policyService       *policy.Service
+	syncService         *syncPkg.Service
+	logger              *logging.Logger
+	location            string
+	source              source // nil when no source is configured
+	indexFile           string
+	downloadURLTemplate string
+	sourceType          string
+	interval            time.Duration
+	runs                *runs.Tracker[CrawlResult]
+}
+
+// NewCrawler creates a new crawler; call Start to enable scheduled crawls
+func NewCrawler(policyService *policy.Service, syncService *syncPkg.Service, cfg *config.CrawlerConfig, logger *logging.Logger) *Crawler {
+	c := &Crawler{
+		policyService:       policyService,
+		syncService:         syncService,
+		logger:              logger,
+		location:            cfg.Source,
+		indexFile:           cfg.IndexFile,
+		downloadURLTemplate: cfg.DownloadURLTemplate,
+		sourceType:          cfg.SourceType,
+		interval:            time.Duration(cfg.IntervalMinutes) * time.Minute,
+		runs:                runs.NewTracker(copyResult, func(r *CrawlResult, at time.Time) { r.FinishedAt = &at }),
+	}
+	if cfg.Source != "" {
+		c.source = newSource(cfg.Source, syncService)
+	}
+	return c
+}
+
+// Start launches the crawl schedule, if the crawler has a source and an interval
+func (c *Crawler) Start() {
+	if c.source == nil || c.interval <= 0 {
+		return
+	}
+
+	c.runs.Schedule(c.interval, func() *CrawlResult { return c.newResult(TriggerSchedule) }, c.crawl)
+
+	c.logger.Info("Catalog crawler scheduled",
+		zap.String("source", c.location),
+		zap.Duration("interval", c.interval))
+}
+
+// Stop aborts a running crawl and waits for it to exit
+func (c *Crawler) Stop(ctx context.Context) {
+	if !c.runs.Stop(ctx) {
+		c.logger.Warn("Catalog crawler did not stop before shutdown deadline")
+	}
+}
+
+// Trigger starts a crawl in the background and returns its initial state
+func (c *Crawler) Trigger() (*CrawlResult, error) {
+	if c.source == nil {
+		return nil, errs.CrawlerNotConfigured()
+	}
+
+	result := c.newResult(TriggerManual)
+	if !c.runs.Begin(result) {
+		return nil, errs.CrawlInProgress()
+	}
+
+	c.runs.Go(func() { c.crawl(result) })
+
+	return c.runs.Latest(), nil
+}
+
+// LastResult returns the state of the running crawl, or of the last finished one
+func (c *Crawler) LastResult() (*CrawlResult, error) {
+	if c.source == nil {
+		return nil, errs.CrawlerNotConfigured()
+	}
+
+	result := c.runs.Latest()
+	if result == nil {
+		return nil, errs.CrawlNotFound()
+	}
+	return result, nil
+}
+
+// newResult returns the initial result of a run started by trigger
+func (c *Crawler) newResult(trigger Trigger) *CrawlResult {
+	return &CrawlResult{
+		Source:    c.location,
+		Trigger:   trigger,
+		Status:    StatusRunning,
+		StartedAt: time.Now(),
+	}
+}
+
+// crawl syncs every listed version that is not in the catalog yet
+func (c *Crawler) crawl(result *CrawlResult) {
+	ctx := c.runs.Context()
+	c.logger.Info("Catalog crawl started", zap.String("source", c.location), zap.String("trigger", string(result.Trigger)))
+
+	manifest, err := c.loadManifest(ctx)
+	if err != nil {
+		c.runs.Finish(func() {
+			result.Status = StatusFailed
+			result.Error = errs.AsAppError(err)
+		})
+		c.logger.Error("Failed to load repository index", zap.String("source", c.location), zap.Error(err))
+		return
+	}
+
+	for _, p := range manifest.Policies {
+		for _, v := range p.Versions {
+			if ctx.Err() != nil {
+				c.runs.Finish(func() {
+					result.Status = StatusFailed
+					result.Error = errs.NewInternalError("crawl aborted", map[string]any{"error": ctx.Err().Error()})
+				})
+				return
+			}
+			c.syncVersion(ctx, result, p.Name, v)
+		}
+	}
+
+	c.runs.Finish(func() { result.Status = StatusCompleted })
+	c.logger.Info("Catalog crawl completed",
+		zap.String("source", c.location),
+		zap.Int("discovered", result.Discovered),
+		zap.Int("missing", result.Missing),
+		zap.Int("synced", result.Synced),
+		zap.Int("failed", result.Failed),
+		zap.Duration("duration", time.Since(result.StartedAt)))
+}
+
+// syncVersion syncs one listed version if it is missing and records the outcome
+func (c *Crawler) syncVersion(ctx context.Context, result *CrawlResult, policyName string, v ManifestVersion) {
+	c.runs.Update(func() { result.Discovered++ })
+
+	fail := func(err error) {
+		appErr := errs.AsAppError(err)
+		c.runs.Update(func() {
+			result.Failed++
+			result.Failures = append(result.Failures, CrawlFailure{PolicyName: policyName, Version: v.Version, Error: appErr})
+		})
+		c.logger.Warn("Failed to sync crawled policy version",
+			zap.String("policy", policyName),
+			zap.String("version", v.Version),
+			zap.String("error", appErr.Message))
+	}
+
+	exists, err := c.policyService.PolicyVersionExists(ctx, policyName, v.Version)
+	if err != nil {
+		fail(err)
+		return
+	}
+	if exists {
+		return
+	}
+	c.runs.Update(func() { result.Missing++ })
+
+	req, err := c.buildRequest(ctx, policyName, v)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	synced, err := c.syncService.SyncPolicy(ctx, req)
+	if err != nil {
+		if errs.AsAppError(err).Code == errs.CodePolicyVersionExists {
+			// Published by someone else since the existence check
+			return
+		}
+		fail(err)
+		return
+	}
+	if synced.Status == syncPkg.StatusUnchanged {
+		return
+	}
+	c.runs.Update(func() { result.Synced++ })
+}
+
+// buildRequest assembles the sync request for a listed version. Files of HTTP sources are
+// passed as URLs for the sync service to fetch; files of local sources are read inline.
+func (c *Crawler) buildRequest(ctx context.Context, policyName string, v ManifestVersion) (*syncPkg.SyncRequest, error) {
+	dir := v.Path
+	if dir == "" {
+		dir = path.Join(policyName, v.Version)
+	}
+	metadataFile := path.Join(dir, valueOr(v.Metadata, DefaultMetadataFile))
+	definitionFile := path.Join(dir, valueOr(v.Definition, DefaultDefinitionFile))
+
+	body, err := c.source.read(ctx, metadataFile)
+	if err != nil {
+		return nil, err
+	}
+	var metadata policy.PolicyMetadata
+	if err := json.Unmarshal(body, &metadata); err != nil {
+		return nil, errs.NewValidationError("invalid policy metadata", map[string]any{
+			"file":  metadataFile,
+			"error": err.Error(),
+		})
+	}
+
+	req := &syncPkg.SyncRequest{
+		PolicyName:  policyName,
+		Version:     v.Version,
+		SourceType:  valueOr(v.SourceType, c.sourceType),
+		DownloadURL: v.DownloadURL,
+		Metadata:    &metadata,
+		Checksum:    v.Checksum,
+	}
+	if req.DownloadURL == "" && c.downloadURLTemplate != "" {
+		req.DownloadURL = strings.NewReplacer("{name}", policyName, "{version}", v.Version).Replace(c.downloadURLTemplate)
+	}
+	if isAbsoluteURL(v.Assets) {
+		req.AssetsBaseURL = v.Assets
+	}
+
+	if definitionURL, ok := c.source.url(definitionFile); ok {
+		req.DefinitionURL = definitionURL
+		req.Documentation = make(map[string]string, len(v.Docs))
+		for page, file := range v.Docs {
+			req.Documentation[page], _ = c.source.url(path.Join(dir, file))
+		}
+		if v.Assets != "" && req.AssetsBaseURL == "" {
+			req.AssetsBaseURL, _ = c.source.url(path.Join(dir, v.Assets))
+		}
+		return req, nil
+	}
+
+	definition, err := c.source.read(ctx, definitionFile)
+	if err != nil {
+		return nil, err
+	}
+	req.DefinitionYAML = string(definition)
+
+	docs := v.Docs
+	if local, ok := c.source.(*dirSource); ok && len(docs) == 0 {
+		docs = local.docPages(dir)
+	}
+	req.InlineDocs = make(map[string]string, len(docs))
+	for page, file := range docs {
+		content, err := c.source.read(ctx, path.Join(dir, file))
+		if err != nil {
+			if !isNotFound(err) {
+				return nil, err
+			}
+			c.logger.Debug("Doc page not found", zap.String("docType", page), zap.String("path", file), zap.Error(err))
+			continue // Skip missing docs, as the sync service does for fetched pages
+		}
+		req.InlineDocs[page] = string(content)
+	}
+
+	return req, nil
+}
+
+// copyResult copies a crawl result with its failures
+func copyResult(r *CrawlResult) *CrawlResult {
+	result := *r
+	result.Failures = append([]CrawlFailure(nil), r.Failures...)
+	return &result
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func isAbsoluteURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
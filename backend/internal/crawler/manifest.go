@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/wso2/policyhub/internal/errs"
+)
+
+// loadManifest reads the repository index. A local directory without an index
+// is scanned for the <policy>/<version>/metadata.json layout instead.
+func (c *Crawler) loadManifest(ctx context.Context) (*Manifest, error) {
+	body, err := c.source.read(ctx, c.indexFile)
+	if err != nil {
+		if dir, ok := c.source.(*dirSource); ok && isNotFound(err) {
+			return dir.discover()
+		}
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, errs.NewValidationError("invalid repository index", map[string]any{
+			"file":  c.indexFile,
+			"error": err.Error(),
+		})
+	}
+	return &manifest, nil
+}
+
+// isNotFound reports whether a source read failed because the file does not exist
+func isNotFound(err error) bool {
+	var appErr *errs.AppError
+	return errors.As(err, &appErr) && appErr.Details["status"] == http.StatusNotFound
+}
+
+// discover builds a manifest from the version directories that contain a metadata file
+func (s *dirSource) discover() (*Manifest, error) {
+	root, err := os.OpenRoot(s.dir)
+	if err != nil {
+		return nil, errs.SyncFetchFailed(s.dir, err)
+	}
+	defer root.Close()
+	fsys := root.FS()
+
+	policies, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errs.SyncFetchFailed(s.dir, err)
+	}
+
+	manifest := &Manifest{}
+	for _, p := range policies {
+		if !p.IsDir() || strings.HasPrefix(p.Name(), ".") {
+			continue
+		}
+
+		versions, err := fs.ReadDir(fsys, p.Name())
+		if err != nil {
+			return nil, errs.SyncFetchFailed(path.Join(s.dir, p.Name()), err)
+		}
+
+		entry := ManifestPolicy{Name: p.Name()}
+		for _, v := range versions {
+			if !v.IsDir() {
+				continue
+			}
+			if _, err := fs.Stat(fsys, path.Join(p.Name(), v.Name(), DefaultMetadataFile)); err != nil {
+				continue
+			}
+			entry.Versions = append(entry.Versions, ManifestVersion{Version: v.Name()})
+		}
+
+		if len(entry.Versions) > 0 {
+			manifest.Policies = append(manifest.Policies, entry)
+		}
+	}
+
+	return manifest, nil
+}
+
+// docPages lists the markdown pages in the docs directory of a version, keyed by page name
+func (s *dirSource) docPages(versionDir string) map[string]string {
+	entries, err := s.readDir(path.Join(versionDir, DefaultDocsDir))
+	if err != nil {
+		return nil
+	}
+
+	pages := make(map[string]string)
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".md") {
+			pages[strings.TrimSuffix(e.Name(), ".md")] = path.Join(DefaultDocsDir, e.Name())
+		}
+	}
+	return pages
+}
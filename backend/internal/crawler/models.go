@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package crawler
+
+import (
+	"time"
+
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/policy"
+)
+
+// Default file layout of a policy version within a repository
+const (
+	DefaultMetadataFile   = "metadata.json"
+	DefaultDefinitionFile = "policy-definition.yaml"
+	DefaultDocsDir        = "docs"
+)
+
+// Trigger identifies what started a crawl
+type Trigger string
+
+const (
+	TriggerManual   Trigger = "manual"
+	TriggerSchedule Trigger = "schedule"
+)
+
+// Status represents the state of a crawl
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed" // The index could not be read
+)
+
+// Manifest is the repository index listing the published policies
+type Manifest struct {
+	Policies []ManifestPolicy `json:"policies"`
+}
+
+// ManifestPolicy lists the versions of one policy
+type ManifestPolicy struct {
+	Name     string            `json:"name"`
+	Versions []ManifestVersion `json:"versions"`
+}
+
+// ManifestVersion describes where the files of a policy version live.
+// Paths are relative to the version directory, which is itself relative to the repository root.
+type ManifestVersion struct {
+	Version     string            `json:"version"`
+	Path        string            `json:"path,omitempty"`       // Defaults to <name>/<version>
+	Metadata    string            `json:"metadata,omitempty"`   // Defaults to metadata.json
+	Definition  string            `json:"definition,omitempty"` // Defaults to policy-definition.yaml
+	Docs        map[string]string `json:"docs,omitempty"`       // Page name to markdown file
+	Assets      string            `json:"assets,omitempty"`     // Relative assets directory or absolute base URL
+	DownloadURL string            `json:"downloadUrl,omitempty"`
+	SourceType  string            `json:"sourceType,omitempty"`
+	Checksum    *policy.Checksum  `json:"checksum,omitempty"`
+}
+
+// CrawlFailure records a version that could not be synced
+type CrawlFailure struct {
+	PolicyName string
+	Version    string
+	Error      *errs.AppError
+}
+
+// CrawlResult summarizes a crawl
+type CrawlResult struct {
+	Source     string
+	Trigger    Trigger
+	Status     Status
+	StartedAt  time.Time
+	FinishedAt *time.Time
+	Discovered int // Versions listed in the index
+	Missing    int // Listed versions not yet in the catalog
+	Synced     int
+	Failed     int
+	Failures   []CrawlFailure
+	Error      *errs.AppError // Set when the index could not be read
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/fetch"
+	syncPkg "github.com/wso2/policyhub/internal/sync"
+)
+
+// maxFileSize bounds the size of any single file read from a local repository; files of HTTP
+// sources are bounded by the fetch limits
+const maxFileSize = 10 << 20
+
+// source reads repository files by slash-separated paths relative to the repository root
+type source interface {
+	read(ctx context.Context, name string) ([]byte, error)
+	// url returns the public URL of a file; local sources have none
+	url(name string) (string, bool)
+}
+
+// newSource returns an HTTP source for http(s) locations and a directory source otherwise
+func newSource(location string, syncService *syncPkg.Service) source {
+	if isAbsoluteURL(location) {
+		return &httpSource{
+			base:        strings.TrimSuffix(location, "/"),
+			syncService: syncService,
+		}
+	}
+	return &dirSource{dir: strings.TrimPrefix(location, "file://")}
+}
+
+// httpSource reads files below an HTTP base URL with the fetchers of the sync service, so that the
+// fetch restrictions, credentials and proxy of syncs apply
+type httpSource struct {
+	base        string
+	syncService *syncPkg.Service
+}
+
+func (s *httpSource) url(name string) (string, bool) {
+	return s.base + "/" + strings.TrimPrefix(path.Clean("/"+name), "/"), true
+}
+
+// read fetches a file as JSON; HTTP sources are only read for the index and the version metadata,
+// since definitions and docs are passed to the sync service as URLs
+func (s *httpSource) read(ctx context.Context, name string) ([]byte, error) {
+	url, _ := s.url(name)
+	return s.syncService.Fetch(ctx, "", url, fetch.JSON)
+}
+
+// dirSource reads files from a local directory; reads cannot escape the directory
+type dirSource struct {
+	dir string
+}
+
+func (s *dirSource) url(string) (string, bool) {
+	return "", false
+}
+
+func (s *dirSource) read(_ context.Context, name string) ([]byte, error) {
+	root, err := os.OpenRoot(s.dir)
+	if err != nil {
+		return nil, errs.SyncFetchFailed(name, err)
+	}
+	defer root.Close()
+
+	f, err := root.Open(path.Clean(name))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, errs.SyncFetchStatus(name, http.StatusNotFound)
+		}
+		return nil, errs.SyncFetchFailed(name, err)
+	}
+	defer f.Close()
+
+	// Read one byte past the limit so that a larger file fails instead of being cut off
+	body, err := io.ReadAll(io.LimitReader(f, maxFileSize+1))
+	if err != nil {
+		return nil, errs.SyncFetchFailed(name, err)
+	}
+	if len(body) > maxFileSize {
+		return nil, errs.SyncFetchRejected(name, fmt.Errorf("%w of %d bytes", fetch.ErrTooLarge, maxFileSize))
+	}
+	return body, nil
+}
+
+// readDir lists a directory below the source root
+func (s *dirSource) readDir(name string) ([]fs.DirEntry, error) {
+	root, err := os.OpenRoot(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+
+	return fs.ReadDir(root.FS(), path.Clean(name))
+}
@@ -0,0 +1,21 @@
+-- name: LockChangeLog :exec
+-- Serializes change log writers until commit so that ids become visible in ascending order
+SELECT pg_advisory_xact_lock(hashtext('change_log'));
+
+-- name: InsertChange :exec
+INSERT INTO change_log (
+    change_type, policy_name, version, payload, created_at
+) VALUES (
+    $1, $2, $3, $4, NOW()
+);
+
+-- name: GetLatestChangeID :one
+SELECT COALESCE(MAX(id), 0)::bigint AS latest_id FROM change_log;
+
+-- name: ListChanges :many
+SELECT * FROM change_log
+WHERE id > $1 AND id <= $2
+    AND ($3::text = '' OR policy_name = $3::text)
+    AND (cardinality($4::text[]) = 0 OR change_type = ANY($4::text[]))
+ORDER BY id
+LIMIT $5;
@@ -0,0 +1,22 @@
+-- name: InsertOutboxEvent :exec
+INSERT INTO event_outbox (
+    event_type, policy_name, version, provider, payload, created_at
+) VALUES (
+    $1, $2, $3, $4, $5, NOW()
+);
+
+-- name: ClaimOutboxEvents :many
+SELECT id FROM event_outbox
+WHERE dispatched_at IS NULL
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxEventsDispatched :exec
+UPDATE event_outbox
+SET dispatched_at = NOW()
+WHERE id = ANY($1::bigint[]);
+
+-- name: GetOutboxEvent :one
+SELECT * FROM event_outbox
+WHERE id = $1;
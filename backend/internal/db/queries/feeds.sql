@@ -0,0 +1,13 @@
+-- name: ListReleases :many
+SELECT id, policy_name, version, display_name, provider, description, categories, release_date, created_at
+FROM policy_version
+WHERE ($1::text = '' OR policy_name = $1::text)
+    AND ($2::text = '' OR provider = $2::text)
+    AND ($3::text = '' OR categories ? $3::text)
+ORDER BY COALESCE(release_date, created_at::date) DESC, created_at DESC, id DESC
+LIMIT $4;
+
+-- name: ListReleaseDocs :many
+SELECT policy_version_id, page, content_md, updated_at
+FROM policy_docs
+WHERE policy_version_id = ANY($1::int[]) AND page = ANY($2::text[]);
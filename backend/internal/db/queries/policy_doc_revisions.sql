@@ -0,0 +1,28 @@
+-- name: InsertPolicyDocRevision :exec
+INSERT INTO policy_doc_revision (policy_version_id, page, revision, content_md, origin, actor, created_at)
+VALUES (
+    $1, $2,
+    (SELECT COALESCE(MAX(revision), 0) + 1 FROM policy_doc_revision WHERE policy_version_id = $1 AND page = $2),
+    $3, $4, $5, NOW()
+);
+
+-- name: ListPolicyDocRevisions :many
+-- Returns the revisions of a page without their content, newest first
+SELECT id, policy_version_id, page, revision, origin, actor, octet_length(content_md)::int AS size, created_at
+FROM policy_doc_revision
+WHERE policy_version_id = $1 AND page = $2
+ORDER BY revision DESC;
+
+-- name: GetPolicyDocRevision :one
+SELECT * FROM policy_doc_revision
+WHERE policy_version_id = $1 AND page = $2 AND revision = $3;
+
+-- name: GetLatestPolicyDocRevision :one
+SELECT * FROM policy_doc_revision
+WHERE policy_version_id = $1 AND page = $2
+ORDER BY revision DESC
+LIMIT 1;
+
+-- name: LockPolicyDocPage :exec
+-- Serializes writers of one doc page until commit, so that they see each other's content and revisions
+SELECT pg_advisory_xact_lock($1::int, hashtext($2::text));
@@ -22,3 +22,17 @@ DO UPDATE SET
     content_md = EXCLUDED.content_md,
     updated_at = NOW()
 RETURNING *;
+
+-- name: ListFallbackPolicyDocs :many
+-- Returns, for each page, the doc of the closest release of a major line below the given minor and patch
+SELECT DISTINCT ON (d.page)
+    d.page,
+    d.content_md,
+    pv.version
+FROM policy_docs d
+JOIN policy_version pv ON pv.id = d.policy_version_id
+WHERE pv.policy_name = $1
+  AND pv.major_version = $2::INT
+  AND (pv.minor_version, pv.patch_version) < ($3::INT, $4::INT)
+  AND pv.version ~ '^\d+\.\d+\.\d+$'
+ORDER BY d.page, pv.minor_version DESC, pv.patch_version DESC;
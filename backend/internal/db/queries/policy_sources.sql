@@ -0,0 +1,30 @@
+-- name: InsertPolicySource :exec
+INSERT INTO policy_source (
+    policy_version_id, source_type, definition_url, doc_urls, assets_base_url, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, NOW(), NOW()
+);
+
+-- name: ListPolicySourcesDue :many
+-- Returns the sources not reconciled since the given time, least recently reconciled first
+SELECT * FROM policy_source
+WHERE reconciled_at IS NULL OR reconciled_at < $1
+ORDER BY reconciled_at NULLS FIRST, policy_version_id
+LIMIT $2;
+
+-- name: UpdatePolicySourceReconciled :exec
+-- Records the outcome of a reconciliation; drift_detected_at keeps the time drift was first seen
+UPDATE policy_source
+SET reconciled_at = NOW(),
+    drift = $2,
+    drift_detected_at = CASE WHEN $2 IS NULL THEN NULL ELSE COALESCE(drift_detected_at, NOW()) END,
+    last_error = $3,
+    updated_at = NOW()
+WHERE policy_version_id = $1;
+
+-- name: ListDriftedPolicySources :many
+SELECT pv.policy_name, pv.version, ps.policy_version_id, ps.drift, ps.drift_detected_at, ps.reconciled_at
+FROM policy_source ps
+JOIN policy_version pv ON pv.id = ps.policy_version_id
+WHERE ps.drift IS NOT NULL
+ORDER BY ps.drift_detected_at DESC, ps.policy_version_id DESC;
@@ -0,0 +1,56 @@
+-- name: IncrementUsageCounts :exec
+INSERT INTO policy_usage_daily (day, policy_name, version, event_type, count)
+SELECT unnest($1::date[]), unnest($2::text[]), unnest($3::text[]), unnest($4::text[]), unnest($5::bigint[])
+ON CONFLICT (day, policy_name, version, event_type)
+DO UPDATE SET count = policy_usage_daily.count + EXCLUDED.count;
+
+-- name: GetUsageTotals :one
+SELECT
+    COALESCE(SUM(count) FILTER (WHERE event_type = 'resolve'), 0)::bigint AS resolves,
+    COALESCE(SUM(count) FILTER (WHERE event_type = 'download'), 0)::bigint AS downloads
+FROM policy_usage_daily
+WHERE day >= $1::date
+    AND ($2::text = '' OR policy_name = $2::text);
+
+-- name: GetUsageTrend :many
+SELECT
+    day,
+    COALESCE(SUM(count) FILTER (WHERE event_type = 'resolve'), 0)::bigint AS resolves,
+    COALESCE(SUM(count) FILTER (WHERE event_type = 'download'), 0)::bigint AS downloads
+FROM policy_usage_daily
+WHERE day >= $1::date
+    AND ($2::text = '' OR policy_name = $2::text)
+GROUP BY day
+ORDER BY day;
+
+-- name: GetUsageByVersion :many
+SELECT
+    version,
+    COALESCE(SUM(count) FILTER (WHERE event_type = 'resolve'), 0)::bigint AS resolves,
+    COALESCE(SUM(count) FILTER (WHERE event_type = 'download'), 0)::bigint AS downloads
+FROM policy_usage_daily
+WHERE day >= $1::date
+    AND policy_name = $2::text
+GROUP BY version
+ORDER BY SUM(count) DESC, version;
+
+-- name: GetTopPolicies :many
+WITH totals AS (
+    SELECT
+        policy_name,
+        COALESCE(SUM(count) FILTER (WHERE event_type = 'resolve'), 0)::bigint AS resolves,
+        COALESCE(SUM(count) FILTER (WHERE event_type = 'download'), 0)::bigint AS downloads
+    FROM policy_usage_daily
+    WHERE day >= $1::date
+    GROUP BY policy_name
+)
+SELECT policy_name, resolves, downloads
+FROM totals
+ORDER BY
+    CASE
+        WHEN $2::text = 'resolves' THEN resolves
+        WHEN $2::text = 'downloads' THEN downloads
+        ELSE resolves + downloads
+    END DESC,
+    policy_name
+LIMIT $3;
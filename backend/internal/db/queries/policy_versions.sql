@@ -8,6 +8,10 @@
 SELECT * FROM policy_version
 WHERE policy_name = $1 AND version = $2;
 
+-- name: GetPolicyVersionByID :one
+SELECT * FROM policy_version
+WHERE id = $1;
+
 -- name: GetLatestPolicyVersion :one
 SELECT * FROM policy_version
 WHERE policy_name = $1 AND is_latest = TRUE;
@@ -19,13 +23,27 @@ WHERE policy_name = $1 AND is_latest = TRUE;
 -- name: ListPolicyVersions :many
 SELECT * FROM policy_version
 WHERE policy_name = $1
-ORDER BY created_at DESC
+ORDER BY created_at DESC, id DESC
 LIMIT $2 OFFSET $3;
 
+-- name: ListPolicyVersionsAfter :many
+SELECT * FROM policy_version
+WHERE policy_name = $1
+    AND ($2::timestamptz IS NULL OR (created_at, id) < ($2::timestamptz, $3::int))
+ORDER BY created_at DESC, id DESC
+LIMIT $4;
+
 -- name: CountPolicyVersions :one
 SELECT COUNT(*) FROM policy_version
 WHERE policy_name = $1;
 
+-- name: SelectPolicyVersions :many
+SELECT * FROM policy_version
+WHERE (cardinality($1::text[]) = 0 OR policy_name = ANY($1::text[]))
+    AND ($2::text = '' OR provider = $2::text)
+    AND ($3::text = '' OR supported_platforms ? $3::text)
+ORDER BY policy_name, major_version, minor_version, patch_version;
+
 -- name: FilterPoliciesByMultiple :many
 WITH ranked_versions AS (
     SELECT 
@@ -37,10 +55,7 @@ WITH ranked_versions AS (
                 pv.created_at DESC
         ) as version_rank
     FROM policy_version pv
-    WHERE ($1::text = '' OR LOWER(pv.display_name) LIKE LOWER('%' || $1 || '%') OR LOWER(pv.description) LIKE LOWER('%' || $1 || '%'))
-        AND ($2::text[] IS NULL OR array_length($2::text[], 1) = 0 OR EXISTS (SELECT 1 FROM unnest($2::text[]) AS cat WHERE pv.categories ? cat))
-        AND ($3::text[] IS NULL OR array_length($3::text[], 1) = 0 OR pv.provider = ANY($3::text[]))
-        AND ($4::text[] IS NULL OR array_length($4::text[], 1) = 0 OR EXISTS (SELECT 1 FROM unnest($4::text[]) AS plat WHERE pv.supported_platforms ? plat))
+    WHERE policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
 )
 SELECT 
     id, policy_name, version, is_latest, display_name, provider, description, 
@@ -49,16 +64,56 @@ SELECT
     created_at, updated_at
 FROM ranked_versions 
 WHERE version_rank = 1
-ORDER BY created_at DESC
-LIMIT $5 OFFSET $6;
+ORDER BY created_at DESC, id DESC
+LIMIT $7 OFFSET $8;
+
+-- name: FilterPoliciesByName :many
+-- Policies in name order, each represented by its latest matching version. The name keyset is
+-- applied before the representative is picked, so only the policies of the page are ranked.
+SELECT DISTINCT ON (pv.policy_name)
+    pv.id, pv.policy_name, pv.version, pv.is_latest, pv.display_name, pv.provider, pv.description,
+    pv.categories, pv.tags, pv.logo_path, pv.banner_path, pv.supported_platforms,
+    pv.release_date, pv.definition_yaml, pv.icon_path, pv.source_type, pv.download_url, pv.checksum,
+    pv.created_at, pv.updated_at
+FROM policy_version pv
+WHERE pv.policy_name > $7::text
+    AND policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
+ORDER BY pv.policy_name, pv.is_latest DESC, pv.created_at DESC, pv.id DESC
+LIMIT $8 OFFSET $9;
+
+-- name: FilterPoliciesByPopularity :many
+WITH ranked_versions AS (
+    SELECT 
+        pv.*,
+        ROW_NUMBER() OVER (
+            PARTITION BY pv.policy_name 
+            ORDER BY 
+                pv.is_latest DESC,
+                pv.created_at DESC
+        ) as version_rank
+    FROM policy_version pv
+    WHERE policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
+),
+usage AS (
+    SELECT policy_name, SUM(count)::bigint AS total
+    FROM policy_usage_daily
+    WHERE day >= $7::date
+    GROUP BY policy_name
+)
+SELECT 
+    rv.id, rv.policy_name, rv.version, rv.is_latest, rv.display_name, rv.provider, rv.description, 
+    rv.categories, rv.tags, rv.logo_path, rv.banner_path, rv.supported_platforms, 
+    rv.release_date, rv.definition_yaml, rv.icon_path, rv.source_type, rv.download_url, rv.checksum,
+    rv.created_at, rv.updated_at
+FROM ranked_versions rv
+LEFT JOIN usage u ON u.policy_name = rv.policy_name
+WHERE rv.version_rank = 1
+ORDER BY COALESCE(u.total, 0) DESC, rv.created_at DESC, rv.id DESC
+LIMIT $8 OFFSET $9;
 
 -- name: CountPoliciesByMultiple :one
 SELECT COUNT(DISTINCT pv.policy_name) FROM policy_version pv
-WHERE ($1::text = '' OR LOWER(pv.display_name) LIKE LOWER('%' || $1 || '%') OR LOWER(pv.description) LIKE LOWER('%' || $1 || '%'))
-    AND ($2::text[] IS NULL OR array_length($2::text[], 1) = 0 OR EXISTS (SELECT 1 FROM unnest($2::text[]) AS cat WHERE pv.categories ? cat))
-    AND ($3::text[] IS NULL OR array_length($3::text[], 1) = 0 OR pv.provider = ANY($3::text[]))
-    AND ($4::text[] IS NULL or array_length($4::text[], 1) = 0 OR EXISTS (SELECT 1 FROM unnest($4::text[]) AS plat WHERE pv.supported_platforms ? plat))
-;
+WHERE policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean);
 
 -- =============================================================================
 -- METADATA OPERATIONS
@@ -82,6 +137,60 @@ FROM policy_version
 WHERE supported_platforms IS NOT NULL AND jsonb_array_length(supported_platforms) > 0
 ORDER BY platform;
 
+-- name: GetDistinctTags :many
+SELECT DISTINCT tag::text AS tag
+FROM policy_version pv
+CROSS JOIN LATERAL jsonb_array_elements_text(
+    CASE WHEN jsonb_typeof(pv.tags) = 'array' THEN pv.tags ELSE '[]'::jsonb END
+) AS tag
+WHERE pv.is_latest = TRUE
+ORDER BY tag;
+
+-- =============================================================================
+-- FACET OPERATIONS (latest versions only)
+-- =============================================================================
+
+-- name: GetCategoryFacets :many
+SELECT facet::text AS value, COUNT(DISTINCT pv.policy_name) AS count
+FROM policy_version pv
+CROSS JOIN LATERAL jsonb_array_elements_text(
+    CASE WHEN jsonb_typeof(pv.categories) = 'array' THEN pv.categories ELSE '[]'::jsonb END
+) AS facet
+WHERE pv.is_latest = TRUE
+    AND policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
+GROUP BY facet
+ORDER BY count DESC, value;
+
+-- name: GetProviderFacets :many
+SELECT pv.provider AS value, COUNT(DISTINCT pv.policy_name) AS count
+FROM policy_version pv
+WHERE pv.is_latest = TRUE
+    AND policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
+GROUP BY pv.provider
+ORDER BY count DESC, value;
+
+-- name: GetPlatformFacets :many
+SELECT facet::text AS value, COUNT(DISTINCT pv.policy_name) AS count
+FROM policy_version pv
+CROSS JOIN LATERAL jsonb_array_elements_text(
+    CASE WHEN jsonb_typeof(pv.supported_platforms) = 'array' THEN pv.supported_platforms ELSE '[]'::jsonb END
+) AS facet
+WHERE pv.is_latest = TRUE
+    AND policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
+GROUP BY facet
+ORDER BY count DESC, value;
+
+-- name: GetTagFacets :many
+SELECT facet::text AS value, COUNT(DISTINCT pv.policy_name) AS count
+FROM policy_version pv
+CROSS JOIN LATERAL jsonb_array_elements_text(
+    CASE WHEN jsonb_typeof(pv.tags) = 'array' THEN pv.tags ELSE '[]'::jsonb END
+) AS facet
+WHERE pv.is_latest = TRUE
+    AND policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
+GROUP BY facet
+ORDER BY count DESC, value;
+
 -- =============================================================================
 -- POLICY VERSION MANAGEMENT
 -- =============================================================================
@@ -0,0 +1,98 @@
+-- name: CreateSyncJob :one
+INSERT INTO sync_job (
+    policy_name, version, status, request, max_attempts, next_attempt_at, created_at, updated_at
+) VALUES (
+    $1, $2, 'queued', $3, $4, NOW(), NOW(), NOW()
+)
+RETURNING *;
+
+-- name: ClaimSyncJob :one
+UPDATE sync_job
+SET status = 'running',
+    attempts = attempts + 1,
+    started_at = NOW(),
+    updated_at = NOW()
+WHERE id = (
+    SELECT id FROM sync_job
+    WHERE status = 'queued' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at, id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteSyncJob :execrows
+-- The outcome of an attempt is only recorded while that attempt still owns the job
+UPDATE sync_job
+SET status = 'succeeded',
+    result = $2,
+    last_error = NULL,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status = 'running' AND attempts = $3;
+
+-- name: FailSyncJob :execrows
+UPDATE sync_job
+SET status = 'failed',
+    last_error = $2,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status = 'running' AND attempts = $3;
+
+-- name: RetrySyncJob :execrows
+UPDATE sync_job
+SET status = 'queued',
+    last_error = $2,
+    next_attempt_at = $3,
+    updated_at = NOW()
+WHERE id = $1 AND status = 'running' AND attempts = $4;
+
+-- name: RequeueStaleSyncJobs :execrows
+UPDATE sync_job
+SET status = 'queued',
+    next_attempt_at = NOW(),
+    updated_at = NOW()
+WHERE status = 'running' AND started_at < $1 AND attempts < max_attempts;
+
+-- name: FailStaleSyncJobs :execrows
+-- Fails running jobs whose worker disappeared during their last allowed attempt
+UPDATE sync_job
+SET status = 'failed',
+    last_error = $2,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE status = 'running' AND started_at < $1 AND attempts >= max_attempts;
+
+-- name: GetSyncJob :one
+SELECT * FROM sync_job
+WHERE id = $1;
+
+-- name: ListSyncJobs :many
+SELECT * FROM sync_job
+WHERE ($1::text = '' OR status = $1::text)
+    AND ($2::text = '' OR policy_name = $2::text)
+ORDER BY created_at DESC, id DESC
+LIMIT $3 OFFSET $4;
+
+-- name: CountSyncJobs :one
+SELECT COUNT(*) FROM sync_job
+WHERE ($1::text = '' OR status = $1::text)
+    AND ($2::text = '' OR policy_name = $2::text);
+
+-- name: CreateSyncJobWithIdempotencyKey :one
+WITH job AS (
+    INSERT INTO sync_job (
+        policy_name, version, status, request, max_attempts, next_attempt_at, created_at, updated_at
+    ) VALUES (
+        $1, $2, 'queued', $3, $4, NOW(), NOW(), NOW()
+    )
+    RETURNING *
+), idempotency_key AS (
+    INSERT INTO sync_idempotency_key (idempotency_key, request_hash, sync_job_id, created_at)
+    SELECT $5, $6, id, NOW() FROM job
+)
+SELECT * FROM job;
+
+-- name: GetSyncIdempotencyKey :one
+SELECT * FROM sync_idempotency_key
+WHERE idempotency_key = $1;
@@ -0,0 +1,94 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscription (
+    url, secret, event_types, policy_name, provider, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, NOW(), NOW()
+)
+RETURNING *;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscription
+WHERE id = $1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscription
+ORDER BY id;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscription
+WHERE id = $1;
+
+-- name: CreateWebhookDeliveries :execrows
+INSERT INTO webhook_delivery (
+    event_id, subscription_id, status, max_attempts, next_attempt_at, created_at, updated_at
+)
+SELECT e.id, s.id, 'pending', $2::int, NOW(), NOW(), NOW()
+FROM event_outbox e
+JOIN webhook_subscription s
+    ON (jsonb_array_length(s.event_types) = 0 OR s.event_types @> jsonb_build_array(e.event_type))
+    AND (s.policy_name IS NULL OR s.policy_name = e.policy_name)
+    AND (s.provider IS NULL OR s.provider = e.provider)
+WHERE e.id = ANY($1::bigint[])
+ON CONFLICT (event_id, subscription_id) DO NOTHING;
+
+-- name: ClaimWebhookDelivery :one
+UPDATE webhook_delivery
+SET attempts = attempts + 1,
+    next_attempt_at = $1,
+    updated_at = NOW()
+WHERE id = (
+    SELECT id FROM webhook_delivery
+    WHERE status = 'pending' AND next_attempt_at <= NOW() AND attempts < max_attempts
+    ORDER BY next_attempt_at, id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteWebhookDelivery :execrows
+-- The outcome of an attempt is only recorded while that attempt still holds the delivery
+UPDATE webhook_delivery
+SET status = 'succeeded',
+    last_status_code = $2,
+    last_error = NULL,
+    delivered_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status = 'pending' AND attempts = $3;
+
+-- name: RetryWebhookDelivery :execrows
+UPDATE webhook_delivery
+SET last_status_code = $2,
+    last_error = $3,
+    next_attempt_at = $4,
+    updated_at = NOW()
+WHERE id = $1 AND status = 'pending' AND attempts = $5;
+
+-- name: FailWebhookDelivery :execrows
+UPDATE webhook_delivery
+SET status = 'failed',
+    last_status_code = $2,
+    last_error = $3,
+    updated_at = NOW()
+WHERE id = $1 AND status = 'pending' AND attempts = $4;
+
+-- name: FailExpiredWebhookDeliveries :execrows
+-- Fails deliveries whose last allowed attempt lost its worker before recording an outcome
+UPDATE webhook_delivery
+SET status = 'failed',
+    last_error = $1,
+    updated_at = NOW()
+WHERE status = 'pending' AND next_attempt_at <= NOW() AND attempts >= max_attempts;
+
+-- name: ListWebhookDeliveries :many
+SELECT d.*, e.event_type, e.policy_name, e.version
+FROM webhook_delivery d
+JOIN event_outbox e ON e.id = d.event_id
+WHERE d.subscription_id = $1
+    AND ($2::text = '' OR d.status = $2::text)
+ORDER BY d.created_at DESC, d.id DESC
+LIMIT $3 OFFSET $4;
+
+-- name: CountWebhookDeliveries :one
+SELECT COUNT(*) FROM webhook_delivery
+WHERE subscription_id = $1
+    AND ($2::text = '' OR status = $2::text);
@@ -11,14 +11,25 @@ package db
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
-// CreateSchema creates the database schema by executing DDL statements directly
-func CreateSchema(pool *pgxpool.Pool, logger *zap.Logger) error {
+// TagNormalizer converts stored tags to their canonical form
+type TagNormalizer interface {
+	NormalizeAll(tags []string) []string
+	// Fingerprint identifies the normalization rules, so that stored tags are converted again when they change
+	Fingerprint() string
+}
+
+// CreateSchema creates the database schema by executing DDL statements directly. Stored tags are
+// brought into the canonical form of tags once for every set of normalization rules.
+func CreateSchema(pool *pgxpool.Pool, tags TagNormalizer, logger *zap.Logger) error {
 	logger.Info("Creating database schema...")
 
 	ctx := context.Background()
@@ -86,6 +97,158 @@ func CreateSchema(pool *pgxpool.Pool, logger *zap.Logger) error {
 		UNIQUE(policy_version_id, page)
 	);`
 
+	// Create policy_usage_daily table (anonymised counters, no client identifiers)
+	policyUsageDailyTable := `
+	CREATE TABLE IF NOT EXISTS policy_usage_daily (
+		day DATE NOT NULL,
+		policy_name VARCHAR(100) NOT NULL,
+		version VARCHAR(50) NOT NULL,
+		event_type VARCHAR(20) NOT NULL,
+		count BIGINT NOT NULL DEFAULT 0,
+		PRIMARY KEY (day, policy_name, version, event_type)
+	);`
+
+	// Create sync_job table (asynchronous sync jobs)
+	syncJobTable := `
+	CREATE TABLE IF NOT EXISTS sync_job (
+		id SERIAL PRIMARY KEY,
+		policy_name VARCHAR(100) NOT NULL,
+		version VARCHAR(50) NOT NULL,
+		status VARCHAR(20) NOT NULL DEFAULT 'queued',
+		request JSONB NOT NULL,
+		attempts INT NOT NULL DEFAULT 0,
+		max_attempts INT NOT NULL,
+		next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+		last_error JSONB,
+		result JSONB,
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+		started_at TIMESTAMP WITH TIME ZONE,
+		finished_at TIMESTAMP WITH TIME ZONE,
+		updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+	);`
+
+	// Create sync_idempotency_key table (Idempotency-Key headers of queued sync requests)
+	syncIdempotencyKeyTable := `
+	CREATE TABLE IF NOT EXISTS sync_idempotency_key (
+		idempotency_key VARCHAR(255) PRIMARY KEY,
+		request_hash CHAR(64) NOT NULL,
+		sync_job_id INT NOT NULL REFERENCES sync_job(id) ON DELETE CASCADE,
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+	);`
+
+	// Create event_outbox table (catalog events written with the change that caused them)
+	eventOutboxTable := `
+	CREATE TABLE IF NOT EXISTS event_outbox (
+		id BIGSERIAL PRIMARY KEY,
+		event_type VARCHAR(50) NOT NULL,
+		policy_name VARCHAR(100) NOT NULL,
+		version VARCHAR(50) NOT NULL,
+		provider VARCHAR(100) NOT NULL,
+		payload JSONB NOT NULL,
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+		dispatched_at TIMESTAMP WITH TIME ZONE
+	);`
+
+	// Create webhook_subscription table (outbound webhook subscribers)
+	webhookSubscriptionTable := `
+	CREATE TABLE IF NOT EXISTS webhook_subscription (
+		id SERIAL PRIMARY KEY,
+		url VARCHAR(1000) NOT NULL,
+		secret VARCHAR(200) NOT NULL,
+		event_types JSONB NOT NULL DEFAULT '[]',
+		policy_name VARCHAR(100),
+		provider VARCHAR(100),
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+		updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+	);`
+
+	// Create webhook_delivery table (one delivery per event and matching subscription)
+	webhookDeliveryTable := `
+	CREATE TABLE IF NOT EXISTS webhook_delivery (
+		id BIGSERIAL PRIMARY KEY,
+		event_id BIGINT NOT NULL REFERENCES event_outbox(id) ON DELETE CASCADE,
+		subscription_id INT NOT NULL REFERENCES webhook_subscription(id) ON DELETE CASCADE,
+		status VARCHAR(20) NOT NULL DEFAULT 'pending',
+		attempts INT NOT NULL DEFAULT 0,
+		max_attempts INT NOT NULL,
+		next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+		last_status_code INT,
+		last_error TEXT,
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+		updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+		delivered_at TIMESTAMP WITH TIME ZONE,
+		UNIQUE(event_id, subscription_id)
+	);`
+
+	// Create change_log table (append-only catalog mutations backing the change feed)
+	changeLogTable := `
+	CREATE TABLE IF NOT EXISTS change_log (
+		id BIGSERIAL PRIMARY KEY,
+		change_type VARCHAR(50) NOT NULL,
+		policy_name VARCHAR(100) NOT NULL,
+		version VARCHAR(50) NOT NULL,
+		payload JSONB NOT NULL,
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+	);`
+
+	policySourceTable := `
+	CREATE TABLE IF NOT EXISTS policy_source (
+		policy_version_id INTEGER PRIMARY KEY REFERENCES policy_version(id) ON DELETE CASCADE,
+		source_type VARCHAR(50) NOT NULL,
+		definition_url VARCHAR(1000),
+		doc_urls JSONB NOT NULL DEFAULT '{}',
+		assets_base_url VARCHAR(1000),
+		reconciled_at TIMESTAMP WITH TIME ZONE,
+		drift JSONB,
+		drift_detected_at TIMESTAMP WITH TIME ZONE,
+		last_error TEXT,
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+		updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+	);`
+
+	policyDocRevisionTable := `
+	CREATE TABLE IF NOT EXISTS policy_doc_revision (
+		id BIGSERIAL PRIMARY KEY,
+		policy_version_id INTEGER NOT NULL REFERENCES policy_version(id) ON DELETE CASCADE,
+		page VARCHAR(50) NOT NULL,
+		revision INT NOT NULL,
+		content_md TEXT NOT NULL,
+		origin VARCHAR(20) NOT NULL,
+		actor VARCHAR(200),
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+		UNIQUE(policy_version_id, page, revision)
+	);`
+
+	// Catalog filter shared by the policy listing, count and facet queries. A single SQL expression,
+	// so the planner inlines it and can still use the indexes on the filtered columns.
+	policyVersionMatchesFunction := `
+	CREATE OR REPLACE FUNCTION policy_version_matches(
+		pv policy_version,
+		search TEXT,
+		categories TEXT[],
+		providers TEXT[],
+		platforms TEXT[],
+		tags TEXT[],
+		match_all_tags BOOLEAN
+	) RETURNS BOOLEAN
+	LANGUAGE sql STABLE
+	AS $$
+		SELECT (search = '' OR LOWER(pv.display_name) LIKE LOWER('%' || search || '%') OR LOWER(pv.description) LIKE LOWER('%' || search || '%'))
+			AND (categories IS NULL OR array_length(categories, 1) IS NULL OR EXISTS (SELECT 1 FROM unnest(categories) AS cat WHERE pv.categories ? cat))
+			AND (providers IS NULL OR array_length(providers, 1) IS NULL OR pv.provider = ANY(providers))
+			AND (platforms IS NULL OR array_length(platforms, 1) IS NULL OR EXISTS (SELECT 1 FROM unnest(platforms) AS plat WHERE pv.supported_platforms ? plat))
+			AND (tags IS NULL OR array_length(tags, 1) IS NULL
+				OR (match_all_tags AND pv.tags ?& tags)
+				OR (NOT match_all_tags AND pv.tags ?| tags))
+	$$;`
+
+	// Create schema_migration table (one-time data migrations that have been applied)
+	schemaMigrationTable := `
+	CREATE TABLE IF NOT EXISTS schema_migration (
+		name VARCHAR(100) PRIMARY KEY,
+		applied_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+	);`
+
 	// Create indexes for better performance
 	indexes := []string{
 		// Critical indexes for high-load operations
@@ -101,6 +264,9 @@ func CreateSchema(pool *pgxpool.Pool, logger *zap.Logger) error {
 		`CREATE INDEX IF NOT EXISTS idx_policy_version_provider 
 		ON policy_version (provider) WHERE is_latest = TRUE;`,
 
+		`CREATE INDEX IF NOT EXISTS idx_policy_version_tags_gin
+		ON policy_version USING gin (tags) WHERE is_latest = TRUE;`,
+
 		`CREATE INDEX IF NOT EXISTS idx_policy_version_platforms_gin
 		ON policy_version USING gin (supported_platforms) WHERE is_latest = TRUE;`,
 
@@ -112,6 +278,7 @@ func CreateSchema(pool *pgxpool.Pool, logger *zap.Logger) error {
 		ON policy_version (policy_name, version);`,
 
 		`CREATE INDEX IF NOT EXISTS idx_policy_version_created_at ON policy_version (created_at DESC);`,
+		`CREATE INDEX IF NOT EXISTS idx_policy_version_name_keyset ON policy_version (policy_name, created_at DESC, id DESC);`,
 		`CREATE INDEX IF NOT EXISTS idx_policy_docs_page ON policy_docs (policy_version_id, page);`,
 
 		// Indexes for performance
@@ -120,19 +287,47 @@ func CreateSchema(pool *pgxpool.Pool, logger *zap.Logger) error {
 
 		`CREATE INDEX IF NOT EXISTS idx_policy_version_patch_lookup 
 		ON policy_version (policy_name, major_version, minor_version, patch_version DESC);`,
+
+		`CREATE INDEX IF NOT EXISTS idx_policy_usage_daily_policy
+		ON policy_usage_daily (policy_name, day);`,
+
+		`CREATE INDEX IF NOT EXISTS idx_sync_job_pending
+		ON sync_job (next_attempt_at, id) WHERE status = 'queued';`,
+
+		`CREATE INDEX IF NOT EXISTS idx_sync_job_created ON sync_job (created_at DESC, id DESC);`,
+
+		`CREATE INDEX IF NOT EXISTS idx_event_outbox_pending
+		ON event_outbox (id) WHERE dispatched_at IS NULL;`,
+
+		`CREATE INDEX IF NOT EXISTS idx_webhook_delivery_pending
+		ON webhook_delivery (next_attempt_at, id) WHERE status = 'pending';`,
+
+		`CREATE INDEX IF NOT EXISTS idx_webhook_delivery_subscription ON webhook_delivery (subscription_id, created_at DESC, id DESC);`,
+
+		`CREATE INDEX IF NOT EXISTS idx_change_log_policy ON change_log (policy_name, id);`,
+
+		`CREATE INDEX IF NOT EXISTS idx_policy_source_reconcile ON policy_source (reconciled_at NULLS FIRST, policy_version_id);`,
+
+		`CREATE INDEX IF NOT EXISTS idx_policy_source_drift
+		ON policy_source (drift_detected_at DESC, policy_version_id) WHERE drift IS NOT NULL;`,
 	}
 
-	tables := []string{policyVersionTable, policyDocsTable}
+	tables := []string{policyVersionTable, policyDocsTable, policyUsageDailyTable, syncJobTable, syncIdempotencyKeyTable, eventOutboxTable, webhookSubscriptionTable, webhookDeliveryTable, changeLogTable, policySourceTable, policyDocRevisionTable, schemaMigrationTable}
 
 	// Execute table creation
 	for i, tableSQL := range tables {
-		tableNames := []string{"policy_version", "policy_docs"}
+		tableNames := []string{"policy_version", "policy_docs", "policy_usage_daily", "sync_job", "sync_idempotency_key", "event_outbox", "webhook_subscription", "webhook_delivery", "change_log", "policy_source", "policy_doc_revision", "schema_migration"}
 		logger.Info("Creating table", zap.String("table", tableNames[i]))
 		if _, err := pool.Exec(ctx, tableSQL); err != nil {
 			return fmt.Errorf("failed to create table %s: %w", tableNames[i], err)
 		}
 	}
 
+	logger.Info("Creating function", zap.String("function", "policy_version_matches"))
+	if _, err := pool.Exec(ctx, policyVersionMatchesFunction); err != nil {
+		return fmt.Errorf("failed to create function policy_version_matches: %w", err)
+	}
+
 	// Execute index creation
 	for _, indexSQL := range indexes {
 		logger.Info("Creating index", zap.String("sql", indexSQL))
@@ -141,6 +336,106 @@ func CreateSchema(pool *pgxpool.Pool, logger *zap.Logger) error {
 		}
 	}
 
+	// Tags stored before tags were normalized, or under other synonyms, are converted to the canonical
+	// form of the current rules; the migration is keyed by their fingerprint to run again when they change
+	if err := runOnce(ctx, pool, "normalize_stored_tags:"+tags.Fingerprint(), func(tx pgx.Tx) error {
+		return normalizeStoredTags(ctx, tx, tags.NormalizeAll, logger)
+	}, logger); err != nil {
+		return err
+	}
+
+	// Doc pages stored before revisions were recorded get their current content as their first revision
+	docRevisionBackfill := `
+	INSERT INTO policy_doc_revision (policy_version_id, page, revision, content_md, origin, created_at)
+	SELECT d.policy_version_id, d.page, 1, d.content_md, 'publish', d.updated_at
+	FROM policy_docs d
+	WHERE NOT EXISTS (
+		SELECT 1 FROM policy_doc_revision r WHERE r.policy_version_id = d.policy_version_id AND r.page = d.page
+	)
+	ON CONFLICT (policy_version_id, page, revision) DO NOTHING;`
+
+	if _, err := pool.Exec(ctx, docRevisionBackfill); err != nil {
+		return fmt.Errorf("failed to backfill doc revisions: %w", err)
+	}
+
 	logger.Info("Database schema created successfully")
 	return nil
 }
+
+// runOnce applies a one-time data migration in a transaction and records it in schema_migration.
+// Recording the name first makes concurrent instances wait for each other instead of migrating twice.
+func runOnce(ctx context.Context, pool *pgxpool.Pool, name string, migrate func(tx pgx.Tx) error, logger *zap.Logger) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start migration %s: %w", name, err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `INSERT INTO schema_migration (name) VALUES ($1) ON CONFLICT (name) DO NOTHING`, name)
+	if err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", name, err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil // Already applied
+	}
+
+	logger.Info("Applying migration", zap.String("migration", name))
+	if err := migrate(tx); err != nil {
+		return fmt.Errorf("failed to apply migration %s: %w", name, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit migration %s: %w", name, err)
+	}
+	return nil
+}
+
+// normalizeStoredTags rewrites the tags of every version whose stored tags differ from their normalized form
+func normalizeStoredTags(ctx context.Context, tx pgx.Tx, normalizeTags func([]string) []string, logger *zap.Logger) error {
+	rows, err := tx.Query(ctx, `SELECT id, tags FROM policy_version WHERE jsonb_typeof(tags) = 'array' ORDER BY id`)
+	if err != nil {
+		return err
+	}
+
+	updates := make(map[int32][]byte)
+	for rows.Next() {
+		var id int32
+		var raw []byte
+		if err := rows.Scan(&id, &raw); err != nil {
+			rows.Close()
+			return err
+		}
+
+		var tags []string
+		if err := json.Unmarshal(raw, &tags); err != nil {
+			continue // Not an array of strings; left as stored
+		}
+		normalized := normalizeTags(tags)
+		if slices.Equal(tags, normalized) {
+			continue
+		}
+		if normalized == nil {
+			normalized = []string{}
+		}
+		encoded, err := json.Marshal(normalized)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		updates[id] = encoded
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, tags := range updates {
+		if _, err := tx.Exec(ctx, `UPDATE policy_version SET tags = $2 WHERE id = $1`, id, tags); err != nil {
+			return err
+		}
+	}
+
+	if len(updates) > 0 {
+		logger.Info("Normalized stored tags", zap.Int("versions", len(updates)))
+	}
+	return nil
+}
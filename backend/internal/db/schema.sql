@@ -62,6 +62,149 @@ CREATE TABLE IF NOT EXISTS policy_docs (
 	UNIQUE(policy_version_id, page)
 );
 
+-- Anonymised usage counters aggregated per day (no client identifiers are stored)
+CREATE TABLE IF NOT EXISTS policy_usage_daily (
+	day DATE NOT NULL,
+	policy_name VARCHAR(100) NOT NULL,
+	version VARCHAR(50) NOT NULL,
+	event_type VARCHAR(20) NOT NULL,
+	count BIGINT NOT NULL DEFAULT 0,
+	PRIMARY KEY (day, policy_name, version, event_type)
+);
+
+-- Asynchronous sync jobs processed by the worker pool
+CREATE TABLE IF NOT EXISTS sync_job (
+	id SERIAL PRIMARY KEY,
+	policy_name VARCHAR(100) NOT NULL,
+	version VARCHAR(50) NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'queued',
+	request JSONB NOT NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	max_attempts INT NOT NULL,
+	next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+	last_error JSONB,
+	result JSONB,
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+	started_at TIMESTAMP WITH TIME ZONE,
+	finished_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Idempotency-Key headers of queued sync requests, with a hash of the request they were first used for
+CREATE TABLE IF NOT EXISTS sync_idempotency_key (
+	idempotency_key VARCHAR(255) PRIMARY KEY,
+	request_hash CHAR(64) NOT NULL,
+	sync_job_id INT NOT NULL REFERENCES sync_job(id) ON DELETE CASCADE,
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Catalog events, written in the transaction of the change that caused them
+CREATE TABLE IF NOT EXISTS event_outbox (
+	id BIGSERIAL PRIMARY KEY,
+	event_type VARCHAR(50) NOT NULL,
+	policy_name VARCHAR(100) NOT NULL,
+	version VARCHAR(50) NOT NULL,
+	provider VARCHAR(100) NOT NULL,
+	payload JSONB NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+	dispatched_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Outbound webhook subscribers
+CREATE TABLE IF NOT EXISTS webhook_subscription (
+	id SERIAL PRIMARY KEY,
+	url VARCHAR(1000) NOT NULL,
+	secret VARCHAR(200) NOT NULL,
+	event_types JSONB NOT NULL DEFAULT '[]',
+	policy_name VARCHAR(100),
+	provider VARCHAR(100),
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+	updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Outbound webhook deliveries, one per event and matching subscription
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+	id BIGSERIAL PRIMARY KEY,
+	event_id BIGINT NOT NULL REFERENCES event_outbox(id) ON DELETE CASCADE,
+	subscription_id INT NOT NULL REFERENCES webhook_subscription(id) ON DELETE CASCADE,
+	status VARCHAR(20) NOT NULL DEFAULT 'pending',
+	attempts INT NOT NULL DEFAULT 0,
+	max_attempts INT NOT NULL,
+	next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+	last_status_code INT,
+	last_error TEXT,
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+	updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+	delivered_at TIMESTAMP WITH TIME ZONE,
+	UNIQUE(event_id, subscription_id)
+);
+
+-- Append-only log of catalog mutations; ids are assigned in commit order and serve as feed cursors
+CREATE TABLE IF NOT EXISTS change_log (
+	id BIGSERIAL PRIMARY KEY,
+	change_type VARCHAR(50) NOT NULL,
+	policy_name VARCHAR(100) NOT NULL,
+	version VARCHAR(50) NOT NULL,
+	payload JSONB NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Sources a synced version was fetched from, and the outcome of reconciling the version with them
+CREATE TABLE IF NOT EXISTS policy_source (
+	policy_version_id INTEGER PRIMARY KEY REFERENCES policy_version(id) ON DELETE CASCADE,
+	source_type VARCHAR(50) NOT NULL,
+	definition_url VARCHAR(1000),
+	doc_urls JSONB NOT NULL DEFAULT '{}',
+	assets_base_url VARCHAR(1000),
+	reconciled_at TIMESTAMP WITH TIME ZONE,
+	drift JSONB,
+	drift_detected_at TIMESTAMP WITH TIME ZONE,
+	last_error TEXT,
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+	updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Every content a doc page has had, numbered per page, with what and who stored it
+CREATE TABLE IF NOT EXISTS policy_doc_revision (
+	id BIGSERIAL PRIMARY KEY,
+	policy_version_id INTEGER NOT NULL REFERENCES policy_version(id) ON DELETE CASCADE,
+	page VARCHAR(50) NOT NULL,
+	revision INT NOT NULL,
+	content_md TEXT NOT NULL,
+	origin VARCHAR(20) NOT NULL,
+	actor VARCHAR(200),
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+	UNIQUE(policy_version_id, page, revision)
+);
+
+-- One-time data migrations that have been applied
+CREATE TABLE IF NOT EXISTS schema_migration (
+	name VARCHAR(100) PRIMARY KEY,
+	applied_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Catalog filter shared by the policy listing, count and facet queries. A single SQL expression,
+-- so the planner inlines it and can still use the indexes on the filtered columns.
+CREATE OR REPLACE FUNCTION policy_version_matches(
+	pv policy_version,
+	search TEXT,
+	categories TEXT[],
+	providers TEXT[],
+	platforms TEXT[],
+	tags TEXT[],
+	match_all_tags BOOLEAN
+) RETURNS BOOLEAN
+LANGUAGE sql STABLE
+AS $$
+	SELECT (search = '' OR LOWER(pv.display_name) LIKE LOWER('%' || search || '%') OR LOWER(pv.description) LIKE LOWER('%' || search || '%'))
+		AND (categories IS NULL OR array_length(categories, 1) IS NULL OR EXISTS (SELECT 1 FROM unnest(categories) AS cat WHERE pv.categories ? cat))
+		AND (providers IS NULL OR array_length(providers, 1) IS NULL OR pv.provider = ANY(providers))
+		AND (platforms IS NULL OR array_length(platforms, 1) IS NULL OR EXISTS (SELECT 1 FROM unnest(platforms) AS plat WHERE pv.supported_platforms ? plat))
+		AND (tags IS NULL OR array_length(tags, 1) IS NULL
+			OR (match_all_tags AND pv.tags ?& tags)
+			OR (NOT match_all_tags AND pv.tags ?| tags))
+$$;
+
 -- Critical indexes for high-load operations
 CREATE UNIQUE INDEX IF NOT EXISTS idx_policy_version_latest_unique 
 ON policy_version (policy_name) WHERE is_latest = TRUE;
@@ -75,6 +218,9 @@ ON policy_version USING gin (categories) WHERE is_latest = TRUE;
 CREATE INDEX IF NOT EXISTS idx_policy_version_provider 
 ON policy_version (provider) WHERE is_latest = TRUE;
 
+CREATE INDEX IF NOT EXISTS idx_policy_version_tags_gin
+ON policy_version USING gin (tags) WHERE is_latest = TRUE;
+
 CREATE INDEX IF NOT EXISTS idx_policy_version_platforms_gin
 ON policy_version USING gin (supported_platforms) WHERE is_latest = TRUE;
 
@@ -86,6 +232,7 @@ CREATE INDEX IF NOT EXISTS idx_policy_version_name_version
 ON policy_version (policy_name, version);
 
 CREATE INDEX IF NOT EXISTS idx_policy_version_created_at ON policy_version (created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_policy_version_name_keyset ON policy_version (policy_name, created_at DESC, id DESC);
 CREATE INDEX IF NOT EXISTS idx_policy_docs_page ON policy_docs (policy_version_id, page);
 
 -- Indexes for performance
@@ -94,3 +241,26 @@ ON policy_version (policy_name, major_version DESC, minor_version DESC, patch_ve
 
 CREATE INDEX IF NOT EXISTS idx_policy_version_patch_lookup 
 ON policy_version (policy_name, major_version, minor_version, patch_version DESC);
+
+CREATE INDEX IF NOT EXISTS idx_policy_usage_daily_policy
+ON policy_usage_daily (policy_name, day);
+
+CREATE INDEX IF NOT EXISTS idx_sync_job_pending
+ON sync_job (next_attempt_at, id) WHERE status = 'queued';
+
+CREATE INDEX IF NOT EXISTS idx_sync_job_created ON sync_job (created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_pending
+ON event_outbox (id) WHERE dispatched_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_pending
+ON webhook_delivery (next_attempt_at, id) WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_subscription ON webhook_delivery (subscription_id, created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_change_log_policy ON change_log (policy_name, id);
+
+CREATE INDEX IF NOT EXISTS idx_policy_source_reconcile ON policy_source (reconciled_at NULLS FIRST, policy_version_id);
+
+CREATE INDEX IF NOT EXISTS idx_policy_source_drift
+ON policy_source (drift_detected_at DESC, policy_version_id) WHERE drift IS NOT NULL;
@@ -8,6 +8,26 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ChangeLog struct {
+	ID         int64              `json:"id"`
+	ChangeType string             `json:"change_type"`
+	PolicyName string             `json:"policy_name"`
+	Version    string             `json:"version"`
+	Payload    []byte             `json:"payload"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type EventOutbox struct {
+	ID           int64              `json:"id"`
+	EventType    string             `json:"event_type"`
+	PolicyName   string             `json:"policy_name"`
+	Version      string             `json:"version"`
+	Provider     string             `json:"provider"`
+	Payload      []byte             `json:"payload"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	DispatchedAt pgtype.Timestamptz `json:"dispatched_at"`
+}
+
 type PolicyDoc struct {
 	ID              int32              `json:"id"`
 	PolicyVersionID int32              `json:"policy_version_id"`
@@ -17,6 +37,39 @@ type PolicyDoc struct {
 	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
 }
 
+type PolicyDocRevision struct {
+	ID              int64              `json:"id"`
+	PolicyVersionID int32              `json:"policy_version_id"`
+	Page            string             `json:"page"`
+	Revision        int32              `json:"revision"`
+	ContentMd       string             `json:"content_md"`
+	Origin          string             `json:"origin"`
+	Actor           pgtype.Text        `json:"actor"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type PolicySource struct {
+	PolicyVersionID int32              `json:"policy_version_id"`
+	SourceType      string             `json:"source_type"`
+	DefinitionUrl   pgtype.Text        `json:"definition_url"`
+	DocUrls         []byte             `json:"doc_urls"`
+	AssetsBaseUrl   pgtype.Text        `json:"assets_base_url"`
+	ReconciledAt    pgtype.Timestamptz `json:"reconciled_at"`
+	Drift           []byte             `json:"drift"`
+	DriftDetectedAt pgtype.Timestamptz `json:"drift_detected_at"`
+	LastError       pgtype.Text        `json:"last_error"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type PolicyUsageDaily struct {
+	Day        pgtype.Date `json:"day"`
+	PolicyName string      `json:"policy_name"`
+	Version    string      `json:"version"`
+	EventType  string      `json:"event_type"`
+	Count      int64       `json:"count"`
+}
+
 type PolicyVersion struct {
 	ID                 int32              `json:"id"`
 	PolicyName         string             `json:"policy_name"`
@@ -42,3 +95,53 @@ type PolicyVersion struct {
 	MinorVersion       pgtype.Int4        `json:"minor_version"`
 	PatchVersion       pgtype.Int4        `json:"patch_version"`
 }
+
+type SyncIdempotencyKey struct {
+	IdempotencyKey string             `json:"idempotency_key"`
+	RequestHash    string             `json:"request_hash"`
+	SyncJobID      int32              `json:"sync_job_id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type SyncJob struct {
+	ID            int32              `json:"id"`
+	PolicyName    string             `json:"policy_name"`
+	Version       string             `json:"version"`
+	Status        string             `json:"status"`
+	Request       []byte             `json:"request"`
+	Attempts      int32              `json:"attempts"`
+	MaxAttempts   int32              `json:"max_attempts"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	LastError     []byte             `json:"last_error"`
+	Result        []byte             `json:"result"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	StartedAt     pgtype.Timestamptz `json:"started_at"`
+	FinishedAt    pgtype.Timestamptz `json:"finished_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID             int64              `json:"id"`
+	EventID        int64              `json:"event_id"`
+	SubscriptionID int32              `json:"subscription_id"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	MaxAttempts    int32              `json:"max_attempts"`
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	LastStatusCode pgtype.Int4        `json:"last_status_code"`
+	LastError      pgtype.Text        `json:"last_error"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+}
+
+type WebhookSubscription struct {
+	ID         int32              `json:"id"`
+	Url        string             `json:"url"`
+	Secret     string             `json:"secret"`
+	EventTypes []byte             `json:"event_types"`
+	PolicyName pgtype.Text        `json:"policy_name"`
+	Provider   pgtype.Text        `json:"provider"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
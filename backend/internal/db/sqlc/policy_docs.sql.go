@@ -33,6 +33,63 @@ func (q *Queries) GetPolicyDoc(ctx context.Context, arg GetPolicyDocParams) (Pol
 	return i, err
 }
 
+const listFallbackPolicyDocs = `-- name: ListFallbackPolicyDocs :many
+SELECT DISTINCT ON (d.page)
+    d.page,
+    d.content_md,
+    pv.version
+FROM policy_docs d
+JOIN policy_version pv ON pv.id = d.policy_version_id
+WHERE pv.policy_name = $1
+  AND pv.major_version = $2::INT
+  AND (pv.minor_version, pv.patch_version) < ($3::INT, $4::INT)
+  AND pv.version ~ '^\d+\.\d+\.\d+$'
+ORDER BY d.page, pv.minor_version DESC, pv.patch_version DESC
+`
+
+type ListFallbackPolicyDocsParams struct {
+	PolicyName string `json:"policy_name"`
+	Column2    int32  `json:"column_2"`
+	Column3    int32  `json:"column_3"`
+	Column4    int32  `json:"column_4"`
+}
+
+type ListFallbackPolicyDocsRow struct {
+	Page      string `json:"page"`
+	ContentMd string `json:"content_md"`
+	Version   string `json:"version"`
+}
+
+// Returns, for each page, the doc of the closest release of a major line below the given minor and patch
+func (q *Queries) ListFallbackPolicyDocs(ctx context.Context, arg ListFallbackPolicyDocsParams) ([]ListFallbackPolicyDocsRow, error) {
+	rows, err := q.db.Query(ctx, listFallbackPolicyDocs,
+		arg.PolicyName,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListFallbackPolicyDocsRow{}
+	for rows.Next() {
+		var i ListFallbackPolicyDocsRow
+		if err := rows.Scan(
+			&i.Page,
+			&i.ContentMd,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPolicyDocs = `-- name: ListPolicyDocs :many
 SELECT id, policy_version_id, page, content_md, created_at, updated_at FROM policy_docs
 WHERE policy_version_id = $1
@@ -13,10 +13,7 @@ import (
 
 const countPoliciesByMultiple = `-- name: CountPoliciesByMultiple :one
 SELECT COUNT(DISTINCT pv.policy_name) FROM policy_version pv
-WHERE ($1::text = '' OR LOWER(pv.display_name) LIKE LOWER('%' || $1 || '%') OR LOWER(pv.description) LIKE LOWER('%' || $1 || '%'))
-    AND ($2::text[] IS NULL OR array_length($2::text[], 1) = 0 OR EXISTS (SELECT 1 FROM unnest($2::text[]) AS cat WHERE pv.categories ? cat))
-    AND ($3::text[] IS NULL OR array_length($3::text[], 1) = 0 OR pv.provider = ANY($3::text[]))
-    AND ($4::text[] IS NULL or array_length($4::text[], 1) = 0 OR EXISTS (SELECT 1 FROM unnest($4::text[]) AS plat WHERE pv.supported_platforms ? plat))
+WHERE policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
 `
 
 type CountPoliciesByMultipleParams struct {
@@ -24,6 +21,8 @@ type CountPoliciesByMultipleParams struct {
 	Column2 []string `json:"column_2"`
 	Column3 []string `json:"column_3"`
 	Column4 []string `json:"column_4"`
+	Column5 []string `json:"column_5"`
+	Column6 bool     `json:"column_6"`
 }
 
 func (q *Queries) CountPoliciesByMultiple(ctx context.Context, arg CountPoliciesByMultipleParams) (int64, error) {
@@ -32,6 +31,8 @@ func (q *Queries) CountPoliciesByMultiple(ctx context.Context, arg CountPolicies
 		arg.Column2,
 		arg.Column3,
 		arg.Column4,
+		arg.Column5,
+		arg.Column6,
 	)
 	var count int64
 	err := row.Scan(&count)
@@ -61,10 +62,7 @@ WITH ranked_versions AS (
                 pv.created_at DESC
         ) as version_rank
     FROM policy_version pv
-    WHERE ($1::text = '' OR LOWER(pv.display_name) LIKE LOWER('%' || $1 || '%') OR LOWER(pv.description) LIKE LOWER('%' || $1 || '%'))
-        AND ($2::text[] IS NULL OR array_length($2::text[], 1) = 0 OR EXISTS (SELECT 1 FROM unnest($2::text[]) AS cat WHERE pv.categories ? cat))
-        AND ($3::text[] IS NULL OR array_length($3::text[], 1) = 0 OR pv.provider = ANY($3::text[]))
-        AND ($4::text[] IS NULL OR array_length($4::text[], 1) = 0 OR EXISTS (SELECT 1 FROM unnest($4::text[]) AS plat WHERE pv.supported_platforms ? plat))
+    WHERE policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
 )
 SELECT 
     id, policy_name, version, is_latest, display_name, provider, description, 
@@ -73,8 +71,8 @@ SELECT
     created_at, updated_at
 FROM ranked_versions 
 WHERE version_rank = 1
-ORDER BY created_at DESC
-LIMIT $5 OFFSET $6
+ORDER BY created_at DESC, id DESC
+LIMIT $7 OFFSET $8
 `
 
 type FilterPoliciesByMultipleParams struct {
@@ -82,6 +80,8 @@ type FilterPoliciesByMultipleParams struct {
 	Column2 []string `json:"column_2"`
 	Column3 []string `json:"column_3"`
 	Column4 []string `json:"column_4"`
+	Column5 []string `json:"column_5"`
+	Column6 bool     `json:"column_6"`
 	Limit   int32    `json:"limit"`
 	Offset  int32    `json:"offset"`
 }
@@ -115,6 +115,8 @@ func (q *Queries) FilterPoliciesByMultiple(ctx context.Context, arg FilterPolici
 		arg.Column2,
 		arg.Column3,
 		arg.Column4,
+		arg.Column5,
+		arg.Column6,
 		arg.Limit,
 		arg.Offset,
 	)
@@ -157,6 +159,284 @@ func (q *Queries) FilterPoliciesByMultiple(ctx context.Context, arg FilterPolici
 	return items, nil
 }
 
+const filterPoliciesByName = `-- name: FilterPoliciesByName :many
+SELECT DISTINCT ON (pv.policy_name)
+    pv.id, pv.policy_name, pv.version, pv.is_latest, pv.display_name, pv.provider, pv.description,
+    pv.categories, pv.tags, pv.logo_path, pv.banner_path, pv.supported_platforms,
+    pv.release_date, pv.definition_yaml, pv.icon_path, pv.source_type, pv.download_url, pv.checksum,
+    pv.created_at, pv.updated_at
+FROM policy_version pv
+WHERE pv.policy_name > $7::text
+    AND policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
+ORDER BY pv.policy_name, pv.is_latest DESC, pv.created_at DESC, pv.id DESC
+LIMIT $8 OFFSET $9
+`
+
+type FilterPoliciesByNameParams struct {
+	Column1 string   `json:"column_1"`
+	Column2 []string `json:"column_2"`
+	Column3 []string `json:"column_3"`
+	Column4 []string `json:"column_4"`
+	Column5 []string `json:"column_5"`
+	Column6 bool     `json:"column_6"`
+	Column7 string   `json:"column_7"`
+	Limit   int32    `json:"limit"`
+	Offset  int32    `json:"offset"`
+}
+
+type FilterPoliciesByNameRow struct {
+	ID                 int32              `json:"id"`
+	PolicyName         string             `json:"policy_name"`
+	Version            string             `json:"version"`
+	IsLatest           pgtype.Bool        `json:"is_latest"`
+	DisplayName        string             `json:"display_name"`
+	Provider           string             `json:"provider"`
+	Description        pgtype.Text        `json:"description"`
+	Categories         []byte             `json:"categories"`
+	Tags               []byte             `json:"tags"`
+	LogoPath           pgtype.Text        `json:"logo_path"`
+	BannerPath         pgtype.Text        `json:"banner_path"`
+	SupportedPlatforms []byte             `json:"supported_platforms"`
+	ReleaseDate        pgtype.Date        `json:"release_date"`
+	DefinitionYaml     string             `json:"definition_yaml"`
+	IconPath           pgtype.Text        `json:"icon_path"`
+	SourceType         pgtype.Text        `json:"source_type"`
+	DownloadUrl        pgtype.Text        `json:"download_url"`
+	Checksum           []byte             `json:"checksum"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Policies in name order, each represented by its latest matching version. The name keyset is
+// applied before the representative is picked, so only the policies of the page are ranked.
+func (q *Queries) FilterPoliciesByName(ctx context.Context, arg FilterPoliciesByNameParams) ([]FilterPoliciesByNameRow, error) {
+	rows, err := q.db.Query(ctx, filterPoliciesByName,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+		arg.Column6,
+		arg.Column7,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FilterPoliciesByNameRow{}
+	for rows.Next() {
+		var i FilterPoliciesByNameRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PolicyName,
+			&i.Version,
+			&i.IsLatest,
+			&i.DisplayName,
+			&i.Provider,
+			&i.Description,
+			&i.Categories,
+			&i.Tags,
+			&i.LogoPath,
+			&i.BannerPath,
+			&i.SupportedPlatforms,
+			&i.ReleaseDate,
+			&i.DefinitionYaml,
+			&i.IconPath,
+			&i.SourceType,
+			&i.DownloadUrl,
+			&i.Checksum,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const filterPoliciesByPopularity = `-- name: FilterPoliciesByPopularity :many
+WITH ranked_versions AS (
+    SELECT 
+        pv.*,
+        ROW_NUMBER() OVER (
+            PARTITION BY pv.policy_name 
+            ORDER BY 
+                pv.is_latest DESC,
+                pv.created_at DESC
+        ) as version_rank
+    FROM policy_version pv
+    WHERE policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
+),
+usage AS (
+    SELECT policy_name, SUM(count)::bigint AS total
+    FROM policy_usage_daily
+    WHERE day >= $7::date
+    GROUP BY policy_name
+)
+SELECT 
+    rv.id, rv.policy_name, rv.version, rv.is_latest, rv.display_name, rv.provider, rv.description, 
+    rv.categories, rv.tags, rv.logo_path, rv.banner_path, rv.supported_platforms, 
+    rv.release_date, rv.definition_yaml, rv.icon_path, rv.source_type, rv.download_url, rv.checksum,
+    rv.created_at, rv.updated_at
+FROM ranked_versions rv
+LEFT JOIN usage u ON u.policy_name = rv.policy_name
+WHERE rv.version_rank = 1
+ORDER BY COALESCE(u.total, 0) DESC, rv.created_at DESC, rv.id DESC
+LIMIT $8 OFFSET $9
+`
+
+type FilterPoliciesByPopularityParams struct {
+	Column1 string      `json:"column_1"`
+	Column2 []string    `json:"column_2"`
+	Column3 []string    `json:"column_3"`
+	Column4 []string    `json:"column_4"`
+	Column5 []string    `json:"column_5"`
+	Column6 bool        `json:"column_6"`
+	Column7 pgtype.Date `json:"column_7"`
+	Limit   int32       `json:"limit"`
+	Offset  int32       `json:"offset"`
+}
+
+type FilterPoliciesByPopularityRow struct {
+	ID                 int32              `json:"id"`
+	PolicyName         string             `json:"policy_name"`
+	Version            string             `json:"version"`
+	IsLatest           pgtype.Bool        `json:"is_latest"`
+	DisplayName        string             `json:"display_name"`
+	Provider           string             `json:"provider"`
+	Description        pgtype.Text        `json:"description"`
+	Categories         []byte             `json:"categories"`
+	Tags               []byte             `json:"tags"`
+	LogoPath           pgtype.Text        `json:"logo_path"`
+	BannerPath         pgtype.Text        `json:"banner_path"`
+	SupportedPlatforms []byte             `json:"supported_platforms"`
+	ReleaseDate        pgtype.Date        `json:"release_date"`
+	DefinitionYaml     string             `json:"definition_yaml"`
+	IconPath           pgtype.Text        `json:"icon_path"`
+	SourceType         pgtype.Text        `json:"source_type"`
+	DownloadUrl        pgtype.Text        `json:"download_url"`
+	Checksum           []byte             `json:"checksum"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) FilterPoliciesByPopularity(ctx context.Context, arg FilterPoliciesByPopularityParams) ([]FilterPoliciesByPopularityRow, error) {
+	rows, err := q.db.Query(ctx, filterPoliciesByPopularity,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+		arg.Column6,
+		arg.Column7,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FilterPoliciesByPopularityRow{}
+	for rows.Next() {
+		var i FilterPoliciesByPopularityRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PolicyName,
+			&i.Version,
+			&i.IsLatest,
+			&i.DisplayName,
+			&i.Provider,
+			&i.Description,
+			&i.Categories,
+			&i.Tags,
+			&i.LogoPath,
+			&i.BannerPath,
+			&i.SupportedPlatforms,
+			&i.ReleaseDate,
+			&i.DefinitionYaml,
+			&i.IconPath,
+			&i.SourceType,
+			&i.DownloadUrl,
+			&i.Checksum,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCategoryFacets = `-- name: GetCategoryFacets :many
+
+SELECT facet::text AS value, COUNT(DISTINCT pv.policy_name) AS count
+FROM policy_version pv
+CROSS JOIN LATERAL jsonb_array_elements_text(
+    CASE WHEN jsonb_typeof(pv.categories) = 'array' THEN pv.categories ELSE '[]'::jsonb END
+) AS facet
+WHERE pv.is_latest = TRUE
+    AND policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
+GROUP BY facet
+ORDER BY count DESC, value
+`
+
+type GetCategoryFacetsParams struct {
+	Column1 string   `json:"column_1"`
+	Column2 []string `json:"column_2"`
+	Column3 []string `json:"column_3"`
+	Column4 []string `json:"column_4"`
+	Column5 []string `json:"column_5"`
+	Column6 bool     `json:"column_6"`
+}
+
+type GetCategoryFacetsRow struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// =============================================================================
+// FACET OPERATIONS (latest versions only)
+// =============================================================================
+func (q *Queries) GetCategoryFacets(ctx context.Context, arg GetCategoryFacetsParams) ([]GetCategoryFacetsRow, error) {
+	rows, err := q.db.Query(ctx, getCategoryFacets,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+		arg.Column6,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCategoryFacetsRow{}
+	for rows.Next() {
+		var i GetCategoryFacetsRow
+		if err := rows.Scan(
+			&i.Value,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getDistinctCategories = `-- name: GetDistinctCategories :many
 
 SELECT DISTINCT jsonb_array_elements_text(categories) as category
@@ -242,6 +522,36 @@ func (q *Queries) GetDistinctProviders(ctx context.Context) ([]string, error) {
 	return items, nil
 }
 
+const getDistinctTags = `-- name: GetDistinctTags :many
+SELECT DISTINCT tag::text AS tag
+FROM policy_version pv
+CROSS JOIN LATERAL jsonb_array_elements_text(
+    CASE WHEN jsonb_typeof(pv.tags) = 'array' THEN pv.tags ELSE '[]'::jsonb END
+) AS tag
+WHERE pv.is_latest = TRUE
+ORDER BY tag
+`
+
+func (q *Queries) GetDistinctTags(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, getDistinctTags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		items = append(items, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLatestPolicyVersion = `-- name: GetLatestPolicyVersion :one
 SELECT id, policy_name, version, is_latest, display_name, provider, description, categories, tags, logo_path, banner_path, supported_platforms, release_date, definition_yaml, icon_path, source_type, download_url, checksum, created_at, updated_at, major_version, minor_version, patch_version FROM policy_version
 WHERE policy_name = $1 AND is_latest = TRUE
@@ -278,6 +588,62 @@ func (q *Queries) GetLatestPolicyVersion(ctx context.Context, policyName string)
 	return i, err
 }
 
+const getPlatformFacets = `-- name: GetPlatformFacets :many
+SELECT facet::text AS value, COUNT(DISTINCT pv.policy_name) AS count
+FROM policy_version pv
+CROSS JOIN LATERAL jsonb_array_elements_text(
+    CASE WHEN jsonb_typeof(pv.supported_platforms) = 'array' THEN pv.supported_platforms ELSE '[]'::jsonb END
+) AS facet
+WHERE pv.is_latest = TRUE
+    AND policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
+GROUP BY facet
+ORDER BY count DESC, value
+`
+
+type GetPlatformFacetsParams struct {
+	Column1 string   `json:"column_1"`
+	Column2 []string `json:"column_2"`
+	Column3 []string `json:"column_3"`
+	Column4 []string `json:"column_4"`
+	Column5 []string `json:"column_5"`
+	Column6 bool     `json:"column_6"`
+}
+
+type GetPlatformFacetsRow struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+func (q *Queries) GetPlatformFacets(ctx context.Context, arg GetPlatformFacetsParams) ([]GetPlatformFacetsRow, error) {
+	rows, err := q.db.Query(ctx, getPlatformFacets,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+		arg.Column6,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPlatformFacetsRow{}
+	for rows.Next() {
+		var i GetPlatformFacetsRow
+		if err := rows.Scan(
+			&i.Value,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPolicyVersion = `-- name: GetPolicyVersion :one
 
 
@@ -325,6 +691,151 @@ func (q *Queries) GetPolicyVersion(ctx context.Context, arg GetPolicyVersionPara
 	return i, err
 }
 
+const getPolicyVersionByID = `-- name: GetPolicyVersionByID :one
+SELECT id, policy_name, version, is_latest, display_name, provider, description, categories, tags, logo_path, banner_path, supported_platforms, release_date, definition_yaml, icon_path, source_type, download_url, checksum, created_at, updated_at, major_version, minor_version, patch_version FROM policy_version
+WHERE id = $1
+`
+
+func (q *Queries) GetPolicyVersionByID(ctx context.Context, id int32) (PolicyVersion, error) {
+	row := q.db.QueryRow(ctx, getPolicyVersionByID, id)
+	var i PolicyVersion
+	err := row.Scan(
+		&i.ID,
+		&i.PolicyName,
+		&i.Version,
+		&i.IsLatest,
+		&i.DisplayName,
+		&i.Provider,
+		&i.Description,
+		&i.Categories,
+		&i.Tags,
+		&i.LogoPath,
+		&i.BannerPath,
+		&i.SupportedPlatforms,
+		&i.ReleaseDate,
+		&i.DefinitionYaml,
+		&i.IconPath,
+		&i.SourceType,
+		&i.DownloadUrl,
+		&i.Checksum,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MajorVersion,
+		&i.MinorVersion,
+		&i.PatchVersion,
+	)
+	return i, err
+}
+
+const getProviderFacets = `-- name: GetProviderFacets :many
+SELECT pv.provider AS value, COUNT(DISTINCT pv.policy_name) AS count
+FROM policy_version pv
+WHERE pv.is_latest = TRUE
+    AND policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
+GROUP BY pv.provider
+ORDER BY count DESC, value
+`
+
+type GetProviderFacetsParams struct {
+	Column1 string   `json:"column_1"`
+	Column2 []string `json:"column_2"`
+	Column3 []string `json:"column_3"`
+	Column4 []string `json:"column_4"`
+	Column5 []string `json:"column_5"`
+	Column6 bool     `json:"column_6"`
+}
+
+type GetProviderFacetsRow struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+func (q *Queries) GetProviderFacets(ctx context.Context, arg GetProviderFacetsParams) ([]GetProviderFacetsRow, error) {
+	rows, err := q.db.Query(ctx, getProviderFacets,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+		arg.Column6,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProviderFacetsRow{}
+	for rows.Next() {
+		var i GetProviderFacetsRow
+		if err := rows.Scan(
+			&i.Value,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTagFacets = `-- name: GetTagFacets :many
+SELECT facet::text AS value, COUNT(DISTINCT pv.policy_name) AS count
+FROM policy_version pv
+CROSS JOIN LATERAL jsonb_array_elements_text(
+    CASE WHEN jsonb_typeof(pv.tags) = 'array' THEN pv.tags ELSE '[]'::jsonb END
+) AS facet
+WHERE pv.is_latest = TRUE
+    AND policy_version_matches(pv, $1::text, $2::text[], $3::text[], $4::text[], $5::text[], $6::boolean)
+GROUP BY facet
+ORDER BY count DESC, value
+`
+
+type GetTagFacetsParams struct {
+	Column1 string   `json:"column_1"`
+	Column2 []string `json:"column_2"`
+	Column3 []string `json:"column_3"`
+	Column4 []string `json:"column_4"`
+	Column5 []string `json:"column_5"`
+	Column6 bool     `json:"column_6"`
+}
+
+type GetTagFacetsRow struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+func (q *Queries) GetTagFacets(ctx context.Context, arg GetTagFacetsParams) ([]GetTagFacetsRow, error) {
+	rows, err := q.db.Query(ctx, getTagFacets,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+		arg.Column6,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTagFacetsRow{}
+	for rows.Next() {
+		var i GetTagFacetsRow
+		if err := rows.Scan(
+			&i.Value,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const insertPolicyVersion = `-- name: InsertPolicyVersion :one
 INSERT INTO policy_version (
     policy_name,
@@ -425,7 +936,7 @@ const listPolicyVersions = `-- name: ListPolicyVersions :many
 
 SELECT id, policy_name, version, is_latest, display_name, provider, description, categories, tags, logo_path, banner_path, supported_platforms, release_date, definition_yaml, icon_path, source_type, download_url, checksum, created_at, updated_at, major_version, minor_version, patch_version FROM policy_version
 WHERE policy_name = $1
-ORDER BY created_at DESC
+ORDER BY created_at DESC, id DESC
 LIMIT $2 OFFSET $3
 `
 
@@ -482,6 +993,70 @@ func (q *Queries) ListPolicyVersions(ctx context.Context, arg ListPolicyVersions
 	return items, nil
 }
 
+const listPolicyVersionsAfter = `-- name: ListPolicyVersionsAfter :many
+SELECT id, policy_name, version, is_latest, display_name, provider, description, categories, tags, logo_path, banner_path, supported_platforms, release_date, definition_yaml, icon_path, source_type, download_url, checksum, created_at, updated_at, major_version, minor_version, patch_version FROM policy_version
+WHERE policy_name = $1
+    AND ($2::timestamptz IS NULL OR (created_at, id) < ($2::timestamptz, $3::int))
+ORDER BY created_at DESC, id DESC
+LIMIT $4
+`
+
+type ListPolicyVersionsAfterParams struct {
+	PolicyName string             `json:"policy_name"`
+	Column2    pgtype.Timestamptz `json:"column_2"`
+	Column3    int32              `json:"column_3"`
+	Limit      int32              `json:"limit"`
+}
+
+func (q *Queries) ListPolicyVersionsAfter(ctx context.Context, arg ListPolicyVersionsAfterParams) ([]PolicyVersion, error) {
+	rows, err := q.db.Query(ctx, listPolicyVersionsAfter,
+		arg.PolicyName,
+		arg.Column2,
+		arg.Column3,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PolicyVersion{}
+	for rows.Next() {
+		var i PolicyVersion
+		if err := rows.Scan(
+			&i.ID,
+			&i.PolicyName,
+			&i.Version,
+			&i.IsLatest,
+			&i.DisplayName,
+			&i.Provider,
+			&i.Description,
+			&i.Categories,
+			&i.Tags,
+			&i.LogoPath,
+			&i.BannerPath,
+			&i.SupportedPlatforms,
+			&i.ReleaseDate,
+			&i.DefinitionYaml,
+			&i.IconPath,
+			&i.SourceType,
+			&i.DownloadUrl,
+			&i.Checksum,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MajorVersion,
+			&i.MinorVersion,
+			&i.PatchVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const resolvePoliciesExact = `-- name: ResolvePoliciesExact :many
 
 SELECT 
@@ -705,6 +1280,64 @@ func (q *Queries) ResolvePoliciesPatch(ctx context.Context, arg ResolvePoliciesP
 	return items, nil
 }
 
+const selectPolicyVersions = `-- name: SelectPolicyVersions :many
+SELECT id, policy_name, version, is_latest, display_name, provider, description, categories, tags, logo_path, banner_path, supported_platforms, release_date, definition_yaml, icon_path, source_type, download_url, checksum, created_at, updated_at, major_version, minor_version, patch_version FROM policy_version
+WHERE (cardinality($1::text[]) = 0 OR policy_name = ANY($1::text[]))
+    AND ($2::text = '' OR provider = $2::text)
+    AND ($3::text = '' OR supported_platforms ? $3::text)
+ORDER BY policy_name, major_version, minor_version, patch_version
+`
+
+type SelectPolicyVersionsParams struct {
+	Column1 []string `json:"column_1"`
+	Column2 string   `json:"column_2"`
+	Column3 string   `json:"column_3"`
+}
+
+func (q *Queries) SelectPolicyVersions(ctx context.Context, arg SelectPolicyVersionsParams) ([]PolicyVersion, error) {
+	rows, err := q.db.Query(ctx, selectPolicyVersions, arg.Column1, arg.Column2, arg.Column3)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PolicyVersion{}
+	for rows.Next() {
+		var i PolicyVersion
+		if err := rows.Scan(
+			&i.ID,
+			&i.PolicyName,
+			&i.Version,
+			&i.IsLatest,
+			&i.DisplayName,
+			&i.Provider,
+			&i.Description,
+			&i.Categories,
+			&i.Tags,
+			&i.LogoPath,
+			&i.BannerPath,
+			&i.SupportedPlatforms,
+			&i.ReleaseDate,
+			&i.DefinitionYaml,
+			&i.IconPath,
+			&i.SourceType,
+			&i.DownloadUrl,
+			&i.Checksum,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MajorVersion,
+			&i.MinorVersion,
+			&i.PatchVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateLatestVersion = `-- name: UpdateLatestVersion :exec
 
 UPDATE policy_version
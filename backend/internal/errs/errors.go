@@ -10,7 +10,10 @@
 package errs
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/jackc/pgx/v5/pgconn"
@@ -20,12 +23,36 @@ import (
 type Code string
 
 const (
-	CodePolicyVersionNotFound Code = "POLICY_VERSION_NOT_FOUND"
-	CodeDocNotFound           Code = "DOC_NOT_FOUND"
-	CodeValidationError       Code = "VALIDATION_ERROR"
-	CodeSyncFetchFailed       Code = "SYNC_FETCH_FAILED"
-	CodeInternalServerError   Code = "INTERNAL_SERVER_ERROR"
-	CodeDatabaseError         Code = "DB_ERROR"
+	CodePolicyVersionNotFound  Code = "POLICY_VERSION_NOT_FOUND"
+	CodeDocNotFound            Code = "DOC_NOT_FOUND"
+	CodeDocRevisionNotFound    Code = "DOC_REVISION_NOT_FOUND"
+	CodeDownloadNotAvailable   Code = "DOWNLOAD_NOT_AVAILABLE"
+	CodeSyncJobNotFound        Code = "SYNC_JOB_NOT_FOUND"
+	CodeCrawlerNotConfigured   Code = "CRAWLER_NOT_CONFIGURED"
+	CodeCrawlInProgress        Code = "CRAWL_IN_PROGRESS"
+	CodeCrawlNotFound          Code = "CRAWL_NOT_FOUND"
+	CodeReconcileInProgress    Code = "RECONCILE_IN_PROGRESS"
+	CodeReconcileNotFound      Code = "RECONCILE_NOT_FOUND"
+	CodeWebhookNotConfigured   Code = "WEBHOOK_NOT_CONFIGURED"
+	CodeWebhookSourceNotFound  Code = "WEBHOOK_SOURCE_NOT_FOUND"
+	CodeInvalidSignature       Code = "INVALID_SIGNATURE"
+	CodeSubscriptionNotFound   Code = "SUBSCRIPTION_NOT_FOUND"
+	CodeBundleNotConfigured    Code = "BUNDLE_SIGNING_NOT_CONFIGURED"
+	CodeChecksumMismatch       Code = "CHECKSUM_MISMATCH"
+	CodeInvalidBundle          Code = "INVALID_BUNDLE"
+	CodeInvalidPackage         Code = "INVALID_PACKAGE"
+	CodeArtifactsNotConfigured Code = "ARTIFACT_STORE_NOT_CONFIGURED"
+	CodeArtifactNotFound       Code = "ARTIFACT_NOT_FOUND"
+	CodePolicyVersionExists    Code = "POLICY_VERSION_EXISTS"
+	CodePolicyVersionConflict  Code = "POLICY_VERSION_CONFLICT"
+	CodeIdempotencyKeyReused   Code = "IDEMPOTENCY_KEY_REUSED"
+	CodeRequiredDocsMissing    Code = "REQUIRED_DOCS_MISSING"
+	CodeBatchAborted           Code = "BATCH_ABORTED"
+	CodeSyncFetchRejected      Code = "SYNC_FETCH_REJECTED"
+	CodeValidationError        Code = "VALIDATION_ERROR"
+	CodeSyncFetchFailed        Code = "SYNC_FETCH_FAILED"
+	CodeInternalServerError    Code = "INTERNAL_SERVER_ERROR"
+	CodeDatabaseError          Code = "DB_ERROR"
 )
 
 // AppError represents a structured application error
@@ -41,6 +68,15 @@ func (e *AppError) Error() string {
 	return string(e.Code) + ": " + e.Message
 }
 
+// AsAppError returns the AppError in an error's chain, or wraps any other error as an internal error
+func AsAppError(err error) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return NewInternalError("unexpected error", map[string]any{"error": err.Error()})
+}
+
 // NewValidationError creates a validation error
 func NewValidationError(msg string, details map[string]any) *AppError {
 	return &AppError{
@@ -126,9 +162,247 @@ func DocNotFound(name, version, page string) *AppError {
 	)
 }
 
+// DocRevisionNotFound creates an error for a missing revision of a documentation page
+func DocRevisionNotFound(name, version, page string, revision int) *AppError {
+	return NewNotFoundError(
+		CodeDocRevisionNotFound,
+		"Documentation revision not found",
+		map[string]any{
+			"policyName": name,
+			"version":    version,
+			"page":       page,
+			"revision":   revision,
+		},
+	)
+}
+
+// DownloadNotAvailable creates an error for a version that has no download URL
+func DownloadNotAvailable(name, version string) *AppError {
+	return NewNotFoundError(
+		CodeDownloadNotAvailable,
+		"Policy version has no download available",
+		map[string]any{
+			"policyName": name,
+			"version":    version,
+		},
+	)
+}
+
+// SyncJobNotFound creates a sync job not found error
+func SyncJobNotFound(id int32) *AppError {
+	return NewNotFoundError(
+		CodeSyncJobNotFound,
+		"Sync job not found",
+		map[string]any{"jobId": id},
+	)
+}
+
+// CrawlerNotConfigured creates an error for crawler requests when no index source is configured
+func CrawlerNotConfigured() *AppError {
+	return NewNotFoundError(
+		CodeCrawlerNotConfigured,
+		"Catalog crawler is not configured",
+		nil,
+	)
+}
+
+// CrawlInProgress creates an error for a crawl requested while another is running
+func CrawlInProgress() *AppError {
+	return NewConflictError(
+		CodeCrawlInProgress,
+		"A catalog crawl is already running",
+		nil,
+	)
+}
+
+// CrawlNotFound creates an error for a crawl status request before any crawl has run
+func CrawlNotFound() *AppError {
+	return NewNotFoundError(
+		CodeCrawlNotFound,
+		"No catalog crawl has run yet",
+		nil,
+	)
+}
+
+// ReconcileInProgress creates an error for a reconciliation requested while another is running
+func ReconcileInProgress() *AppError {
+	return NewConflictError(
+		CodeReconcileInProgress,
+		"A reconciliation is already running",
+		nil,
+	)
+}
+
+// ReconcileNotFound creates an error for a reconciliation status request before any reconciliation has run
+func ReconcileNotFound() *AppError {
+	return NewNotFoundError(
+		CodeReconcileNotFound,
+		"No reconciliation has run yet",
+		nil,
+	)
+}
+
+// WebhookNotConfigured creates an error for webhook deliveries when no webhook secret is configured
+func WebhookNotConfigured() *AppError {
+	return NewNotFoundError(
+		CodeWebhookNotConfigured,
+		"Webhook receiver is not configured",
+		nil,
+	)
+}
+
+// WebhookSourceNotFound creates an error for a webhook delivery from an unconfigured repository
+func WebhookSourceNotFound(repository string) *AppError {
+	return NewNotFoundError(
+		CodeWebhookSourceNotFound,
+		"Repository is not a configured webhook source",
+		map[string]any{"repository": repository},
+	)
+}
+
+// InvalidSignature creates an error for a webhook delivery whose signature does not match its payload
+func InvalidSignature() *AppError {
+	return &AppError{
+		Code:       CodeInvalidSignature,
+		HTTPStatus: http.StatusUnauthorized,
+		Message:    "Invalid webhook signature",
+	}
+}
+
+// SubscriptionNotFound creates a webhook subscription not found error
+func SubscriptionNotFound(id int32) *AppError {
+	return NewNotFoundError(
+		CodeSubscriptionNotFound,
+		"Webhook subscription not found",
+		map[string]any{"subscriptionId": id},
+	)
+}
+
+// BundleSigningNotConfigured creates an error for bundle exports when no signing key is configured
+func BundleSigningNotConfigured() *AppError {
+	return NewNotFoundError(
+		CodeBundleNotConfigured,
+		"Bundle signing key is not configured",
+		nil,
+	)
+}
+
+// ChecksumMismatch creates an error for content whose digest differs from the one it was published with
+func ChecksumMismatch(resource, expected, actual string) *AppError {
+	return &AppError{
+		Code:       CodeChecksumMismatch,
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Checksum does not match content",
+		Details: map[string]any{
+			"resource": resource,
+			"expected": expected,
+			"actual":   actual,
+		},
+	}
+}
+
+// InvalidBundle creates an error for bundle archives that cannot be read or trusted as a whole
+func InvalidBundle(reason string, details map[string]any) *AppError {
+	return &AppError{
+		Code:       CodeInvalidBundle,
+		HTTPStatus: http.StatusBadRequest,
+		Message:    reason,
+		Details:    details,
+	}
+}
+
+// InvalidPackage creates an error for policy package archives that cannot be extracted
+func InvalidPackage(reason string, details map[string]any) *AppError {
+	return &AppError{
+		Code:       CodeInvalidPackage,
+		HTTPStatus: http.StatusBadRequest,
+		Message:    reason,
+		Details:    details,
+	}
+}
+
+// ArtifactStoreNotConfigured creates an error for requests that store artifacts when no artifact store is configured
+func ArtifactStoreNotConfigured() *AppError {
+	return NewNotFoundError(
+		CodeArtifactsNotConfigured,
+		"Artifact store is not configured",
+		nil,
+	)
+}
+
+// ArtifactNotFound creates an error for unknown stored artifacts
+func ArtifactNotFound(path string) *AppError {
+	return NewNotFoundError(
+		CodeArtifactNotFound,
+		"Artifact not found",
+		map[string]any{"path": path},
+	)
+}
+
+// PolicyVersionExists creates an error for publishing a version that is already stored
+func PolicyVersionExists(name, version string) *AppError {
+	return NewConflictError(
+		CodePolicyVersionExists,
+		"Policy version already exists",
+		map[string]any{
+			"policyName": name,
+			"version":    version,
+		},
+	)
+}
+
+// PolicyVersionConflict creates an error for re-publishing a stored version with different content
+func PolicyVersionConflict(name, version string, diff any) *AppError {
+	return NewConflictError(
+		CodePolicyVersionConflict,
+		"Policy version already exists with different content",
+		map[string]any{
+			"policyName": name,
+			"version":    version,
+			"diff":       diff,
+		},
+	)
+}
+
+// IdempotencyKeyReused creates an error for an idempotency key replayed with a different request
+func IdempotencyKeyReused(key string) *AppError {
+	return &AppError{
+		Code:       CodeIdempotencyKeyReused,
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Idempotency key was already used for a different request",
+		Details:    map[string]any{"idempotencyKey": key},
+	}
+}
+
+// RequiredDocsMissing creates an error for a sync whose required doc pages were not found
+func RequiredDocsMissing(name, version string, pages []string, docs any) *AppError {
+	return &AppError{
+		Code:       CodeRequiredDocsMissing,
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Required documentation pages were not found",
+		Details: map[string]any{
+			"policyName": name,
+			"version":    version,
+			"pages":      pages,
+			"docs":       docs,
+		},
+	}
+}
+
+// BatchAborted creates the error of an item that was not written because another item of an atomic
+// batch failed
+func BatchAborted(failedIndex int) *AppError {
+	return &AppError{
+		Code:       CodeBatchAborted,
+		HTTPStatus: http.StatusFailedDependency,
+		Message:    "Batch aborted, nothing was written",
+		Details:    map[string]any{"failedIndex": failedIndex},
+	}
+}
+
 // SyncFetchFailed creates a sync fetch failure error
 func SyncFetchFailed(url string, err error) *AppError {
-	details := map[string]any{"url": url}
+	details := map[string]any{"url": redactURL(url)}
 	if err != nil {
 		details["error"] = err.Error()
 	}
@@ -140,6 +414,33 @@ func SyncFetchFailed(url string, err error) *AppError {
 	}
 }
 
+// redactURL masks a password in the user info of a URL
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Redacted()
+}
+
+// SyncFetchStatus creates a sync fetch failure error for an unexpected HTTP status
+func SyncFetchStatus(url string, status int) *AppError {
+	appErr := SyncFetchFailed(url, fmt.Errorf("status code %d", status))
+	appErr.Details["status"] = status
+	return appErr
+}
+
+// SyncFetchRejected creates an error for a remote URL that may not be fetched, or whose response
+// is not acceptable
+func SyncFetchRejected(url string, err error) *AppError {
+	return &AppError{
+		Code:       CodeSyncFetchRejected,
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Message:    "Remote URL was rejected by the fetch restrictions",
+		Details:    map[string]any{"url": redactURL(url), "error": err.Error()},
+	}
+}
+
 // IsUniqueConstraintError checks if an error is a PostgreSQL unique constraint violation
 func IsUniqueConstraintError(err error) bool {
 	if err == nil {
@@ -0,0 +1,363 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/fetch"
+	"github.com/wso2/policyhub/internal/logging"
+)
+
+// Delivery request headers
+const (
+	HeaderEvent     = "X-PolicyHub-Event"
+	HeaderDelivery  = "X-PolicyHub-Delivery"
+	HeaderSignature = "X-PolicyHub-Signature-256"
+)
+
+// fanOutBatchSize is the number of outbox events turned into deliveries per transaction
+const fanOutBatchSize = 100
+
+// maxResponseBodySize bounds how much of a subscriber response is read before it is discarded
+const maxResponseBodySize = 64 << 10
+
+// Sign returns the signature header value of a delivery body: sha256= followed by the hex HMAC-SHA256
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher fans outbox events out to matching subscriptions and delivers them with retries
+type Dispatcher struct {
+	repo            Repository
+	logger          *logging.Logger
+	client          *http.Client
+	workers         int
+	maxAttempts     int
+	initialBackoff  time.Duration
+	maxBackoff      time.Duration
+	pollInterval    time.Duration
+	deliveryTimeout time.Duration
+	wake            chan struct{}
+	stop            chan struct{}
+	wg              sync.WaitGroup
+}
+
+// NewDispatcher creates a new dispatcher; call Start to begin delivering
+func NewDispatcher(repo Repository, cfg *config.EventsConfig, logger *logging.Logger) *Dispatcher {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !cfg.AllowPrivateNetworks {
+		// Subscription URLs are checked when registered, but a name may resolve differently later
+		dialer.Control = fetch.CheckAddress
+	}
+
+	return &Dispatcher{
+		repo:   repo,
+		logger: logger,
+		client: &http.Client{
+			// Deliveries connect directly; a proxy would hide the subscriber address from the dialer
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				ForceAttemptHTTP2:   true,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+			// Redirects are reported as failed deliveries rather than followed
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		workers:         cfg.Workers,
+		maxAttempts:     cfg.MaxAttempts,
+		initialBackoff:  time.Duration(cfg.InitialBackoffSeconds) * time.Second,
+		maxBackoff:      time.Duration(cfg.MaxBackoffSeconds) * time.Second,
+		pollInterval:    time.Duration(cfg.PollIntervalSeconds) * time.Second,
+		deliveryTimeout: time.Duration(cfg.DeliveryTimeoutSeconds) * time.Second,
+		wake:            make(chan struct{}, 1),
+		stop:            make(chan struct{}),
+	}
+}
+
+// Start launches the fan-out loop and the delivery workers
+func (d *Dispatcher) Start() {
+	d.wg.Add(1)
+	go d.fanOut()
+
+	for i := 0; i < d.workers; i++ {
+		d.wg.Add(1)
+		go d.work()
+	}
+
+	d.logger.Info("Webhook dispatcher started", zap.Int("workers", d.workers))
+}
+
+// Stop signals the dispatcher to exit and waits for in-flight deliveries to finish
+func (d *Dispatcher) Stop(ctx context.Context) {
+	close(d.stop)
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		d.logger.Info("Webhook dispatcher stopped")
+	case <-ctx.Done():
+		// Interrupted deliveries are retried once their lease expires
+		d.logger.Warn("Webhook dispatcher did not stop before shutdown deadline")
+	}
+}
+
+func (d *Dispatcher) fanOut() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+		}
+
+		d.failExpired()
+
+		// Drain the outbox before waiting again
+		for {
+			events, deliveries, err := d.repo.FanOutEvents(context.Background(), fanOutBatchSize, d.maxAttempts)
+			if err != nil {
+				d.logger.Error("Failed to fan out catalog events", zap.Error(err))
+				break
+			}
+			if deliveries > 0 {
+				d.notify()
+			}
+			if events < fanOutBatchSize {
+				break
+			}
+		}
+	}
+}
+
+// failExpired fails deliveries whose last allowed attempt never recorded an outcome, e.g. after a crash;
+// claiming skips them, so they would otherwise stay pending forever
+func (d *Dispatcher) failExpired() {
+	failed, err := d.repo.FailExpiredDeliveries(context.Background(), "delivery attempt did not complete before its lease expired")
+	if err != nil {
+		d.logger.Error("Failed to fail expired webhook deliveries", zap.Error(err))
+	} else if failed > 0 {
+		d.logger.Warn("Failed webhook deliveries without attempts left", zap.Int64("count", failed))
+	}
+}
+
+// notify wakes an idle delivery worker
+func (d *Dispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain all due deliveries before waiting again
+		for d.deliverNext() {
+			select {
+			case <-d.stop:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-d.stop:
+			return
+		case <-d.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverNext claims and sends one due delivery; it reports whether a delivery was processed
+func (d *Dispatcher) deliverNext() bool {
+	// The lease outlives the request so that a crashed worker's delivery is retried, not duplicated in flight
+	delivery, err := d.repo.ClaimDelivery(context.Background(), time.Now().Add(2*d.deliveryTimeout))
+	if err != nil {
+		d.logger.Error("Failed to claim webhook delivery", zap.Error(err))
+		return false
+	}
+	if delivery == nil {
+		return false
+	}
+
+	d.deliver(delivery)
+	return true
+}
+
+func (d *Dispatcher) deliver(delivery *Delivery) {
+	ctx := context.Background()
+
+	sub, err := d.repo.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		var appErr *errs.AppError
+		if errors.As(err, &appErr) && appErr.Code == errs.CodeSubscriptionNotFound {
+			return // Deleted since the delivery was claimed; its deliveries are gone too
+		}
+		d.logger.Error("Failed to load webhook subscription", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+		return
+	}
+
+	event, err := d.repo.GetEvent(ctx, delivery.EventID)
+	if err != nil {
+		d.logger.Error("Failed to load catalog event", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+		return
+	}
+
+	statusCode, sendErr := d.send(sub, event, delivery)
+	if sendErr == nil {
+		recorded, err := d.repo.CompleteDelivery(ctx, delivery.ID, delivery.Attempts, statusCode)
+		if err != nil {
+			d.logger.Error("Failed to record webhook delivery", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+			return
+		}
+		if !recorded {
+			d.logSuperseded(delivery)
+			return
+		}
+		d.logger.Debug("Webhook delivered",
+			zap.Int64("delivery_id", delivery.ID),
+			zap.Int32("subscription_id", sub.ID),
+			zap.String("event", event.Type),
+			zap.Int("status", statusCode))
+		return
+	}
+
+	if delivery.Attempts < delivery.MaxAttempts {
+		nextAttemptAt := time.Now().Add(d.backoff(delivery.Attempts))
+		recorded, err := d.repo.RetryDelivery(ctx, delivery.ID, delivery.Attempts, statusCode, sendErr.Error(), nextAttemptAt)
+		if err != nil {
+			d.logger.Error("Failed to reschedule webhook delivery", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+			return
+		}
+		if !recorded {
+			d.logSuperseded(delivery)
+			return
+		}
+		d.logger.Warn("Webhook delivery failed, retrying",
+			zap.Int64("delivery_id", delivery.ID),
+			zap.Int32("subscription_id", sub.ID),
+			zap.Int("attempt", delivery.Attempts),
+			zap.Time("next_attempt_at", nextAttemptAt),
+			zap.Error(sendErr))
+		return
+	}
+
+	recorded, err := d.repo.FailDelivery(ctx, delivery.ID, delivery.Attempts, statusCode, sendErr.Error())
+	if err != nil {
+		d.logger.Error("Failed to mark webhook delivery as failed", zap.Int64("delivery_id", delivery.ID), zap.Error(err))
+		return
+	}
+	if !recorded {
+		d.logSuperseded(delivery)
+		return
+	}
+	d.logger.Error("Webhook delivery failed permanently",
+		zap.Int64("delivery_id", delivery.ID),
+		zap.Int32("subscription_id", sub.ID),
+		zap.Int("attempts", delivery.Attempts),
+		zap.Error(sendErr))
+}
+
+// logSuperseded reports an attempt that outlived its lease; the delivery was claimed again, so the
+// outcome of this attempt is discarded in favour of the later one
+func (d *Dispatcher) logSuperseded(delivery *Delivery) {
+	d.logger.Warn("Webhook delivery attempt finished after its lease expired, discarding its outcome",
+		zap.Int64("delivery_id", delivery.ID),
+		zap.Int32("subscription_id", delivery.SubscriptionID),
+		zap.Int("attempt", delivery.Attempts))
+}
+
+// send posts a signed event to a subscriber; any non-2xx response is an error
+func (d *Dispatcher) send(sub *Subscription, event *Event, delivery *Delivery) (int, error) {
+	body, err := json.Marshal(Message{
+		DeliveryID: delivery.ID,
+		EventID:    event.ID,
+		Type:       event.Type,
+		CreatedAt:  event.CreatedAt,
+		Data:       event.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.deliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PolicyHub-Webhook")
+	req.Header.Set(HeaderEvent, event.Type)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.initialBackoff
+	for i := 1; i < attempt && delay < d.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.maxBackoff {
+		delay = d.maxBackoff
+	}
+	return delay
+}
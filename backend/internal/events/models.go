@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package events
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DeliveryStatus represents the state of a webhook delivery
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed" // Attempts exhausted
+)
+
+// ValidDeliveryStatuses returns a map of valid delivery statuses
+func ValidDeliveryStatuses() map[string]bool {
+	return map[string]bool{
+		string(DeliveryPending):   true,
+		string(DeliverySucceeded): true,
+		string(DeliveryFailed):    true,
+	}
+}
+
+// Subscription is a registered outbound webhook. Empty filters match every event.
+type Subscription struct {
+	ID         int32
+	URL        string
+	Secret     string // Signs deliveries; only returned when the subscription is created
+	EventTypes []string
+	PolicyName *string
+	Provider   *string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Event is a catalog event recorded in the outbox
+type Event struct {
+	ID         int64
+	Type       string
+	PolicyName string
+	Version    string
+	Provider   string
+	Payload    json.RawMessage
+	CreatedAt  time.Time
+}
+
+// Delivery is the delivery of one event to one subscription
+type Delivery struct {
+	ID             int64
+	EventID        int64
+	SubscriptionID int32
+	EventType      string
+	PolicyName     string
+	Version        string
+	Status         DeliveryStatus
+	Attempts       int
+	MaxAttempts    int
+	NextAttemptAt  *time.Time
+	LastStatusCode *int
+	LastError      *string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// DeliveryFilters holds filtering and paging criteria for listing deliveries
+type DeliveryFilters struct {
+	SubscriptionID int32
+	Status         string
+	Page           int
+	PageSize       int
+}
+
+// Message is the JSON body posted to subscribers
+type Message struct {
+	DeliveryID int64           `json:"deliveryId"`
+	EventID    int64           `json:"eventId"`
+	Type       string          `json:"type"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	Data       json.RawMessage `json:"data"`
+}
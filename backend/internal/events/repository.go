@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for outbound webhook data access
+type Repository interface {
+	CreateSubscription(ctx context.Context, sub *Subscription) (*Subscription, error)
+	GetSubscription(ctx context.Context, id int32) (*Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]*Subscription, error)
+	DeleteSubscription(ctx context.Context, id int32) error
+	ListDeliveries(ctx context.Context, filters DeliveryFilters) ([]*Delivery, error)
+	CountDeliveries(ctx context.Context, filters DeliveryFilters) (int, error)
+
+	// Dispatcher operations
+	// FanOutEvents turns up to limit undispatched outbox events into deliveries for the matching subscriptions
+	FanOutEvents(ctx context.Context, limit int, maxAttempts int) (events int, deliveries int64, err error)
+	// ClaimDelivery leases a due delivery until leaseUntil; it returns nil when no delivery is due
+	ClaimDelivery(ctx context.Context, leaseUntil time.Time) (*Delivery, error)
+	GetEvent(ctx context.Context, id int64) (*Event, error)
+	// Completing, retrying and failing apply only while the given attempt still holds the delivery and
+	// report whether it did, so that an attempt whose lease expired cannot overwrite a later attempt.
+	CompleteDelivery(ctx context.Context, id int64, attempt int, statusCode int) (bool, error)
+	RetryDelivery(ctx context.Context, id int64, attempt int, statusCode int, deliveryErr string, nextAttemptAt time.Time) (bool, error)
+	FailDelivery(ctx context.Context, id int64, attempt int, statusCode int, deliveryErr string) (bool, error)
+	// FailExpiredDeliveries fails due deliveries that have no attempts left
+	FailExpiredDeliveries(ctx context.Context, deliveryErr string) (int64, error)
+}
@@ -0,0 +1,332 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/wso2/policyhub/internal/db"
+	"github.com/wso2/policyhub/internal/db/sqlc"
+	"github.com/wso2/policyhub/internal/errs"
+)
+
+// SQLCRepository implements Repository using sqlc-generated code
+type SQLCRepository struct {
+	db      *db.DB
+	queries *sqlc.Queries
+}
+
+// NewSQLCRepository creates a new SQLC-based outbound webhook repository
+func NewSQLCRepository(database *db.DB) Repository {
+	return &SQLCRepository{
+		db:      database,
+		queries: sqlc.New(database.Pool),
+	}
+}
+
+// Helper functions for pgtype conversions
+
+func pgtypeTimestamptzToPtr(ts pgtype.Timestamptz) *time.Time {
+	if ts.Valid {
+		return &ts.Time
+	}
+	return nil
+}
+
+func pgtypeTextToPtr(t pgtype.Text) *string {
+	if t.Valid {
+		return &t.String
+	}
+	return nil
+}
+
+func ptrToPgtypeText(s *string) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *s, Valid: true}
+}
+
+// statusCodeToPgtype stores a missing HTTP status (the request failed) as NULL
+func statusCodeToPgtype(statusCode int) pgtype.Int4 {
+	return pgtype.Int4{Int32: int32(statusCode), Valid: statusCode > 0}
+}
+
+// Mapper functions to convert between sqlc and domain models
+
+func sqlcToSubscription(ss sqlc.WebhookSubscription) (*Subscription, error) {
+	sub := &Subscription{
+		ID:         ss.ID,
+		URL:        ss.Url,
+		Secret:     ss.Secret,
+		PolicyName: pgtypeTextToPtr(ss.PolicyName),
+		Provider:   pgtypeTextToPtr(ss.Provider),
+		CreatedAt:  ss.CreatedAt.Time,
+		UpdatedAt:  ss.UpdatedAt.Time,
+	}
+	if err := json.Unmarshal(ss.EventTypes, &sub.EventTypes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event types: %w", err)
+	}
+	return sub, nil
+}
+
+func sqlcToDelivery(sd sqlc.WebhookDelivery) *Delivery {
+	delivery := &Delivery{
+		ID:             sd.ID,
+		EventID:        sd.EventID,
+		SubscriptionID: sd.SubscriptionID,
+		Status:         DeliveryStatus(sd.Status),
+		Attempts:       int(sd.Attempts),
+		MaxAttempts:    int(sd.MaxAttempts),
+		NextAttemptAt:  pgtypeTimestamptzToPtr(sd.NextAttemptAt),
+		LastError:      pgtypeTextToPtr(sd.LastError),
+		CreatedAt:      sd.CreatedAt.Time,
+		UpdatedAt:      sd.UpdatedAt.Time,
+		DeliveredAt:    pgtypeTimestamptzToPtr(sd.DeliveredAt),
+	}
+	if sd.LastStatusCode.Valid {
+		code := int(sd.LastStatusCode.Int32)
+		delivery.LastStatusCode = &code
+	}
+	return delivery
+}
+
+func (r *SQLCRepository) CreateSubscription(ctx context.Context, sub *Subscription) (*Subscription, error) {
+	eventTypes, err := json.Marshal(sub.EventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event types: %w", err)
+	}
+
+	ss, err := r.queries.CreateWebhookSubscription(ctx, sqlc.CreateWebhookSubscriptionParams{
+		Url:        sub.URL,
+		Secret:     sub.Secret,
+		EventTypes: eventTypes,
+		PolicyName: ptrToPgtypeText(sub.PolicyName),
+		Provider:   ptrToPgtypeText(sub.Provider),
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to create webhook subscription", map[string]any{"error": err.Error()})
+	}
+
+	return sqlcToSubscription(ss)
+}
+
+func (r *SQLCRepository) GetSubscription(ctx context.Context, id int32) (*Subscription, error) {
+	ss, err := r.queries.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.SubscriptionNotFound(id)
+		}
+		return nil, errs.NewDatabaseError("failed to get webhook subscription", map[string]any{"error": err.Error()})
+	}
+
+	return sqlcToSubscription(ss)
+}
+
+func (r *SQLCRepository) ListSubscriptions(ctx context.Context) ([]*Subscription, error) {
+	rows, err := r.queries.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list webhook subscriptions", map[string]any{"error": err.Error()})
+	}
+
+	subs := make([]*Subscription, 0, len(rows))
+	for _, row := range rows {
+		sub, err := sqlcToSubscription(row)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, nil
+}
+
+func (r *SQLCRepository) DeleteSubscription(ctx context.Context, id int32) error {
+	count, err := r.queries.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		return errs.NewDatabaseError("failed to delete webhook subscription", map[string]any{"error": err.Error()})
+	}
+	if count == 0 {
+		return errs.SubscriptionNotFound(id)
+	}
+
+	return nil
+}
+
+func (r *SQLCRepository) ListDeliveries(ctx context.Context, filters DeliveryFilters) ([]*Delivery, error) {
+	rows, err := r.queries.ListWebhookDeliveries(ctx, sqlc.ListWebhookDeliveriesParams{
+		SubscriptionID: filters.SubscriptionID,
+		Column2:        filters.Status,
+		Limit:          int32(filters.PageSize),
+		Offset:         int32((filters.Page - 1) * filters.PageSize),
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list webhook deliveries", map[string]any{"error": err.Error()})
+	}
+
+	deliveries := make([]*Delivery, 0, len(rows))
+	for _, row := range rows {
+		delivery := sqlcToDelivery(sqlc.WebhookDelivery{
+			ID:             row.ID,
+			EventID:        row.EventID,
+			SubscriptionID: row.SubscriptionID,
+			Status:         row.Status,
+			Attempts:       row.Attempts,
+			MaxAttempts:    row.MaxAttempts,
+			NextAttemptAt:  row.NextAttemptAt,
+			LastStatusCode: row.LastStatusCode,
+			LastError:      row.LastError,
+			CreatedAt:      row.CreatedAt,
+			UpdatedAt:      row.UpdatedAt,
+			DeliveredAt:    row.DeliveredAt,
+		})
+		delivery.EventType = row.EventType
+		delivery.PolicyName = row.PolicyName
+		delivery.Version = row.Version
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (r *SQLCRepository) CountDeliveries(ctx context.Context, filters DeliveryFilters) (int, error) {
+	count, err := r.queries.CountWebhookDeliveries(ctx, sqlc.CountWebhookDeliveriesParams{
+		SubscriptionID: filters.SubscriptionID,
+		Column2:        filters.Status,
+	})
+	if err != nil {
+		return 0, errs.NewDatabaseError("failed to count webhook deliveries", map[string]any{"error": err.Error()})
+	}
+
+	return int(count), nil
+}
+
+func (r *SQLCRepository) FanOutEvents(ctx context.Context, limit int, maxAttempts int) (int, int64, error) {
+	// Claiming, fanning out and marking the events happen in one transaction so that
+	// concurrent dispatchers never create deliveries for the same event twice
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return 0, 0, errs.NewDatabaseError("failed to start transaction", map[string]any{"error": err.Error()})
+	}
+	defer tx.Rollback(ctx)
+
+	q := sqlc.New(tx)
+
+	ids, err := q.ClaimOutboxEvents(ctx, int32(limit))
+	if err != nil {
+		return 0, 0, errs.NewDatabaseError("failed to claim outbox events", map[string]any{"error": err.Error()})
+	}
+	if len(ids) == 0 {
+		return 0, 0, nil
+	}
+
+	deliveries, err := q.CreateWebhookDeliveries(ctx, sqlc.CreateWebhookDeliveriesParams{
+		Column1: ids,
+		Column2: int32(maxAttempts),
+	})
+	if err != nil {
+		return 0, 0, errs.NewDatabaseError("failed to create webhook deliveries", map[string]any{"error": err.Error()})
+	}
+
+	if err := q.MarkOutboxEventsDispatched(ctx, ids); err != nil {
+		return 0, 0, errs.NewDatabaseError("failed to mark outbox events dispatched", map[string]any{"error": err.Error()})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, 0, errs.NewDatabaseError("failed to commit transaction", map[string]any{"error": err.Error()})
+	}
+
+	return len(ids), deliveries, nil
+}
+
+func (r *SQLCRepository) ClaimDelivery(ctx context.Context, leaseUntil time.Time) (*Delivery, error) {
+	sd, err := r.queries.ClaimWebhookDelivery(ctx, pgtype.Timestamptz{Time: leaseUntil, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errs.NewDatabaseError("failed to claim webhook delivery", map[string]any{"error": err.Error()})
+	}
+
+	return sqlcToDelivery(sd), nil
+}
+
+func (r *SQLCRepository) GetEvent(ctx context.Context, id int64) (*Event, error) {
+	se, err := r.queries.GetOutboxEvent(ctx, id)
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to get outbox event", map[string]any{"error": err.Error()})
+	}
+
+	return &Event{
+		ID:         se.ID,
+		Type:       se.EventType,
+		PolicyName: se.PolicyName,
+		Version:    se.Version,
+		Provider:   se.Provider,
+		Payload:    se.Payload,
+		CreatedAt:  se.CreatedAt.Time,
+	}, nil
+}
+
+func (r *SQLCRepository) CompleteDelivery(ctx context.Context, id int64, attempt int, statusCode int) (bool, error) {
+	count, err := r.queries.CompleteWebhookDelivery(ctx, sqlc.CompleteWebhookDeliveryParams{
+		ID:             id,
+		LastStatusCode: statusCodeToPgtype(statusCode),
+		Attempts:       int32(attempt),
+	})
+	if err != nil {
+		return false, errs.NewDatabaseError("failed to complete webhook delivery", map[string]any{"error": err.Error()})
+	}
+
+	return count > 0, nil
+}
+
+func (r *SQLCRepository) RetryDelivery(ctx context.Context, id int64, attempt int, statusCode int, deliveryErr string, nextAttemptAt time.Time) (bool, error) {
+	count, err := r.queries.RetryWebhookDelivery(ctx, sqlc.RetryWebhookDeliveryParams{
+		ID:             id,
+		LastStatusCode: statusCodeToPgtype(statusCode),
+		LastError:      pgtype.Text{String: deliveryErr, Valid: true},
+		NextAttemptAt:  pgtype.Timestamptz{Time: nextAttemptAt, Valid: true},
+		Attempts:       int32(attempt),
+	})
+	if err != nil {
+		return false, errs.NewDatabaseError("failed to reschedule webhook delivery", map[string]any{"error": err.Error()})
+	}
+
+	return count > 0, nil
+}
+
+func (r *SQLCRepository) FailDelivery(ctx context.Context, id int64, attempt int, statusCode int, deliveryErr string) (bool, error) {
+	count, err := r.queries.FailWebhookDelivery(ctx, sqlc.FailWebhookDeliveryParams{
+		ID:             id,
+		LastStatusCode: statusCodeToPgtype(statusCode),
+		LastError:      pgtype.Text{String: deliveryErr, Valid: true},
+		Attempts:       int32(attempt),
+	})
+	if err != nil {
+		return false, errs.NewDatabaseError("failed to mark webhook delivery as failed", map[string]any{"error": err.Error()})
+	}
+
+	return count > 0, nil
+}
+
+func (r *SQLCRepository) FailExpiredDeliveries(ctx context.Context, deliveryErr string) (int64, error) {
+	count, err := r.queries.FailExpiredWebhookDeliveries(ctx, pgtype.Text{String: deliveryErr, Valid: true})
+	if err != nil {
+		return 0, errs.NewDatabaseError("failed to fail expired webhook deliveries", map[string]any{"error": err.Error()})
+	}
+
+	return count, nil
+}
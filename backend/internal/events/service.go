@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/fetch"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/policy"
+	"github.com/wso2/policyhub/internal/validation"
+)
+
+// Length limits for caller-provided secrets
+const (
+	MinSecretLength       = 16
+	MaxSecretLength       = 200
+	generatedSecretLength = 32 // Random bytes, hex encoded when no secret is provided
+)
+
+// Service handles outbound webhook subscriptions and delivery logs
+type Service struct {
+	repo                 Repository
+	allowPrivateNetworks bool
+	logger               *logging.Logger
+}
+
+// NewService creates a new outbound webhook service
+func NewService(repo Repository, cfg *config.EventsConfig, logger *logging.Logger) *Service {
+	return &Service{
+		repo:                 repo,
+		allowPrivateNetworks: cfg.AllowPrivateNetworks,
+		logger:               logger,
+	}
+}
+
+// CreateSubscription validates and registers a subscription, generating a secret when none is given
+func (s *Service) CreateSubscription(ctx context.Context, sub *Subscription) (*Subscription, error) {
+	if err := validation.ValidateURL(sub.URL); err != nil {
+		return nil, errs.NewValidationError("invalid subscription URL", map[string]any{"error": err.Message})
+	}
+	if !s.allowPrivateNetworks {
+		if err := checkSubscriberHost(ctx, sub.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	validTypes := policy.ValidEventTypes()
+	eventTypes := make([]string, 0, len(sub.EventTypes))
+	for _, eventType := range sub.EventTypes {
+		if !validTypes[eventType] {
+			return nil, errs.NewValidationError("invalid event type", map[string]any{
+				"allowed_values": []string{policy.EventVersionPublished, policy.EventVersionDeprecated, policy.EventVersionYanked, policy.EventDocsUpdated},
+				"provided":       eventType,
+			})
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	sub.EventTypes = eventTypes
+
+	if sub.PolicyName != nil {
+		if err := validation.ValidatePolicyName(*sub.PolicyName); err != nil {
+			return nil, err
+		}
+	}
+	if sub.Provider != nil && strings.TrimSpace(*sub.Provider) == "" {
+		return nil, errs.NewValidationError("provider filter cannot be empty", nil)
+	}
+
+	if sub.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, errs.NewInternalError("failed to generate subscription secret", map[string]any{"error": err.Error()})
+		}
+		sub.Secret = secret
+	} else if len(sub.Secret) < MinSecretLength || len(sub.Secret) > MaxSecretLength {
+		return nil, errs.NewValidationError("invalid subscription secret length", map[string]any{
+			"minLength": MinSecretLength,
+			"maxLength": MaxSecretLength,
+		})
+	}
+
+	created, err := s.repo.CreateSubscription(ctx, sub)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("creating webhook subscription")
+	}
+
+	s.logger.Info("Webhook subscription created",
+		zap.Int32("subscription_id", created.ID),
+		zap.Strings("event_types", created.EventTypes))
+
+	return created, nil
+}
+
+// checkSubscriberHost rejects subscription URLs whose host is, or resolves to, an internal address.
+// Hosts that do not resolve yet are accepted; the dispatcher refuses internal addresses when connecting.
+func checkSubscriberHost(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errs.NewValidationError("invalid subscription URL", map[string]any{"error": err.Error()})
+	}
+
+	err = fetch.CheckHost(ctx, u.Hostname())
+	if errors.Is(err, fetch.ErrBlockedAddress) {
+		return errs.NewValidationError("subscription URL host is not allowed", map[string]any{
+			"host":  u.Hostname(),
+			"error": err.Error(),
+		})
+	}
+	return nil
+}
+
+// GetSubscription retrieves a subscription by ID
+func (s *Service) GetSubscription(ctx context.Context, id int32) (*Subscription, error) {
+	return s.repo.GetSubscription(ctx, id)
+}
+
+// ListSubscriptions retrieves all subscriptions
+func (s *Service) ListSubscriptions(ctx context.Context) ([]*Subscription, error) {
+	subs, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("listing webhook subscriptions")
+	}
+	return subs, nil
+}
+
+// DeleteSubscription removes a subscription together with its deliveries
+func (s *Service) DeleteSubscription(ctx context.Context, id int32) error {
+	if err := s.repo.DeleteSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.Info("Webhook subscription deleted", zap.Int32("subscription_id", id))
+	return nil
+}
+
+// ListDeliveries retrieves a page of a subscription's deliveries, newest first
+func (s *Service) ListDeliveries(ctx context.Context, filters DeliveryFilters) ([]*Delivery, *policy.PaginationInfo, error) {
+	if filters.Page < 1 {
+		filters.Page = 1
+	}
+	if filters.PageSize < policy.MinPageSize || filters.PageSize > policy.MaxPageSize {
+		filters.PageSize = policy.DefaultPageSize
+	}
+	if filters.Status != "" && !ValidDeliveryStatuses()[filters.Status] {
+		return nil, nil, errs.NewValidationError("invalid status", map[string]any{
+			"allowed_values": []DeliveryStatus{DeliveryPending, DeliverySucceeded, DeliveryFailed},
+			"provided":       filters.Status,
+		})
+	}
+
+	// Report unknown subscriptions instead of an empty log
+	if _, err := s.repo.GetSubscription(ctx, filters.SubscriptionID); err != nil {
+		return nil, nil, err
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, filters)
+	if err != nil {
+		return nil, nil, errs.SanitizeDatabaseError("listing webhook deliveries")
+	}
+
+	total, err := s.repo.CountDeliveries(ctx, filters)
+	if err != nil {
+		return nil, nil, errs.SanitizeDatabaseError("counting webhook deliveries")
+	}
+
+	return deliveries, &policy.PaginationInfo{
+		Page:       filters.Page,
+		PageSize:   filters.PageSize,
+		HasTotal:   true,
+		TotalItems: total,
+		TotalPages: policy.CalculateTotalPages(total, filters.PageSize),
+	}, nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, generatedSecretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
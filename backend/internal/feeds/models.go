@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package feeds
+
+import "time"
+
+// ScopeKind selects the releases a feed covers
+type ScopeKind string
+
+const (
+	ScopeCatalog  ScopeKind = "catalog"
+	ScopePolicy   ScopeKind = "policy"
+	ScopeProvider ScopeKind = "provider"
+	ScopeCategory ScopeKind = "category"
+)
+
+// Scope is the subject of a feed; Value is empty for the catalog feed
+type Scope struct {
+	Kind  ScopeKind
+	Value string
+}
+
+// ContentPages lists the documentation pages used as entry content, in order of preference
+var ContentPages = []string{"changelog", "overview"}
+
+// Release is a published policy version as presented in a feed
+type Release struct {
+	PolicyName  string
+	Version     string
+	DisplayName string
+	Provider    string
+	Description *string
+	Categories  []string
+	ReleaseDate *time.Time
+	CreatedAt   time.Time
+	Updated     time.Time // Latest of the version creation and its content page update
+	Content     *string   // Markdown of the preferred content page, if any
+}
+
+// Feed is a rendered feed document
+type Feed struct {
+	Body    []byte
+	Updated time.Time
+}
@@ -0,0 +1,18 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package feeds
+
+import "context"
+
+// Repository defines the interface for reading feed releases
+type Repository interface {
+	// ListReleases returns up to limit releases in scope, newest first, with their content pages
+	ListReleases(ctx context.Context, scope Scope, limit int) ([]*Release, error)
+}
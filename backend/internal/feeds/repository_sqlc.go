@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package feeds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/wso2/policyhub/internal/db"
+	"github.com/wso2/policyhub/internal/db/sqlc"
+	"github.com/wso2/policyhub/internal/errs"
+)
+
+// SQLCRepository implements Repository using sqlc-generated code
+type SQLCRepository struct {
+	db      *db.DB
+	queries *sqlc.Queries
+}
+
+// NewSQLCRepository creates a new SQLC-based feed repository
+func NewSQLCRepository(database *db.DB) Repository {
+	return &SQLCRepository{
+		db:      database,
+		queries: sqlc.New(database.Pool),
+	}
+}
+
+func (r *SQLCRepository) ListReleases(ctx context.Context, scope Scope, limit int) ([]*Release, error) {
+	params := sqlc.ListReleasesParams{Limit: int32(limit)}
+	switch scope.Kind {
+	case ScopePolicy:
+		params.Column1 = scope.Value
+	case ScopeProvider:
+		params.Column2 = scope.Value
+	case ScopeCategory:
+		params.Column3 = scope.Value
+	}
+
+	rows, err := r.queries.ListReleases(ctx, params)
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list releases", map[string]any{"error": err.Error()})
+	}
+
+	releases := make([]*Release, 0, len(rows))
+	byID := make(map[int32]*Release, len(rows))
+	versionIDs := make([]int32, 0, len(rows))
+	for _, row := range rows {
+		release := &Release{
+			PolicyName:  row.PolicyName,
+			Version:     row.Version,
+			DisplayName: row.DisplayName,
+			Provider:    row.Provider,
+			CreatedAt:   row.CreatedAt.Time,
+			Updated:     row.CreatedAt.Time,
+		}
+		if row.Description.Valid {
+			release.Description = &row.Description.String
+		}
+		if row.ReleaseDate.Valid {
+			release.ReleaseDate = &row.ReleaseDate.Time
+		}
+		if len(row.Categories) > 0 {
+			if err := json.Unmarshal(row.Categories, &release.Categories); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal categories: %w", err)
+			}
+		}
+
+		releases = append(releases, release)
+		byID[row.ID] = release
+		versionIDs = append(versionIDs, row.ID)
+	}
+
+	if len(versionIDs) == 0 {
+		return releases, nil
+	}
+
+	docs, err := r.queries.ListReleaseDocs(ctx, sqlc.ListReleaseDocsParams{
+		Column1: versionIDs,
+		Column2: ContentPages,
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list release docs", map[string]any{"error": err.Error()})
+	}
+
+	// Keep the most preferred page of each version
+	preferred := make(map[int32]sqlc.ListReleaseDocsRow, len(docs))
+	for _, doc := range docs {
+		current, ok := preferred[doc.PolicyVersionID]
+		if !ok || slices.Index(ContentPages, doc.Page) < slices.Index(ContentPages, current.Page) {
+			preferred[doc.PolicyVersionID] = doc
+		}
+	}
+	for versionID, doc := range preferred {
+		release := byID[versionID]
+		release.Content = &doc.ContentMd
+		if doc.UpdatedAt.Time.After(release.Updated) {
+			release.Updated = doc.UpdatedAt.Time
+		}
+	}
+
+	return releases, nil
+}
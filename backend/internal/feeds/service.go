@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/logging"
+)
+
+// Service builds release feeds
+type Service struct {
+	repo    Repository
+	apiURL  string
+	siteURL string
+	entries int
+	maxAge  time.Duration
+	logger  *logging.Logger
+}
+
+// NewService creates a new feed service
+func NewService(repo Repository, cfg *config.FeedsConfig, logger *logging.Logger) *Service {
+	return &Service{
+		repo:    repo,
+		apiURL:  cfg.APIURL,
+		siteURL: cfg.SiteURL,
+		entries: cfg.Entries,
+		maxAge:  time.Duration(cfg.MaxAgeSeconds) * time.Second,
+		logger:  logger,
+	}
+}
+
+// MaxAge returns how long clients and proxies may cache a feed
+func (s *Service) MaxAge() time.Duration {
+	return s.maxAge
+}
+
+// Atom renders the Atom feed of the latest releases in scope. Entries link to the web portal when
+// a site URL is configured and to the API otherwise; all links are built from configured URLs, so
+// that a cached feed is the same for every client.
+func (s *Service) Atom(ctx context.Context, scope Scope) (*Feed, error) {
+	releases, err := s.repo.ListReleases(ctx, scope, s.entries)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every policy has at least one version, so an empty policy feed means an unknown policy
+	if scope.Kind == ScopePolicy && len(releases) == 0 {
+		return nil, errs.NewNotFoundError(errs.CodePolicyVersionNotFound, "No versions found for policy", map[string]any{"policyName": scope.Value})
+	}
+
+	linkBase := s.siteURL
+	if linkBase == "" {
+		linkBase = s.apiURL
+	}
+
+	feed := &atomFeed{
+		ID:        feedID(scope),
+		Title:     feedTitle(scope, releases),
+		Links:     []atomLink{{Rel: "self", Href: s.apiURL + feedPath(scope), Type: "application/atom+xml"}},
+		Generator: "PolicyHub",
+		Entries:   make([]atomEntry, 0, len(releases)),
+	}
+	if s.siteURL != "" {
+		alternate := s.siteURL + "/policies"
+		if scope.Kind == ScopePolicy {
+			alternate += "/" + url.PathEscape(scope.Value)
+		}
+		feed.Links = append(feed.Links, atomLink{Rel: "alternate", Href: alternate, Type: "text/html"})
+	}
+
+	// Feeds without entries still need a stable timestamp so that caches can validate them
+	var updated time.Time
+	for _, release := range releases {
+		if release.Updated.After(updated) {
+			updated = release.Updated
+		}
+		feed.Entries = append(feed.Entries, toAtomEntry(release, linkBase))
+	}
+	if updated.IsZero() {
+		feed.Updated = atomTime(time.Unix(0, 0))
+	} else {
+		feed.Updated = atomTime(updated)
+	}
+
+	body, err := feed.render()
+	if err != nil {
+		return nil, errs.NewInternalError("failed to render feed", map[string]any{"error": err.Error()})
+	}
+
+	return &Feed{Body: body, Updated: updated}, nil
+}
+
+func toAtomEntry(release *Release, linkBase string) atomEntry {
+	published := release.CreatedAt
+	if release.ReleaseDate != nil {
+		published = *release.ReleaseDate
+	}
+
+	entry := atomEntry{
+		ID:        fmt.Sprintf("urn:policyhub:release:%s:%s", release.PolicyName, release.Version),
+		Title:     fmt.Sprintf("%s %s", release.DisplayName, release.Version),
+		Updated:   atomTime(release.Updated),
+		Published: atomTime(published),
+		Author:    atomPerson{Name: release.Provider},
+		Links: []atomLink{{
+			Rel:  "alternate",
+			Href: fmt.Sprintf("%s/policies/%s/versions/%s", linkBase, url.PathEscape(release.PolicyName), url.PathEscape(release.Version)),
+		}},
+		Categories: make([]atomCategory, 0, len(release.Categories)),
+	}
+	for _, category := range release.Categories {
+		entry.Categories = append(entry.Categories, atomCategory{Term: category})
+	}
+	if release.Description != nil && *release.Description != "" {
+		entry.Summary = &atomText{Type: "text", Body: *release.Description}
+	}
+	// Documentation is Markdown, which feed readers show best as plain text
+	if release.Content != nil {
+		entry.Content = &atomText{Type: "text", Body: *release.Content}
+	}
+	return entry
+}
+
+func feedID(scope Scope) string {
+	if scope.Kind == ScopeCatalog {
+		return "urn:policyhub:feed:releases"
+	}
+	return fmt.Sprintf("urn:policyhub:feed:%s:%s", scope.Kind, url.PathEscape(scope.Value))
+}
+
+// feedPath returns the path of the feed of scope below the API base URL
+func feedPath(scope Scope) string {
+	switch scope.Kind {
+	case ScopePolicy:
+		return "/feeds/policies/" + url.PathEscape(scope.Value)
+	case ScopeProvider:
+		return "/feeds/providers/" + url.PathEscape(scope.Value)
+	case ScopeCategory:
+		return "/feeds/categories/" + url.PathEscape(scope.Value)
+	default:
+		return "/feeds/releases"
+	}
+}
+
+func feedTitle(scope Scope, releases []*Release) string {
+	switch scope.Kind {
+	case ScopePolicy:
+		// Name the policy as it is displayed in its latest release
+		return fmt.Sprintf("%s releases", releases[0].DisplayName)
+	case ScopeProvider:
+		return fmt.Sprintf("PolicyHub releases by %s", scope.Value)
+	case ScopeCategory:
+		return fmt.Sprintf("PolicyHub releases in %s", scope.Value)
+	default:
+		return "PolicyHub releases"
+	}
+}
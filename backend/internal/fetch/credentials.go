@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package fetch
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/wso2/policyhub/internal/config"
+)
+
+// credentials authenticates requests with the configured per-host credentials
+type credentials struct {
+	entries []config.FetchCredential
+	headers []string // Every header that may carry a secret
+	secrets []string
+}
+
+func newCredentials(entries []config.FetchCredential) *credentials {
+	c := &credentials{entries: entries, headers: []string{"Authorization"}}
+	for _, entry := range entries {
+		if entry.Type == config.CredentialHeader {
+			c.headers = append(c.headers, entry.Header)
+		}
+		c.secrets = append(c.secrets, string(entry.Secret))
+	}
+	return c
+}
+
+// apply sets the credentials for the host of a request, removing any set for another host.
+// Credentials are only sent over https.
+func (c *credentials) apply(req *http.Request) {
+	for _, header := range c.headers {
+		req.Header.Del(header)
+	}
+	if req.URL.Scheme != "https" {
+		return
+	}
+
+	host := strings.ToLower(req.URL.Hostname())
+	for _, entry := range c.entries {
+		if !hostMatches(entry.Host, host) {
+			continue
+		}
+		switch entry.Type {
+		case config.CredentialBearer:
+			req.Header.Set("Authorization", "Bearer "+string(entry.Secret))
+		case config.CredentialBasic:
+			req.SetBasicAuth(entry.Username, string(entry.Secret))
+		case config.CredentialHeader:
+			req.Header.Set(entry.Header, string(entry.Secret))
+		}
+		return
+	}
+}
+
+// redact removes credentials from the message of a fetch error, keeping the error chain
+func (c *credentials) redact(err error) error {
+	msg := err.Error()
+	redacted := msg
+	for _, secret := range c.secrets {
+		redacted = strings.ReplaceAll(redacted, secret, "[REDACTED]")
+	}
+	if redacted == msg {
+		return err
+	}
+	return &redactedError{msg: redacted, err: err}
+}
+
+// redactedError is an error whose message has credentials removed
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
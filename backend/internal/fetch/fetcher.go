@@ -0,0 +1,211 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/wso2/policyhub/internal/config"
+)
+
+// Fetcher fetches the content behind a sync URL
+type Fetcher interface {
+	// Open returns the content of a URL. Reading it fails with ErrTooLarge once it exceeds the size
+	// limit of the kind.
+	Open(ctx context.Context, rawURL string, kind Kind) (io.ReadCloser, error)
+}
+
+// ErrNotFound reports that a URL names content that does not exist
+var ErrNotFound = errors.New("not found")
+
+// Errors for fetches refused by the outbound restrictions. They are not transient: repeating the
+// fetch fails the same way.
+var (
+	ErrUnsupportedURL   = errors.New("no fetcher for URL")
+	ErrInvalidURL       = errors.New("invalid URL for the fetcher")
+	ErrScheme           = errors.New("URL scheme is not supported by the fetcher")
+	ErrHostNotAllowed   = errors.New("host is not in the allowed hosts")
+	ErrBlockedAddress   = errors.New("host resolves to a loopback, private or link-local address")
+	ErrPathNotAllowed   = errors.New("path is not below an allowed file root")
+	ErrTooManyRedirects = errors.New("too many redirects")
+	ErrContentType      = errors.New("unexpected content type")
+	ErrTooLarge         = errors.New("response body exceeds the size limit")
+)
+
+// IsRejected reports whether a fetch was refused by the outbound restrictions
+func IsRejected(err error) bool {
+	for _, target := range []error{
+		ErrUnsupportedURL, ErrInvalidURL, ErrScheme, ErrHostNotAllowed, ErrBlockedAddress, ErrPathNotAllowed,
+		ErrTooManyRedirects, ErrContentType, ErrTooLarge,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTransient reports whether a fetch failed in a way that may not repeat: a 5xx, 408 or 429
+// response, a timeout, or a connection that was refused, reset or closed early
+func IsTransient(err error) bool {
+	if IsRejected(err) || errors.Is(err, ErrNotFound) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError ||
+			statusErr.StatusCode == http.StatusRequestTimeout ||
+			statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// StatusError reports a response with a status other than 200 OK. 404 and 410 responses match
+// ErrNotFound.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status code %d", e.StatusCode)
+}
+
+func (e *StatusError) Is(target error) bool {
+	return target == ErrNotFound && (e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusGone)
+}
+
+// Kind is a kind of fetched content and the media types it may be served as
+type Kind struct {
+	Name       string
+	MediaTypes []string
+	MaxSize    int64 // Overrides the configured body size limits when set
+}
+
+// Kinds of content fetched during a sync. Generic media types such as text/plain, which raw file
+// hosts commonly serve, are only accepted when configured for every kind.
+var (
+	Definition = Kind{
+		Name:       "policy definition",
+		MediaTypes: []string{"application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml"},
+	}
+	Markdown = Kind{
+		Name:       "markdown",
+		MediaTypes: []string{"text/markdown", "text/x-markdown"},
+	}
+	JSON = Kind{
+		Name:       "JSON",
+		MediaTypes: []string{"application/json"},
+	}
+	Package = Kind{
+		Name:       "package",
+		MediaTypes: []string{"application/gzip", "application/x-gzip", "application/x-tar", "application/zip", "application/x-zip-compressed"},
+	}
+)
+
+// limits checks fetched content against the media types of its kind and the configured size limits
+type limits struct {
+	genericMediaTypes []string // Accepted for every kind
+	maxBodySize       int64
+	maxBodySizes      map[string]int64
+}
+
+func newLimits(cfg *config.FetchConfig) limits {
+	l := limits{
+		genericMediaTypes: cfg.GenericMediaTypes,
+		maxBodySize:       int64(cfg.MaxBodySizeKB) << 10,
+		maxBodySizes:      make(map[string]int64, len(cfg.MaxBodySizesKB)),
+	}
+	for mediaType, size := range cfg.MaxBodySizesKB {
+		l.maxBodySizes[mediaType] = int64(size) << 10
+	}
+	return l
+}
+
+// check checks the media type and size of content, with size -1 when unknown, and returns the size
+// limit that applies to it
+func (l limits) check(kind Kind, mediaType string, size int64) (int64, error) {
+	if !slices.Contains(kind.MediaTypes, mediaType) && !slices.Contains(l.genericMediaTypes, mediaType) {
+		return 0, fmt.Errorf("%w %s for %s", ErrContentType, mediaType, kind.Name)
+	}
+
+	limit := l.maxBodySize
+	if kind.MaxSize > 0 {
+		limit = kind.MaxSize
+	} else if mediaLimit, ok := l.maxBodySizes[mediaType]; ok {
+		limit = mediaLimit
+	}
+	if size > limit {
+		return 0, fmt.Errorf("%w of %d bytes", ErrTooLarge, limit)
+	}
+	return limit, nil
+}
+
+// Media types of local files, by extension. Other files are application/octet-stream.
+var fileMediaTypes = map[string]string{
+	".md":       "text/markdown",
+	".markdown": "text/markdown",
+	".yaml":     "application/yaml",
+	".yml":      "application/yaml",
+	".txt":      "text/plain",
+	".gz":       "application/gzip",
+	".tgz":      "application/gzip",
+	".tar":      "application/x-tar",
+	".zip":      "application/zip",
+}
+
+// fileMediaType returns the media type of a local file from its extension
+func fileMediaType(name string) string {
+	if mediaType, ok := fileMediaTypes[strings.ToLower(path.Ext(name))]; ok {
+		return mediaType
+	}
+	return "application/octet-stream"
+}
+
+// limitedBody is a body that fails once more than limit bytes are read
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	limit     int64
+}
+
+func newLimitedBody(body io.ReadCloser, limit int64) *limitedBody {
+	return &limitedBody{ReadCloser: body, remaining: limit, limit: limit}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, fmt.Errorf("%w of %d bytes", ErrTooLarge, b.limit)
+	}
+	// Read one byte past the limit to tell a body of exactly limit bytes from a larger one
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), fmt.Errorf("%w of %d bytes", ErrTooLarge, b.limit)
+	}
+	return n, err
+}
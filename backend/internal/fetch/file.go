@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/policyhub/internal/config"
+)
+
+// FileFetcher fetches file:// URLs for local and development publishing. Only files below the
+// configured roots can be read, and symbolic links may not lead out of a root.
+type FileFetcher struct {
+	roots  []string
+	limits limits
+}
+
+// NewFile creates a fetcher for files below the configured file roots
+func NewFile(cfg *config.FetchConfig) *FileFetcher {
+	return &FileFetcher{
+		roots:  cleanRoots(cfg.FileRoots),
+		limits: newLimits(cfg),
+	}
+}
+
+// Open opens the file named by a file:// URL
+func (f *FileFetcher) Open(_ context.Context, rawURL string, kind Kind) (io.ReadCloser, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "file" {
+		return nil, fmt.Errorf("%w: %s", ErrScheme, u.Scheme)
+	}
+	name, err := localPath(u)
+	if err != nil {
+		return nil, err
+	}
+	root, rel, err := splitRoot(f.roots, name)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := os.OpenRoot(root)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	file, err := r.Open(rel)
+	if err != nil {
+		return nil, fileError(name, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		file.Close()
+		return nil, fmt.Errorf("%s is not a regular file: %w", name, ErrNotFound)
+	}
+
+	limit, err := f.limits.check(kind, fileMediaType(name), info.Size())
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return newLimitedBody(file, limit), nil
+}
+
+// localPath returns the local path of a file:// URL, which must be absolute and name no other host
+func localPath(u *url.URL) (string, error) {
+	if u.Host != "" && u.Host != "localhost" {
+		return "", fmt.Errorf("%w: file URLs cannot name a host", ErrPathNotAllowed)
+	}
+	name := filepath.FromSlash(u.Path)
+	if !filepath.IsAbs(name) {
+		return "", fmt.Errorf("%w: %s is not absolute", ErrPathNotAllowed, u.Path)
+	}
+	return filepath.Clean(name), nil
+}
+
+// splitRoot returns the root that contains a path and the path relative to it
+func splitRoot(roots []string, name string) (string, string, error) {
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, name)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return root, rel, nil
+		}
+	}
+	return "", "", fmt.Errorf("%w: %s", ErrPathNotAllowed, name)
+}
+
+// cleanRoots returns the absolute, cleaned form of the configured file roots
+func cleanRoots(roots []string) []string {
+	cleaned := make([]string, 0, len(roots))
+	for _, root := range roots {
+		if abs, err := filepath.Abs(root); err == nil {
+			cleaned = append(cleaned, abs)
+		}
+	}
+	return cleaned
+}
+
+// fileError converts an error opening a file below a root. Besides missing files, os.Root fails
+// for paths that escape the root through a symbolic link, and those are refused.
+func fileError(name string, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+	return fmt.Errorf("%w: %v", ErrPathNotAllowed, err)
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package fetch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wso2/policyhub/internal/config"
+)
+
+// writeFile creates a file and its parent directories
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fileURL returns the file:// URL of a local path
+func fileURL(name string) string {
+	return "file://" + filepath.ToSlash(name)
+}
+
+func TestFileFetcherOpen(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "root")
+	outside := filepath.Join(base, "outside")
+
+	writeFile(t, filepath.Join(root, "rate-limit", "docs", "overview.md"), "# Overview")
+	writeFile(t, filepath.Join(root, "rate-limit", "policy-definition.yaml"), "name: rate-limit")
+	writeFile(t, filepath.Join(root, "large.md"), strings.Repeat("x", 2048))
+	writeFile(t, filepath.Join(outside, "secret.md"), "secret")
+	if err := os.Symlink(filepath.Join(outside, "secret.md"), filepath.Join(root, "escape.md")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "escape-dir")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join("rate-limit", "docs", "overview.md"), filepath.Join(root, "inside.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewFile(&config.FetchConfig{FileRoots: []string{root}, MaxBodySizeKB: 1})
+
+	tests := []struct {
+		name    string
+		url     string
+		kind    Kind
+		want    string
+		wantErr error
+	}{
+		{
+			name: "file below root",
+			url:  fileURL(filepath.Join(root, "rate-limit", "docs", "overview.md")),
+			kind: Markdown,
+			want: "# Overview",
+		},
+		{
+			name: "localhost host",
+			url:  "file://localhost" + filepath.ToSlash(filepath.Join(root, "rate-limit", "policy-definition.yaml")),
+			kind: Definition,
+			want: "name: rate-limit",
+		},
+		{
+			name: "symlink within root",
+			url:  fileURL(filepath.Join(root, "inside.md")),
+			kind: Markdown,
+			want: "# Overview",
+		},
+		{
+			name:    "parent directory escape",
+			url:     fileURL(root) + "/../outside/secret.md",
+			kind:    Markdown,
+			wantErr: ErrPathNotAllowed,
+		},
+		{
+			name:    "encoded parent directory escape",
+			url:     fileURL(root) + "/%2e%2e/outside/secret.md",
+			kind:    Markdown,
+			wantErr: ErrPathNotAllowed,
+		},
+		{
+			name:    "symlink out of root",
+			url:     fileURL(filepath.Join(root, "escape.md")),
+			kind:    Markdown,
+			wantErr: ErrPathNotAllowed,
+		},
+		{
+			name:    "directory symlink out of root",
+			url:     fileURL(filepath.Join(root, "escape-dir", "secret.md")),
+			kind:    Markdown,
+			wantErr: ErrPathNotAllowed,
+		},
+		{
+			name:    "other host",
+			url:     "file://example.com" + filepath.ToSlash(filepath.Join(root, "rate-limit", "docs", "overview.md")),
+			kind:    Markdown,
+			wantErr: ErrPathNotAllowed,
+		},
+		{
+			name:    "missing file",
+			url:     fileURL(filepath.Join(root, "rate-limit", "docs", "missing.md")),
+			kind:    Markdown,
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "directory",
+			url:     fileURL(filepath.Join(root, "rate-limit")),
+			kind:    Markdown,
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "too large",
+			url:     fileURL(filepath.Join(root, "large.md")),
+			kind:    Markdown,
+			wantErr: ErrTooLarge,
+		},
+		{
+			name:    "unexpected media type",
+			url:     fileURL(filepath.Join(root, "rate-limit", "policy-definition.yaml")),
+			kind:    Markdown,
+			wantErr: ErrContentType,
+		},
+		{
+			name:    "other scheme",
+			url:     "https://example.com/overview.md",
+			kind:    Markdown,
+			wantErr: ErrScheme,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := f.Open(context.Background(), tt.url, tt.kind)
+			if tt.wantErr != nil {
+				if body != nil {
+					body.Close()
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Open() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer body.Close()
+
+			got, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("reading body: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Open() content = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileFetcherWithoutRoots(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "overview.md")
+	writeFile(t, name, "# Overview")
+
+	f := NewFile(&config.FetchConfig{MaxBodySizeKB: 1})
+	if _, err := f.Open(context.Background(), fileURL(name), Markdown); !errors.Is(err, ErrPathNotAllowed) {
+		t.Fatalf("Open() error = %v, want %v", err, ErrPathNotAllowed)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/wso2/policyhub/internal/config"
+)
+
+// GitFetcher fetches files from local git repositories at a tag. A URL names the repository, the
+// tag and the file within the repository:
+//
+//	git+file:///srv/repos/policies?tag=v1.2.0&path=rate-limit/policy-definition.yml
+//
+// Repositories must be below the configured file roots. Objects are read with the git command.
+type GitFetcher struct {
+	roots  []string
+	limits limits
+}
+
+// NewGit creates a fetcher for git repositories below the configured file roots
+func NewGit(cfg *config.FetchConfig) *GitFetcher {
+	roots := cleanRoots(cfg.FileRoots)
+	for i, root := range roots {
+		// Repository paths are compared after resolving symbolic links, so roots are too
+		if resolved, err := filepath.EvalSymlinks(root); err == nil {
+			roots[i] = resolved
+		}
+	}
+	return &GitFetcher{roots: roots, limits: newLimits(cfg)}
+}
+
+// Open reads the file named by a git URL at its tag
+func (f *GitFetcher) Open(ctx context.Context, rawURL string, kind Kind) (io.ReadCloser, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "git+file" && u.Scheme != "file" {
+		return nil, fmt.Errorf("%w: %s", ErrScheme, u.Scheme)
+	}
+	repo, err := localPath(u)
+	if err != nil {
+		return nil, err
+	}
+	// Resolved so that a symbolic link below a root cannot point to a repository elsewhere
+	if repo, err = filepath.EvalSymlinks(repo); err != nil {
+		return nil, fmt.Errorf("git repository %s: %w", u.Path, err)
+	}
+	if _, _, err := splitRoot(f.roots, repo); err != nil {
+		return nil, err
+	}
+
+	tag, file, err := gitRef(u.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	// Look up the tag and the file in one call; each line answers "<object> <type> <size>" or
+	// "<name> missing"
+	out, err := f.git(ctx, repo, "refs/tags/"+tag+"\nrefs/tags/"+tag+":"+file+"\n", "cat-file", "--batch-check")
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 || strings.HasSuffix(lines[0], " missing") {
+		return nil, fmt.Errorf("tag %s not found in git repository %s", tag, repo)
+	}
+	fields := strings.Fields(lines[1])
+	if len(fields) != 3 || fields[1] != "blob" {
+		return nil, fmt.Errorf("%s at tag %s: %w", file, tag, ErrNotFound)
+	}
+	size, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected git object size %q", fields[2])
+	}
+
+	if _, err := f.limits.check(kind, fileMediaType(file), size); err != nil {
+		return nil, err
+	}
+	content, err := f.git(ctx, repo, "", "cat-file", "blob", fields[0])
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+// gitRef returns the tag and file path of a git URL
+func gitRef(query url.Values) (string, string, error) {
+	tag, file := query.Get("tag"), query.Get("path")
+	if tag == "" || file == "" {
+		return "", "", fmt.Errorf("%w: git URLs need tag and path parameters", ErrInvalidURL)
+	}
+	// Characters that git does not allow in ref names, or that would change the object name
+	if strings.HasPrefix(tag, "-") || strings.ContainsAny(tag, ": \t\n~^?*[\\") || strings.Contains(tag, "..") {
+		return "", "", fmt.Errorf("%w: invalid tag %q", ErrInvalidURL, tag)
+	}
+	file = path.Clean(strings.TrimPrefix(file, "/"))
+	if file == "." || file == ".." || strings.HasPrefix(file, "../") || strings.Contains(file, "\n") {
+		return "", "", fmt.Errorf("%w: invalid path %q", ErrInvalidURL, file)
+	}
+	return tag, file, nil
+}
+
+// git runs a git command in a repository, stopping repository discovery at the repository itself
+func (f *GitFetcher) git(ctx context.Context, repo, stdin string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-c", "safe.directory=" + repo, "-C", repo}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_CEILING_DIRECTORIES="+filepath.Dir(repo))
+	cmd.Stdin = strings.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s in %s: %w: %s", args[0], repo, err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
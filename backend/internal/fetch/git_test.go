@@ -0,0 +1,231 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package fetch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wso2/policyhub/internal/config"
+)
+
+// initGitRepo creates a git repository with the given files committed and tagged v1.0.0
+func initGitRepo(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		writeFile(t, filepath.Join(dir, filepath.FromSlash(name)), content)
+	}
+	runGit(t, dir, "init", "--quiet")
+	runGit(t, dir, "add", "--all")
+	runGit(t, dir, "commit", "--quiet", "--message", "Release 1.0.0")
+	runGit(t, dir, "tag", "v1.0.0")
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_GLOBAL="+os.DevNull,
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_AUTHOR_NAME=Test",
+		"GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test",
+		"GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+	}
+}
+
+// gitURL returns the git+file:// URL of a file in a repository at a tag
+func gitURL(repo, tag, file string) string {
+	return "git+file://" + filepath.ToSlash(repo) + "?tag=" + tag + "&path=" + file
+}
+
+func TestGitFetcherOpen(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	base := t.TempDir()
+	root := filepath.Join(base, "root")
+	repo := filepath.Join(root, "policies")
+	outsideRepo := filepath.Join(base, "outside")
+
+	// A symbolic link committed to the repository is stored as a blob holding its target
+	if err := os.MkdirAll(repo, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("../../outside/secret.md", filepath.Join(repo, "link.md")); err != nil {
+		t.Fatal(err)
+	}
+	initGitRepo(t, repo, map[string]string{
+		"rate-limit/policy-definition.yaml": "name: rate-limit",
+		"rate-limit/docs/overview.md":       "# Overview",
+		"large.md":                          strings.Repeat("x", 2048),
+	})
+	initGitRepo(t, outsideRepo, map[string]string{
+		"secret.md": "secret",
+	})
+	if err := os.Symlink(outsideRepo, filepath.Join(root, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	// Content committed after the tag is not visible at the tag
+	writeFile(t, filepath.Join(repo, "rate-limit", "docs", "changelog.md"), "# Changelog")
+	runGit(t, repo, "add", "--all")
+	runGit(t, repo, "commit", "--quiet", "--message", "Add changelog")
+
+	f := NewGit(&config.FetchConfig{FileRoots: []string{root}, MaxBodySizeKB: 1})
+
+	tests := []struct {
+		name      string
+		url       string
+		kind      Kind
+		want      string
+		wantErr   error
+		wantErrIn string // Expected in the message of errors without a sentinel
+	}{
+		{
+			name: "file at tag",
+			url:  gitURL(repo, "v1.0.0", "rate-limit/docs/overview.md"),
+			kind: Markdown,
+			want: "# Overview",
+		},
+		{
+			name: "file scheme and leading slash",
+			url:  "file://" + filepath.ToSlash(repo) + "?tag=v1.0.0&path=/rate-limit/policy-definition.yaml",
+			kind: Definition,
+			want: "name: rate-limit",
+		},
+		{
+			name: "committed symlink is not followed",
+			url:  gitURL(repo, "v1.0.0", "link.md"),
+			kind: Markdown,
+			want: "../../outside/secret.md",
+		},
+		{
+			name:    "file added after tag",
+			url:     gitURL(repo, "v1.0.0", "rate-limit/docs/changelog.md"),
+			kind:    Markdown,
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "directory",
+			url:     gitURL(repo, "v1.0.0", "rate-limit/docs"),
+			kind:    Markdown,
+			wantErr: ErrNotFound,
+		},
+		{
+			name:      "missing tag",
+			url:       gitURL(repo, "v9.9.9", "rate-limit/docs/overview.md"),
+			kind:      Markdown,
+			wantErrIn: "tag v9.9.9 not found",
+		},
+		{
+			name:    "path escaping the repository",
+			url:     gitURL(repo, "v1.0.0", "../outside/secret.md"),
+			kind:    Markdown,
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name:    "path escaping after cleaning",
+			url:     gitURL(repo, "v1.0.0", "rate-limit/../../secret.md"),
+			kind:    Markdown,
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name:    "repository path escaping the root",
+			url:     gitURL(root+"/../outside", "v1.0.0", "secret.md"),
+			kind:    Markdown,
+			wantErr: ErrPathNotAllowed,
+		},
+		{
+			name:    "repository symlink out of root",
+			url:     gitURL(filepath.Join(root, "escape"), "v1.0.0", "secret.md"),
+			kind:    Markdown,
+			wantErr: ErrPathNotAllowed,
+		},
+		{
+			name:    "tag with revision syntax",
+			url:     gitURL(repo, "v1.0.0~1", "rate-limit/docs/overview.md"),
+			kind:    Markdown,
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name:    "tag as option",
+			url:     gitURL(repo, "--output=x", "rate-limit/docs/overview.md"),
+			kind:    Markdown,
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name:    "missing path",
+			url:     "git+file://" + filepath.ToSlash(repo) + "?tag=v1.0.0",
+			kind:    Markdown,
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name:    "too large",
+			url:     gitURL(repo, "v1.0.0", "large.md"),
+			kind:    Markdown,
+			wantErr: ErrTooLarge,
+		},
+		{
+			name:    "unexpected media type",
+			url:     gitURL(repo, "v1.0.0", "rate-limit/policy-definition.yaml"),
+			kind:    Markdown,
+			wantErr: ErrContentType,
+		},
+		{
+			name:    "other scheme",
+			url:     "https://example.com/policies.git?tag=v1.0.0&path=overview.md",
+			kind:    Markdown,
+			wantErr: ErrScheme,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := f.Open(context.Background(), tt.url, tt.kind)
+			if tt.wantErr != nil || tt.wantErrIn != "" {
+				if body != nil {
+					body.Close()
+				}
+				if err == nil {
+					t.Fatal("Open() succeeded, want an error")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Open() error = %v, want %v", err, tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantErrIn) {
+					t.Fatalf("Open() error = %v, want it to mention %q", err, tt.wantErrIn)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer body.Close()
+
+			got, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("reading body: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Open() content = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
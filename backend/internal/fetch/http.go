@@ -0,0 +1,263 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package fetch
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/wso2/policyhub/internal/config"
+)
+
+// HTTPFetcher fetches http and https URLs. Only URLs on the allowed hosts are fetched, connections
+// to loopback, private and link-local addresses are refused after DNS resolution, redirect targets
+// are resolved and checked before they are followed, and response bodies are checked against the media types and size limits of the fetched kind.
+// Requests to hosts with configured credentials are authenticated, and may go through a proxy.
+type HTTPFetcher struct {
+	client       *http.Client
+	allowedHosts []string
+	limits       limits
+	credentials  *credentials
+	// checkDNS resolves hosts before fetching when connections go through a proxy, which makes the
+	// connection checks see the proxy address instead of the target
+	checkDNS bool
+	// checkRedirectDNS resolves the host of every redirect before following it, so that a redirect to
+	// an internal address is refused before any request is sent, with or without a proxy
+	checkRedirectDNS bool
+}
+
+// NewHTTP creates an HTTP fetcher whose fetches time out after the given duration
+func NewHTTP(cfg *config.FetchConfig, timeout time.Duration) *HTTPFetcher {
+	f := &HTTPFetcher{
+		allowedHosts: make([]string, 0, len(cfg.AllowedHosts)),
+		limits:       newLimits(cfg),
+		credentials:  newCredentials(cfg.Credentials),
+	}
+	for _, host := range cfg.AllowedHosts {
+		f.allowedHosts = append(f.allowedHosts, strings.ToLower(host))
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	var proxyAddr string
+	if cfg.ProxyURL != "" {
+		proxyURL, _ := url.Parse(cfg.ProxyURL) // Validated with the configuration
+		transport.Proxy = http.ProxyURL(proxyURL)
+		proxyAddr = canonicalAddr(proxyURL)
+		if password, ok := proxyURL.User.Password(); ok {
+			f.credentials.secrets = append(f.credentials.secrets, password)
+		}
+		f.checkDNS = !cfg.AllowPrivateNetworks
+	}
+	f.checkRedirectDNS = !cfg.AllowPrivateNetworks
+	if !cfg.AllowPrivateNetworks {
+		// Runs for every connection with the resolved address, so names that resolve to internal
+		// addresses are caught no matter how the URL was reached. The proxy itself may be internal.
+		checked := *dialer
+		checked.Control = CheckAddress
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if addr == proxyAddr {
+				return dialer.DialContext(ctx, network, addr)
+			}
+			return checked.DialContext(ctx, network, addr)
+		}
+	}
+	if cfg.CABundle != nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pool.AppendCertsFromPEM(cfg.CABundle) // Validated with the configuration
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	maxRedirects := cfg.MaxRedirects
+	f.client = &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return ErrTooManyRedirects
+			}
+			if err := f.checkURL(req.Context(), req.URL, f.checkRedirectDNS); err != nil {
+				return err
+			}
+			// Headers are copied from the previous request; credentials must match the new host
+			f.credentials.apply(req)
+			return nil
+		},
+	}
+
+	return f
+}
+
+// Open fetches a URL and returns its response body
+func (f *HTTPFetcher) Open(ctx context.Context, rawURL string, kind Kind) (io.ReadCloser, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.checkURL(ctx, u, f.checkDNS); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	f.credentials.apply(req)
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, f.credentials.redact(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	header := resp.Header.Get("Content-Type")
+	if header == "" {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: none given for %s", ErrContentType, kind.Name)
+	}
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %q", ErrContentType, header)
+	}
+	limit, err := f.limits.check(kind, mediaType, resp.ContentLength)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	return newLimitedBody(resp.Body, limit), nil
+}
+
+// checkURL checks the scheme and host of a URL that is about to be fetched, and with resolve also the
+// addresses the host resolves to
+func (f *HTTPFetcher) checkURL(ctx context.Context, u *url.URL, resolve bool) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: %s", ErrScheme, u.Scheme)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if len(f.allowedHosts) > 0 && !matchesAny(f.allowedHosts, host) {
+		return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+	}
+
+	if resolve {
+		return CheckHost(ctx, host)
+	}
+	return nil
+}
+
+// CheckHost resolves a host and returns ErrBlockedAddress when it is, or resolves to, an internal
+// address. The result only holds at the time of the check; connections still need CheckAddress.
+func CheckHost(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if addr = addr.Unmap(); isBlocked(addr) {
+			return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+		}
+	}
+	return nil
+}
+
+// matchesAny reports whether a host matches one of the patterns: a host name, or "*.example.com"
+// for example.com and its subdomains
+func matchesAny(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		if hostMatches(pattern, host) {
+			return true
+		}
+	}
+	return false
+}
+
+func hostMatches(pattern, host string) bool {
+	if domain, ok := strings.CutPrefix(pattern, "*."); ok {
+		return host == domain || strings.HasSuffix(host, "."+domain)
+	}
+	return host == pattern
+}
+
+// canonicalAddr returns the host:port a URL connects to
+func canonicalAddr(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[u.Scheme]
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// Address ranges that are refused besides loopback, private, link-local, multicast and unspecified
+// addresses
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "This" network
+	netip.MustParsePrefix("100.64.0.0/10"),  // Shared address space, used by some cloud metadata services
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"),  // Benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),    // Reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64, which reaches embedded IPv4 addresses
+	netip.MustParsePrefix("64:ff9b:1::/48"), // Local-use NAT64
+	netip.MustParsePrefix("2002::/16"),      // 6to4, which embeds an IPv4 address
+	netip.MustParsePrefix("2001::/32"),      // Teredo, which embeds an IPv4 address
+}
+
+// CheckAddress is a dialer control function that refuses connections to internal addresses
+func CheckAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	if isBlocked(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// isBlocked reports whether an address is internal
+func isBlocked(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return true
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,272 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package fetch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/wso2/policyhub/internal/config"
+)
+
+func TestCheckAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		wantErr error
+	}{
+		{name: "public IPv4", address: "93.184.216.34:443"},
+		{name: "public IPv6", address: "[2606:2800:220:1:248:1893:25c8:1946]:443"},
+		{name: "loopback", address: "127.0.0.1:80", wantErr: ErrBlockedAddress},
+		{name: "loopback outside 127.0.0.1", address: "127.1.2.3:80", wantErr: ErrBlockedAddress},
+		{name: "IPv6 loopback", address: "[::1]:80", wantErr: ErrBlockedAddress},
+		{name: "unspecified", address: "0.0.0.0:80", wantErr: ErrBlockedAddress},
+		{name: "this network", address: "0.1.2.3:80", wantErr: ErrBlockedAddress},
+		{name: "IPv6 unspecified", address: "[::]:80", wantErr: ErrBlockedAddress},
+		{name: "private 10/8", address: "10.0.0.1:80", wantErr: ErrBlockedAddress},
+		{name: "private 172.16/12", address: "172.16.0.1:80", wantErr: ErrBlockedAddress},
+		{name: "private 192.168/16", address: "192.168.1.1:80", wantErr: ErrBlockedAddress},
+		{name: "link-local", address: "169.254.1.1:80", wantErr: ErrBlockedAddress},
+		{name: "metadata service", address: "169.254.169.254:80", wantErr: ErrBlockedAddress},
+		{name: "IPv6 link-local", address: "[fe80::1]:80", wantErr: ErrBlockedAddress},
+		{name: "shared address space", address: "100.64.0.1:80", wantErr: ErrBlockedAddress},
+		{name: "shared address space upper bound", address: "100.127.255.254:80", wantErr: ErrBlockedAddress},
+		{name: "above shared address space", address: "100.128.0.1:80"},
+		{name: "unique local", address: "[fd00::1]:80", wantErr: ErrBlockedAddress},
+		{name: "unique local fd00::/8 upper bound", address: "[fdff:ffff::1]:80", wantErr: ErrBlockedAddress},
+		{name: "IPv4-mapped loopback", address: "[::ffff:127.0.0.1]:80", wantErr: ErrBlockedAddress},
+		{name: "IPv4-mapped metadata service", address: "[::ffff:169.254.169.254]:80", wantErr: ErrBlockedAddress},
+		{name: "IPv4-mapped private", address: "[::ffff:10.0.0.1]:80", wantErr: ErrBlockedAddress},
+		{name: "IPv4-mapped public", address: "[::ffff:93.184.216.34]:443"},
+		{name: "NAT64 metadata service", address: "[64:ff9b::a9fe:a9fe]:80", wantErr: ErrBlockedAddress},
+		{name: "6to4", address: "[2002:7f00:1::1]:80", wantErr: ErrBlockedAddress},
+		{name: "multicast", address: "224.0.0.1:80", wantErr: ErrBlockedAddress},
+		{name: "broadcast", address: "255.255.255.255:80", wantErr: ErrBlockedAddress},
+		{name: "not an IP address", address: "example.com:80", wantErr: ErrBlockedAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckAddress("tcp", tt.address, nil)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CheckAddress(%q) error = %v, want %v", tt.address, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CheckAddress(%q) error = %v", tt.address, err)
+			}
+		})
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		host     string
+		want     bool
+	}{
+		{name: "exact host", patterns: []string{"example.com"}, host: "example.com", want: true},
+		{name: "exact host does not match subdomain", patterns: []string{"example.com"}, host: "www.example.com"},
+		{name: "wildcard matches the domain itself", patterns: []string{"*.example.com"}, host: "example.com", want: true},
+		{name: "wildcard matches subdomain", patterns: []string{"*.example.com"}, host: "raw.example.com", want: true},
+		{name: "wildcard matches nested subdomain", patterns: []string{"*.example.com"}, host: "a.b.example.com", want: true},
+		{name: "wildcard does not match suffix without dot", patterns: []string{"*.example.com"}, host: "badexample.com"},
+		{name: "wildcard does not match domain as prefix", patterns: []string{"*.example.com"}, host: "example.com.evil.net"},
+		{name: "wildcard does not match parent", patterns: []string{"*.raw.example.com"}, host: "example.com"},
+		{name: "any of several patterns", patterns: []string{"github.com", "*.example.com"}, host: "cdn.example.com", want: true},
+		{name: "none of several patterns", patterns: []string{"github.com", "*.example.com"}, host: "gitlab.com"},
+		{name: "no patterns", patterns: nil, host: "example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesAny(tt.patterns, tt.host); got != tt.want {
+				t.Errorf("matchesAny(%q, %q) = %v, want %v", tt.patterns, tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPFetcherCheckURL(t *testing.T) {
+	f := NewHTTP(&config.FetchConfig{AllowedHosts: []string{"*.Example.com", "169.254.169.254", "::ffff:127.0.0.1"}}, 0)
+
+	tests := []struct {
+		name    string
+		url     string
+		resolve bool
+		wantErr error
+	}{
+		{name: "allowed host", url: "https://raw.example.com/policy-definition.yaml"},
+		{name: "allowed host in other case", url: "https://RAW.example.COM/policy-definition.yaml"},
+		{name: "wildcard domain itself", url: "https://example.com/policy-definition.yaml"},
+		{name: "host with allowed domain as suffix", url: "https://badexample.com/policy-definition.yaml", wantErr: ErrHostNotAllowed},
+		{name: "host not allowed", url: "https://github.com/policy-definition.yaml", wantErr: ErrHostNotAllowed},
+		{name: "other scheme", url: "ftp://raw.example.com/policy-definition.yaml", wantErr: ErrScheme},
+		{name: "allowed internal host without resolving", url: "http://169.254.169.254/latest/meta-data/"},
+		{name: "allowed metadata host when resolving", url: "http://169.254.169.254/latest/meta-data/", resolve: true, wantErr: ErrBlockedAddress},
+		{name: "allowed IPv4-mapped loopback when resolving", url: "http://[::ffff:127.0.0.1]/", resolve: true, wantErr: ErrBlockedAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = f.checkURL(context.Background(), u, tt.resolve)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("checkURL(%q) error = %v, want %v", tt.url, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("checkURL(%q) error = %v", tt.url, err)
+			}
+		})
+	}
+}
+
+func TestHTTPFetcherOpen(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/overview.md", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/markdown")
+		_, _ = io.WriteString(w, "# Overview")
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, r.URL.Query().Get("to"), http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	redirect := func(target string) string {
+		return server.URL + "/redirect?to=" + url.QueryEscape(target)
+	}
+
+	// The test server listens on loopback, so private networks are allowed for the connections. The
+	// checked fetchers switch the redirect checks back on to see them refuse internal targets before
+	// connecting, which also refuses redirects back to the test server.
+	lenient := NewHTTP(&config.FetchConfig{AllowPrivateNetworks: true, MaxRedirects: 2, MaxBodySizeKB: 1}, 0)
+	checked := NewHTTP(&config.FetchConfig{AllowPrivateNetworks: true, MaxRedirects: 2, MaxBodySizeKB: 1}, 0)
+	checked.checkRedirectDNS = true
+	restricted := NewHTTP(&config.FetchConfig{AllowedHosts: []string{"127.0.0.1"}, AllowPrivateNetworks: true, MaxRedirects: 2, MaxBodySizeKB: 1}, 0)
+	restricted.checkRedirectDNS = true
+	blocked := NewHTTP(&config.FetchConfig{MaxRedirects: 2, MaxBodySizeKB: 1}, 0)
+
+	tests := []struct {
+		name    string
+		fetcher *HTTPFetcher
+		url     string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "direct fetch",
+			fetcher: lenient,
+			url:     server.URL + "/overview.md",
+			want:    "# Overview",
+		},
+		{
+			name:    "redirect within the server",
+			fetcher: lenient,
+			url:     redirect(server.URL + "/overview.md"),
+			want:    "# Overview",
+		},
+		{
+			name:    "redirect to metadata service",
+			fetcher: checked,
+			url:     redirect("http://169.254.169.254/latest/meta-data/"),
+			wantErr: ErrBlockedAddress,
+		},
+		{
+			name:    "redirect to IPv4-mapped loopback",
+			fetcher: checked,
+			url:     redirect("http://[::ffff:127.0.0.1]/"),
+			wantErr: ErrBlockedAddress,
+		},
+		{
+			name:    "redirect to unspecified address",
+			fetcher: checked,
+			url:     redirect("http://0.0.0.0/"),
+			wantErr: ErrBlockedAddress,
+		},
+		{
+			name:    "redirect to shared address space",
+			fetcher: checked,
+			url:     redirect("http://100.100.100.200/"),
+			wantErr: ErrBlockedAddress,
+		},
+		{
+			name:    "redirect to unique local address",
+			fetcher: checked,
+			url:     redirect("http://[fd00::1]/"),
+			wantErr: ErrBlockedAddress,
+		},
+		{
+			name:    "redirect to other scheme",
+			fetcher: checked,
+			url:     redirect("file:///etc/passwd"),
+			wantErr: ErrScheme,
+		},
+		{
+			name:    "redirect to host not allowed",
+			fetcher: restricted,
+			url:     redirect("http://badexample.com/overview.md"),
+			wantErr: ErrHostNotAllowed,
+		},
+		{
+			name:    "too many redirects",
+			fetcher: lenient,
+			url:     redirect(redirect(redirect(server.URL + "/overview.md"))),
+			wantErr: ErrTooManyRedirects,
+		},
+		{
+			name:    "connection to loopback",
+			fetcher: blocked,
+			url:     server.URL + "/overview.md",
+			wantErr: ErrBlockedAddress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := tt.fetcher.Open(context.Background(), tt.url, Markdown)
+			if tt.wantErr != nil {
+				if body != nil {
+					body.Close()
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Open() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer body.Close()
+
+			got, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("reading body: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Open() content = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wso2/policyhub/internal/config"
+)
+
+// Registry chooses the fetcher for a sync URL: the fetcher registered for the source type of the
+// request if there is one, otherwise the fetcher registered for the URL scheme
+type Registry struct {
+	schemes     map[string]Fetcher
+	sourceTypes map[string]Fetcher
+
+	retries int           // Retries of transient failures in Get
+	backoff time.Duration // Delay before the first retry
+}
+
+// Source types with a built-in fetcher
+const (
+	SourceTypeLocalGit = "local-git" // file:// URLs name git repositories, as with git+file://
+)
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		schemes:     make(map[string]Fetcher),
+		sourceTypes: make(map[string]Fetcher),
+	}
+}
+
+// New creates a registry with the built-in fetchers: HTTP for http and https URLs, whose fetches
+// time out after the given duration, and, when file roots are configured, local files for file://
+// and local git repositories for git+file:// URLs and the local-git source type
+func New(cfg *config.FetchConfig, timeout time.Duration) *Registry {
+	r := NewRegistry()
+	r.retries = cfg.Retries
+	r.backoff = time.Duration(cfg.RetryBackoffMs) * time.Millisecond
+
+	httpFetcher := NewHTTP(cfg, timeout)
+	r.RegisterScheme("http", httpFetcher)
+	r.RegisterScheme("https", httpFetcher)
+
+	if len(cfg.FileRoots) > 0 {
+		gitFetcher := NewGit(cfg)
+		r.RegisterScheme("file", NewFile(cfg))
+		r.RegisterScheme("git+file", gitFetcher)
+		r.RegisterSourceType(SourceTypeLocalGit, gitFetcher)
+	}
+
+	return r
+}
+
+// RegisterScheme sets the fetcher for URLs with a scheme
+func (r *Registry) RegisterScheme(scheme string, f Fetcher) {
+	r.schemes[strings.ToLower(scheme)] = f
+}
+
+// RegisterSourceType sets the fetcher for every URL of requests with a source type
+func (r *Registry) RegisterSourceType(sourceType string, f Fetcher) {
+	r.sourceTypes[sourceType] = f
+}
+
+// Open fetches a URL with the fetcher for the source type or the URL scheme
+func (r *Registry) Open(ctx context.Context, sourceType, rawURL string, kind Kind) (io.ReadCloser, error) {
+	f, err := r.fetcher(sourceType, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return f.Open(ctx, rawURL, kind)
+}
+
+// Get fetches a URL and reads all of its content. Transient failures are retried with jittered
+// exponential backoff for as long as the context allows.
+func (r *Registry) Get(ctx context.Context, sourceType, rawURL string, kind Kind) ([]byte, error) {
+	for retry := 0; ; retry++ {
+		content, err := r.get(ctx, sourceType, rawURL, kind)
+		if err == nil || retry >= r.retries || !IsTransient(err) || ctx.Err() != nil {
+			return content, err
+		}
+
+		// Half the delay is fixed and half random, so that parallel fetches spread out
+		delay := r.backoff << retry
+		delay = delay/2 + rand.N(delay/2+1)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return nil, err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *Registry) get(ctx context.Context, sourceType, rawURL string, kind Kind) ([]byte, error) {
+	body, err := r.Open(ctx, sourceType, rawURL, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+func (r *Registry) fetcher(sourceType, rawURL string) (Fetcher, error) {
+	if f, ok := r.sourceTypes[sourceType]; ok {
+		return f, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if f, ok := r.schemes[strings.ToLower(u.Scheme)]; ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("%w with scheme %q", ErrUnsupportedURL, u.Scheme)
+}
@@ -9,7 +9,10 @@
 
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // BaseResponse is the standard API response envelope
 type BaseResponse struct {
@@ -47,14 +50,31 @@ type PaginatedMetaDTO struct {
 	Timestamp  time.Time     `json:"timestamp"`
 	RequestID  string        `json:"request_id"`
 	Pagination PaginationDTO `json:"pagination"`
+	Facets     *FacetsDTO    `json:"facets,omitempty"`
 }
 
 // PaginationDTO contains pagination information
+// TotalItems and TotalPages are omitted when the count was not requested
 type PaginationDTO struct {
-	Page       int `json:"page"`
-	PageSize   int `json:"pageSize"`
-	TotalItems int `json:"totalItems"`
-	TotalPages int `json:"totalPages"`
+	Page       int    `json:"page"`
+	PageSize   int    `json:"pageSize"`
+	TotalItems *int   `json:"totalItems,omitempty"`
+	TotalPages *int   `json:"totalPages,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// FacetCountDTO represents a facet value and its number of matching policies
+type FacetCountDTO struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// FacetsDTO contains facet counts for each filterable dimension
+type FacetsDTO struct {
+	Categories []FacetCountDTO `json:"categories"`
+	Providers  []FacetCountDTO `json:"providers"`
+	Platforms  []FacetCountDTO `json:"platforms"`
+	Tags       []FacetCountDTO `json:"tags"`
 }
 
 // DocsAllResponseDTO contains all documentation pages as an array
@@ -62,11 +82,39 @@ type DocsAllResponseDTO []DocsSingleResponseDTO
 
 // DocsSingleResponseDTO represents a single documentation page
 type DocsSingleResponseDTO struct {
-	Page    string `json:"page"`
+	Page          string `json:"page"`
+	Format        string `json:"format"`
+	Content       string `json:"content"`
+	SourceVersion string `json:"sourceVersion,omitempty"` // Version the content is stored for; set with fallback
+}
+
+// DocRevisionDTO represents one stored revision of a documentation page
+type DocRevisionDTO struct {
+	Page      string    `json:"page"`
+	Revision  int       `json:"revision"`
+	Origin    string    `json:"origin"`
+	Actor     string    `json:"actor,omitempty"`
+	Size      int       `json:"size"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// DocRevisionDetailDTO represents a revision of a documentation page with its content
+type DocRevisionDetailDTO struct {
+	DocRevisionDTO
 	Format  string `json:"format"`
 	Content string `json:"content"`
 }
 
+// DocDiffDTO represents a unified line diff between two revisions of a documentation page
+type DocDiffDTO struct {
+	Page         string `json:"page"`
+	FromRevision int    `json:"fromRevision"`
+	ToRevision   int    `json:"toRevision"`
+	Added        int    `json:"added"`
+	Removed      int    `json:"removed"`
+	Diff         string `json:"diff"`
+}
+
 // PolicyMetadataDTO represents policy metadata
 type PolicyMetadataDTO struct {
 	DisplayName        string   `json:"displayName" binding:"required"`
@@ -90,13 +138,262 @@ type SyncRequestDTO struct {
 	Documentation map[string]string `json:"documentation"`
 	AssetsBaseURL string            `json:"assetsBaseUrl"`
 	Checksum      *ChecksumDTO      `json:"checksum"`
+	RequiredDocs  []string          `json:"requiredDocs"`
 }
 
 // SyncResponseDTO represents the sync response payload
 type SyncResponseDTO struct {
+	PolicyName string         `json:"policyName"`
+	Version    string         `json:"version"`
+	Status     string         `json:"status"`
+	Docs       []DocResultDTO `json:"docs,omitempty"`
+}
+
+// SyncDryRunDTO represents the report of a sync dry run
+type SyncDryRunDTO struct {
+	PolicyName       string         `json:"policyName"`
+	Version          string         `json:"version"`
+	Status           string         `json:"status"`
+	DryRun           bool           `json:"dryRun"`
+	IsLatest         bool           `json:"isLatest"`
+	CurrentLatest    string         `json:"currentLatest,omitempty"`
+	DefinitionSHA256 string         `json:"definitionSha256"`
+	Docs             []DocResultDTO `json:"docs,omitempty"`
+}
+
+// DocResultDTO represents how one doc page of a sync was fetched
+type DocResultDTO struct {
+	Page     string `json:"page"`
+	Status   string `json:"status"`
+	URL      string `json:"url,omitempty"`
+	Required bool   `json:"required,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// PackagePublishRequestDTO represents a request to publish a policy package downloaded from a URL
+type PackagePublishRequestDTO struct {
+	URL        string `json:"url" binding:"required"`
+	PolicyName string `json:"policyName"`
+	Version    string `json:"version"`
+	SourceType string `json:"sourceType"`
+}
+
+// PackagePublishResultDTO represents a policy version published from a package
+type PackagePublishResultDTO struct {
+	PolicyName    string      `json:"policyName"`
+	Version       string      `json:"version"`
+	Status        string      `json:"status"`
+	DownloadURL   string      `json:"downloadUrl"`
+	AssetsBaseURL string      `json:"assetsBaseUrl,omitempty"`
+	Checksum      ChecksumDTO `json:"checksum"`
+	Size          int64       `json:"size"`
+	Docs          []string    `json:"docs"`
+	Assets        int         `json:"assets"`
+}
+
+// SyncBatchRequestDTO represents a batch of sync requests
+type SyncBatchRequestDTO []SyncRequestDTO
+
+// SyncBatchItemDTO represents the outcome of one batch item
+type SyncBatchItemDTO struct {
+	Index      int       `json:"index"`
+	PolicyName string    `json:"policyName"`
+	Version    string    `json:"version"`
+	Outcome    string    `json:"outcome"`
+	Error      *ErrorDTO `json:"error,omitempty"`
+}
+
+// SyncBatchResponseDTO represents the outcome of a batch sync
+type SyncBatchResponseDTO struct {
+	Atomic    bool               `json:"atomic"`
+	Created   int                `json:"created"`
+	Unchanged int                `json:"unchanged"`
+	Failed    int                `json:"failed"`
+	Items     []SyncBatchItemDTO `json:"items"`
+}
+
+// SyncJobDTO represents an asynchronous sync job
+type SyncJobDTO struct {
+	ID            int32            `json:"id"`
+	PolicyName    string           `json:"policyName"`
+	Version       string           `json:"version"`
+	Status        string           `json:"status"`
+	Attempts      int              `json:"attempts"`
+	MaxAttempts   int              `json:"maxAttempts"`
+	NextAttemptAt *time.Time       `json:"nextAttemptAt,omitempty"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	StartedAt     *time.Time       `json:"startedAt,omitempty"`
+	FinishedAt    *time.Time       `json:"finishedAt,omitempty"`
+	UpdatedAt     time.Time        `json:"updatedAt"`
+	DurationMs    *int64           `json:"durationMs,omitempty"`
+	LastError     *SyncJobErrorDTO `json:"lastError,omitempty"`
+	Result        *SyncResponseDTO `json:"result,omitempty"`
+}
+
+// SyncJobErrorDTO represents the error of the most recent failed sync job attempt
+type SyncJobErrorDTO struct {
+	Code      string         `json:"code"`
+	Message   string         `json:"message"`
+	Details   map[string]any `json:"details,omitempty"`
+	Retryable bool           `json:"retryable"`
+}
+
+// CrawlDTO represents the state of a catalog crawl
+type CrawlDTO struct {
+	Source     string            `json:"source"`
+	Trigger    string            `json:"trigger"`
+	Status     string            `json:"status"`
+	StartedAt  time.Time         `json:"startedAt"`
+	FinishedAt *time.Time        `json:"finishedAt,omitempty"`
+	DurationMs *int64            `json:"durationMs,omitempty"`
+	Discovered int               `json:"discovered"`
+	Missing    int               `json:"missing"`
+	Synced     int               `json:"synced"`
+	Failed     int               `json:"failed"`
+	Failures   []CrawlFailureDTO `json:"failures"`
+	Error      *ErrorDTO         `json:"error,omitempty"`
+}
+
+// CrawlFailureDTO represents a listed policy version a crawl could not sync
+type CrawlFailureDTO struct {
+	PolicyName string   `json:"policyName"`
+	Version    string   `json:"version"`
+	Error      ErrorDTO `json:"error"`
+}
+
+// ReconciliationDTO represents the state of a reconciliation run
+type ReconciliationDTO struct {
+	Trigger     string                `json:"trigger"`
+	Status      string                `json:"status"`
+	StartedAt   time.Time             `json:"startedAt"`
+	FinishedAt  *time.Time            `json:"finishedAt,omitempty"`
+	DurationMs  *int64                `json:"durationMs,omitempty"`
+	Checked     int                   `json:"checked"`
+	DocsUpdated int                   `json:"docsUpdated"`
+	Drifted     int                   `json:"drifted"`
+	Failed      int                   `json:"failed"`
+	Failures    []ReconcileFailureDTO `json:"failures"`
+	Error       *ErrorDTO             `json:"error,omitempty"`
+}
+
+// ReconcileFailureDTO represents a policy version whose sources could not all be fetched
+type ReconcileFailureDTO struct {
+	PolicyName string   `json:"policyName"`
+	Version    string   `json:"version"`
+	Error      ErrorDTO `json:"error"`
+}
+
+// DriftedVersionDTO represents a policy version whose upstream sources no longer match what is stored
+type DriftedVersionDTO struct {
+	PolicyName   string         `json:"policyName"`
+	Version      string         `json:"version"`
+	Drift        []DriftItemDTO `json:"drift"`
+	DetectedAt   time.Time      `json:"detectedAt"`
+	ReconciledAt time.Time      `json:"reconciledAt"`
+}
+
+// DriftItemDTO represents one drifted part of a version, with the SHA-256 of its stored and upstream content
+type DriftItemDTO struct {
+	Field    string `json:"field"`
+	Stored   string `json:"stored"`
+	Upstream string `json:"upstream,omitempty"`
+}
+
+// WebhookResultDTO represents the handling of a webhook delivery
+type WebhookResultDTO struct {
+	Event      string      `json:"event"`
+	DeliveryID string      `json:"deliveryId,omitempty"`
+	Repository string      `json:"repository,omitempty"`
+	Tag        string      `json:"tag,omitempty"`
+	PolicyName string      `json:"policyName,omitempty"`
+	Version    string      `json:"version,omitempty"`
+	Outcome    string      `json:"outcome"`
+	Reason     string      `json:"reason,omitempty"`
+	Job        *SyncJobDTO `json:"job,omitempty"`
+}
+
+// WebhookSubscriptionRequestDTO represents a request to register an outbound webhook
+type WebhookSubscriptionRequestDTO struct {
+	URL        string   `json:"url" binding:"required"`
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"eventTypes,omitempty"`
+	PolicyName *string  `json:"policyName,omitempty"`
+	Provider   *string  `json:"provider,omitempty"`
+}
+
+// WebhookSubscriptionDTO represents an outbound webhook subscription
+type WebhookSubscriptionDTO struct {
+	ID         int32     `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"` // Only returned on creation
+	EventTypes []string  `json:"eventTypes"`
+	PolicyName *string   `json:"policyName,omitempty"`
+	Provider   *string   `json:"provider,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// WebhookDeliveryDTO represents the delivery of one event to a subscription
+type WebhookDeliveryDTO struct {
+	ID             int64      `json:"id"`
+	EventID        int64      `json:"eventId"`
+	SubscriptionID int32      `json:"subscriptionId"`
+	EventType      string     `json:"eventType"`
+	PolicyName     string     `json:"policyName"`
+	Version        string     `json:"version"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	MaxAttempts    int        `json:"maxAttempts"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty"`
+	LastStatusCode *int       `json:"lastStatusCode,omitempty"`
+	LastError      *string    `json:"lastError,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty"`
+}
+
+// ChangeDTO represents one catalog mutation in the change feed
+type ChangeDTO struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	PolicyName string          `json:"policyName"`
+	Version    string          `json:"version"`
+	Data       json.RawMessage `json:"data"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+// ChangeFeedDTO represents a page of the change feed
+type ChangeFeedDTO struct {
+	Changes []ChangeDTO `json:"changes"`
+	Cursor  int64       `json:"cursor"`
+	HasMore bool        `json:"hasMore"`
+}
+
+// BundleExportRequestDTO represents a request to export catalog content as an offline bundle
+type BundleExportRequestDTO struct {
+	PolicyNames       []string `json:"policyNames,omitempty"`
+	Provider          string   `json:"provider,omitempty"`
+	Platform          string   `json:"platform,omitempty"`
+	VersionConstraint string   `json:"versionConstraint,omitempty"`
+	Artifacts         *bool    `json:"artifacts,omitempty"` // Defaults to true
+}
+
+// BundleImportReportDTO represents the outcome of importing an offline bundle
+type BundleImportReportDTO struct {
+	DryRun            bool                  `json:"dryRun"`
+	KeyID             string                `json:"keyId,omitempty"`
+	SignatureVerified bool                  `json:"signatureVerified"`
+	Added             []BundleImportItemDTO `json:"added"`
+	Skipped           []BundleImportItemDTO `json:"skipped"`
+	Rejected          []BundleImportItemDTO `json:"rejected"`
+}
+
+// BundleImportItemDTO represents the outcome for one bundled version
+type BundleImportItemDTO struct {
 	PolicyName string `json:"policyName"`
 	Version    string `json:"version"`
-	Status     string `json:"status"`
+	IsLatest   bool   `json:"isLatest,omitempty"`
+	Reason     string `json:"reason,omitempty"`
 }
 
 // HealthResponseDTO represents health check response
@@ -149,3 +446,38 @@ type PolicyDTO struct {
 	DownloadURL        string       `json:"downloadUrl,omitempty"`
 	Checksum           *ChecksumDTO `json:"checksum,omitempty"`
 }
+
+// UsageCountsDTO represents resolve and download counts
+type UsageCountsDTO struct {
+	Resolves  int64 `json:"resolves"`
+	Downloads int64 `json:"downloads"`
+	Total     int64 `json:"total"`
+}
+
+// DailyUsageDTO represents usage counts for a single day
+type DailyUsageDTO struct {
+	Date string `json:"date"`
+	UsageCountsDTO
+}
+
+// VersionUsageDTO represents usage counts for a single policy version
+type VersionUsageDTO struct {
+	Version string `json:"version"`
+	UsageCountsDTO
+}
+
+// PolicyUsageDTO represents usage counts for a single policy
+type PolicyUsageDTO struct {
+	PolicyName string `json:"policyName"`
+	UsageCountsDTO
+}
+
+// UsageSummaryDTO represents usage totals and trend over a time window
+type UsageSummaryDTO struct {
+	PolicyName string            `json:"policyName,omitempty"`
+	Since      string            `json:"since"`
+	Days       int               `json:"days"`
+	Totals     UsageCountsDTO    `json:"totals"`
+	Trend      []DailyUsageDTO   `json:"trend"`
+	Versions   []VersionUsageDTO `json:"versions,omitempty"`
+}
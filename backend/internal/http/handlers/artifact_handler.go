@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package handlers
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wso2/policyhub/internal/artifacts"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/logging"
+)
+
+// ArtifactHandler serves packages and images from the hub's artifact store
+type ArtifactHandler struct {
+	store  *artifacts.Store
+	logger *logging.Logger
+}
+
+// NewArtifactHandler creates a new artifact handler
+func NewArtifactHandler(store *artifacts.Store, logger *logging.Logger) *ArtifactHandler {
+	return &ArtifactHandler{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// GetArtifact handles GET /artifacts/{path}
+func (h *ArtifactHandler) GetArtifact(c *gin.Context) {
+	if !h.store.Enabled() {
+		_ = c.Error(errs.ArtifactStoreNotConfigured())
+		return
+	}
+
+	name := strings.TrimPrefix(c.Param("filepath"), "/")
+	f, info, err := h.store.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			_ = c.Error(errs.ArtifactNotFound(name))
+			return
+		}
+		_ = c.Error(errs.NewInternalError("failed to open artifact", map[string]any{"error": err.Error()}))
+		return
+	}
+	defer f.Close()
+
+	// Stored artifacts are never overwritten, so they can be cached indefinitely
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), f)
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/bundle"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/http/dto"
+	"github.com/wso2/policyhub/internal/http/middleware"
+	"github.com/wso2/policyhub/internal/logging"
+)
+
+// HeaderBundleKeyID carries the ID of the key an exported bundle is signed with
+const HeaderBundleKeyID = "X-PolicyHub-Bundle-Key-Id"
+
+// BundleHandler handles offline bundle HTTP requests
+type BundleHandler struct {
+	exporter *bundle.Exporter
+	importer *bundle.Importer
+	logger   *logging.Logger
+}
+
+// NewBundleHandler creates a new bundle handler
+func NewBundleHandler(exporter *bundle.Exporter, importer *bundle.Importer, logger *logging.Logger) *BundleHandler {
+	return &BundleHandler{
+		exporter: exporter,
+		importer: importer,
+		logger:   logger,
+	}
+}
+
+// ExportBundle handles POST /bundles/export
+func (h *BundleHandler) ExportBundle(c *gin.Context) {
+	// An empty body exports the whole catalog
+	var req dto.BundleExportRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		_ = c.Error(err)
+		return
+	}
+
+	sel := bundle.Selection{
+		PolicyNames:       req.PolicyNames,
+		Provider:          req.Provider,
+		Platform:          req.Platform,
+		VersionConstraint: req.VersionConstraint,
+		Artifacts:         req.Artifacts == nil || *req.Artifacts,
+	}
+
+	// Mirroring artifacts and streaming the archive may outlast the server write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	b, err := h.exporter.Prepare(c.Request.Context(), sel)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer b.Close()
+
+	filename := fmt.Sprintf("policyhub-bundle-%s.tar.gz", time.Now().UTC().Format("20060102T150405Z"))
+	c.Header("Content-Type", "application/gzip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header(HeaderBundleKeyID, b.Summary().KeyID)
+	c.Status(http.StatusOK)
+
+	// The status is already sent, so a failure can only cut the archive short
+	if err := b.Write(c.Writer); err != nil {
+		h.logger.Error("Failed to stream bundle", zap.Error(err))
+		c.Abort()
+	}
+}
+
+// ImportBundle handles POST /bundles/import
+func (h *BundleHandler) ImportBundle(c *gin.Context) {
+	maxSize := h.importer.MaxSize()
+	if c.Request.ContentLength > maxSize {
+		_ = c.Error(errs.InvalidBundle("Bundle exceeds the maximum import size", map[string]any{"maxBytes": maxSize}))
+		return
+	}
+
+	// Uploading and importing a large bundle may outlast the server read and write timeouts
+	rc := http.NewResponseController(c.Writer)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxSize)
+	report, err := h.importer.Import(c.Request.Context(), body, getBoolQuery(c, "dryRun", false))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccess(c, dto.BundleImportReportDTO{
+		DryRun:            report.DryRun,
+		KeyID:             report.KeyID,
+		SignatureVerified: report.SignatureVerified,
+		Added:             toBundleImportItemDTOs(report.Added),
+		Skipped:           toBundleImportItemDTOs(report.Skipped),
+		Rejected:          toBundleImportItemDTOs(report.Rejected),
+	})
+}
+
+func toBundleImportItemDTOs(items []bundle.ImportItem) []dto.BundleImportItemDTO {
+	result := make([]dto.BundleImportItemDTO, 0, len(items))
+	for _, item := range items {
+		result = append(result, dto.BundleImportItemDTO{
+			PolicyName: item.PolicyName,
+			Version:    item.Version,
+			IsLatest:   item.IsLatest,
+			Reason:     item.Reason,
+		})
+	}
+	return result
+}
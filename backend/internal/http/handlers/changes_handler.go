@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wso2/policyhub/internal/changes"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/http/dto"
+	"github.com/wso2/policyhub/internal/http/middleware"
+	"github.com/wso2/policyhub/internal/logging"
+)
+
+// ChangesHandler handles the catalog change feed
+type ChangesHandler struct {
+	changeService *changes.Service
+	logger        *logging.Logger
+}
+
+// NewChangesHandler creates a new change feed handler
+func NewChangesHandler(changeService *changes.Service, logger *logging.Logger) *ChangesHandler {
+	return &ChangesHandler{
+		changeService: changeService,
+		logger:        logger,
+	}
+}
+
+// ListChanges handles GET /changes, as JSON pages with optional long-polling or as a Server-Sent Events stream
+func (h *ChangesHandler) ListChanges(c *gin.Context) {
+	filters := changes.ChangeFilters{
+		PolicyName: c.Query("policyName"),
+		Types:      parseCommaSeparatedValues(c, "type", "types"),
+		Limit:      getIntQuery(c, "limit", changes.DefaultLimit),
+	}
+
+	since := c.Query("since")
+	stream := strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+	if lastEventID := c.GetHeader("Last-Event-ID"); stream && lastEventID != "" {
+		// Reconnecting event streams resume from the last event received
+		since = lastEventID
+	}
+	if since != "" {
+		cursor, err := strconv.ParseInt(since, 10, 64)
+		if err != nil || cursor < 0 {
+			_ = c.Error(errs.NewValidationError("invalid cursor", map[string]any{"since": since}))
+			return
+		}
+		filters.Since = cursor
+	}
+
+	if stream {
+		h.streamChanges(c, filters)
+		return
+	}
+
+	wait := time.Duration(getIntQuery(c, "wait", 0)) * time.Second
+	if wait > 0 {
+		// Long-polls may outlast the server write timeout
+		_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	}
+
+	page, err := h.changeService.ListChanges(c.Request.Context(), filters, wait)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	feed := dto.ChangeFeedDTO{
+		Changes: make([]dto.ChangeDTO, 0, len(page.Changes)),
+		Cursor:  page.Cursor,
+		HasMore: page.HasMore,
+	}
+	for _, change := range page.Changes {
+		feed.Changes = append(feed.Changes, toChangeDTO(change))
+	}
+
+	middleware.SendSuccess(c, feed)
+}
+
+// streamChanges writes changes as Server-Sent Events until the client disconnects or the server shuts down
+func (h *ChangesHandler) streamChanges(c *gin.Context, filters changes.ChangeFilters) {
+	ctx := c.Request.Context()
+
+	// Validate before committing to a stream so that bad requests get a normal error response
+	page, err := h.changeService.ListChanges(ctx, filters, 0)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Event streams outlive the server write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for {
+		if len(page.Changes) == 0 {
+			_, err = io.WriteString(c.Writer, ": keepalive\n\n")
+		}
+		for _, change := range page.Changes {
+			if err = writeEvent(c.Writer, strconv.FormatInt(change.ID, 10), change.Type, toChangeDTO(change)); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			return // Client went away
+		}
+		c.Writer.Flush()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.changeService.Done():
+			return
+		default:
+		}
+
+		filters.Since = page.Cursor
+		page, err = h.changeService.ListChanges(ctx, filters, h.changeService.Heartbeat())
+		if err != nil {
+			if ctx.Err() == nil {
+				h.reportStreamError(c, err)
+			}
+			return
+		}
+	}
+}
+
+// reportStreamError ends an event stream with an error event, since the status has already been sent
+func (h *ChangesHandler) reportStreamError(c *gin.Context, err error) {
+	var appErr *errs.AppError
+	if !errors.As(err, &appErr) {
+		appErr = errs.NewInternalError("An unexpected error occurred", map[string]any{"error": err.Error()})
+	}
+	if writeEvent(c.Writer, "", "error", toErrorDTO(appErr)) == nil {
+		c.Writer.Flush()
+	}
+}
+
+// writeEvent writes one Server-Sent Event with a JSON payload
+func writeEvent(w io.Writer, id, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
+
+// toChangeDTO converts a change to DTO
+func toChangeDTO(change *changes.Change) dto.ChangeDTO {
+	return dto.ChangeDTO{
+		ID:         change.ID,
+		Type:       change.Type,
+		PolicyName: change.PolicyName,
+		Version:    change.Version,
+		Data:       change.Data,
+		CreatedAt:  change.CreatedAt,
+	}
+}
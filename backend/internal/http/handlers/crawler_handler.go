@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wso2/policyhub/internal/crawler"
+	"github.com/wso2/policyhub/internal/http/dto"
+	"github.com/wso2/policyhub/internal/http/middleware"
+	"github.com/wso2/policyhub/internal/logging"
+)
+
+// CrawlerHandler handles catalog crawler HTTP requests
+type CrawlerHandler struct {
+	crawler *crawler.Crawler
+	logger  *logging.Logger
+}
+
+// NewCrawlerHandler creates a new crawler handler
+func NewCrawlerHandler(crawler *crawler.Crawler, logger *logging.Logger) *CrawlerHandler {
+	return &CrawlerHandler{
+		crawler: crawler,
+		logger:  logger,
+	}
+}
+
+// TriggerCrawl handles POST /crawls
+func (h *CrawlerHandler) TriggerCrawl(c *gin.Context) {
+	result, err := h.crawler.Trigger()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccessWithStatus(c, http.StatusAccepted, toCrawlDTO(result))
+}
+
+// GetLatestCrawl handles GET /crawls/latest
+func (h *CrawlerHandler) GetLatestCrawl(c *gin.Context) {
+	result, err := h.crawler.LastResult()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccess(c, toCrawlDTO(result))
+}
+
+func toCrawlDTO(result *crawler.CrawlResult) dto.CrawlDTO {
+	crawlDTO := dto.CrawlDTO{
+		Source:     result.Source,
+		Trigger:    string(result.Trigger),
+		Status:     string(result.Status),
+		StartedAt:  result.StartedAt,
+		FinishedAt: result.FinishedAt,
+		Discovered: result.Discovered,
+		Missing:    result.Missing,
+		Synced:     result.Synced,
+		Failed:     result.Failed,
+		Failures:   make([]dto.CrawlFailureDTO, 0, len(result.Failures)),
+	}
+
+	if result.FinishedAt != nil {
+		durationMs := result.FinishedAt.Sub(result.StartedAt).Milliseconds()
+		crawlDTO.DurationMs = &durationMs
+	}
+
+	for _, f := range result.Failures {
+		crawlDTO.Failures = append(crawlDTO.Failures, dto.CrawlFailureDTO{
+			PolicyName: f.PolicyName,
+			Version:    f.Version,
+			Error:      *toErrorDTO(f.Error),
+		})
+	}
+
+	if result.Error != nil {
+		crawlDTO.Error = toErrorDTO(result.Error)
+	}
+
+	return crawlDTO
+}
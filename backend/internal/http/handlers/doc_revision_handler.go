@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/http/dto"
+	"github.com/wso2/policyhub/internal/http/middleware"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/policy"
+)
+
+// HeaderActor names the caller on whose behalf a documentation page is changed
+const HeaderActor = "X-Actor"
+
+// maxActorLength is the longest actor a revision can record
+const maxActorLength = 200
+
+// DocRevisionHandler handles documentation revision HTTP requests
+type DocRevisionHandler struct {
+	service *policy.Service
+	logger  *logging.Logger
+}
+
+// NewDocRevisionHandler creates a new documentation revision handler
+func NewDocRevisionHandler(service *policy.Service, logger *logging.Logger) *DocRevisionHandler {
+	return &DocRevisionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListRevisions handles GET /policies/{name}/versions/{version}/docs/{page}/revisions
+func (h *DocRevisionHandler) ListRevisions(c *gin.Context) {
+	name := c.Param("name")
+	version := c.Param("version")
+	page := c.Param("page")
+
+	revisions, err := h.service.ListDocRevisions(c.Request.Context(), name, version, page)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	revisionDTOs := make([]dto.DocRevisionDTO, 0, len(revisions))
+	for _, r := range revisions {
+		revisionDTOs = append(revisionDTOs, toDocRevisionDTO(r))
+	}
+
+	middleware.SendSuccess(c, revisionDTOs)
+}
+
+// GetRevision handles GET /policies/{name}/versions/{version}/docs/{page}/revisions/{revision}
+func (h *DocRevisionHandler) GetRevision(c *gin.Context) {
+	revision, ok := revisionParam(c, c.Param("revision"))
+	if !ok {
+		return
+	}
+
+	r, err := h.service.GetDocRevision(c.Request.Context(), c.Param("name"), c.Param("version"), c.Param("page"), revision)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccess(c, dto.DocRevisionDetailDTO{
+		DocRevisionDTO: toDocRevisionDTO(r),
+		Format:         "markdown",
+		Content:        r.ContentMd,
+	})
+}
+
+// DiffRevisions handles GET /policies/{name}/versions/{version}/docs/{page}/diff
+func (h *DocRevisionHandler) DiffRevisions(c *gin.Context) {
+	from, ok := revisionParam(c, c.Query("from"))
+	if !ok {
+		return
+	}
+	to := 0
+	if c.Query("to") != "" {
+		if to, ok = revisionParam(c, c.Query("to")); !ok {
+			return
+		}
+	}
+
+	page := c.Param("page")
+	diff, err := h.service.DiffDocRevisions(c.Request.Context(), c.Param("name"), c.Param("version"), page, from, to)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccess(c, dto.DocDiffDTO{
+		Page:         diff.Page,
+		FromRevision: diff.FromRevision,
+		ToRevision:   diff.ToRevision,
+		Added:        diff.Added,
+		Removed:      diff.Removed,
+		Diff:         diff.Unified,
+	})
+}
+
+// RestoreRevision handles POST /policies/{name}/versions/{version}/docs/{page}/revisions/{revision}/restore
+func (h *DocRevisionHandler) RestoreRevision(c *gin.Context) {
+	revision, ok := revisionParam(c, c.Param("revision"))
+	if !ok {
+		return
+	}
+
+	actor := c.GetHeader(HeaderActor)
+	if len(actor) > maxActorLength {
+		_ = c.Error(errs.NewValidationError("actor is too long", map[string]any{"maxLength": maxActorLength}))
+		return
+	}
+
+	latest, err := h.service.RestoreDocRevision(c.Request.Context(), c.Param("name"), c.Param("version"), c.Param("page"), revision, actor)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccess(c, toDocRevisionDTO(latest))
+}
+
+// revisionParam parses a revision number, reporting a validation error when it is not a positive integer
+func revisionParam(c *gin.Context, value string) (int, bool) {
+	revision, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || revision < 1 {
+		_ = c.Error(errs.NewValidationError("invalid revision", map[string]any{"revision": value}))
+		return 0, false
+	}
+	return int(revision), true
+}
+
+func toDocRevisionDTO(r *policy.DocRevision) dto.DocRevisionDTO {
+	return dto.DocRevisionDTO{
+		Page:      r.Page,
+		Revision:  r.Revision,
+		Origin:    r.Origin,
+		Actor:     r.Actor,
+		Size:      r.Size,
+		CreatedAt: r.CreatedAt,
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wso2/policyhub/internal/feeds"
+	"github.com/wso2/policyhub/internal/logging"
+)
+
+// FeedHandler handles Atom release feeds
+type FeedHandler struct {
+	feedService *feeds.Service
+	logger      *logging.Logger
+}
+
+// NewFeedHandler creates a new feed handler
+func NewFeedHandler(feedService *feeds.Service, logger *logging.Logger) *FeedHandler {
+	return &FeedHandler{
+		feedService: feedService,
+		logger:      logger,
+	}
+}
+
+// CatalogFeed handles GET /feeds/releases
+func (h *FeedHandler) CatalogFeed(c *gin.Context) {
+	h.serveFeed(c, feeds.Scope{Kind: feeds.ScopeCatalog})
+}
+
+// PolicyFeed handles GET /feeds/policies/{name}
+func (h *FeedHandler) PolicyFeed(c *gin.Context) {
+	h.serveFeed(c, feeds.Scope{Kind: feeds.ScopePolicy, Value: c.Param("name")})
+}
+
+// ProviderFeed handles GET /feeds/providers/{provider}
+func (h *FeedHandler) ProviderFeed(c *gin.Context) {
+	h.serveFeed(c, feeds.Scope{Kind: feeds.ScopeProvider, Value: c.Param("provider")})
+}
+
+// CategoryFeed handles GET /feeds/categories/{category}
+func (h *FeedHandler) CategoryFeed(c *gin.Context) {
+	h.serveFeed(c, feeds.Scope{Kind: feeds.ScopeCategory, Value: c.Param("category")})
+}
+
+// serveFeed renders a feed with validators so that feed readers can poll it cheaply
+func (h *FeedHandler) serveFeed(c *gin.Context, scope feeds.Scope) {
+	feed, err := h.feedService.Atom(c.Request.Context(), scope)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	sum := sha256.Sum256(feed.Body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.feedService.MaxAge().Seconds())))
+	if !feed.Updated.IsZero() {
+		c.Header("Last-Modified", feed.Updated.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request, etag, feed.Updated) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, feeds.AtomContentType, feed.Body)
+}
+
+// notModified evaluates the request's conditional headers; If-None-Match takes precedence over If-Modified-Since
+func notModified(r *http.Request, etag string, updated time.Time) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" && !updated.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		return err == nil && !updated.Truncate(time.Second).After(since)
+	}
+	return false
+}
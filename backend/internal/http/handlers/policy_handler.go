@@ -11,6 +11,7 @@ package handlers
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -21,32 +22,28 @@ import (
 	"github.com/wso2/policyhub/internal/http/middleware"
 	"github.com/wso2/policyhub/internal/logging"
 	"github.com/wso2/policyhub/internal/policy"
+	"github.com/wso2/policyhub/internal/stats"
 )
 
 // PolicyHandler handles policy-related HTTP requests
 type PolicyHandler struct {
-	service *policy.Service
-	logger  *logging.Logger
+	service  *policy.Service
+	recorder *stats.Recorder
+	logger   *logging.Logger
 }
 
 // NewPolicyHandler creates a new policy handler
-func NewPolicyHandler(service *policy.Service, logger *logging.Logger) *PolicyHandler {
+func NewPolicyHandler(service *policy.Service, recorder *stats.Recorder, logger *logging.Logger) *PolicyHandler {
 	return &PolicyHandler{
-		service: service,
-		logger:  logger,
+		service:  service,
+		recorder: recorder,
+		logger:   logger,
 	}
 }
 
 // ListPolicies handles GET /policies
 func (h *PolicyHandler) ListPolicies(c *gin.Context) {
-	filters := policy.PolicyFilters{
-		Search:     c.Query("search"),
-		Categories: parseCommaSeparatedValues(c, "category", "categories"),
-		Providers:  parseCommaSeparatedValues(c, "provider", "providers"),
-		Platforms:  parseCommaSeparatedValues(c, "platform", "platforms"),
-		Page:       getIntQuery(c, "page", 1),
-		PageSize:   getIntQuery(c, "pageSize", 20),
-	}
+	filters := parsePolicyFilters(c)
 
 	policies, pagination, err := h.service.ListPolicies(c.Request.Context(), filters)
 	if err != nil {
@@ -60,14 +57,29 @@ func (h *PolicyHandler) ListPolicies(c *gin.Context) {
 		items = append(items, toPolicyDTO(p))
 	}
 
-	paginationDTO := dto.PaginationDTO{
-		Page:       pagination.Page,
-		PageSize:   pagination.PageSize,
-		TotalItems: pagination.TotalItems,
-		TotalPages: pagination.TotalPages,
+	if !getBoolQuery(c, "facets", false) {
+		middleware.SendSuccessWithPagination(c, items, toPaginationDTO(pagination))
+		return
 	}
 
-	middleware.SendSuccessWithPagination(c, items, paginationDTO)
+	facets, err := h.service.GetFacets(c.Request.Context(), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccessWithFacets(c, items, toPaginationDTO(pagination), toFacetsDTO(facets))
+}
+
+// GetFacets handles GET /policies/facets
+func (h *PolicyHandler) GetFacets(c *gin.Context) {
+	facets, err := h.service.GetFacets(c.Request.Context(), parsePolicyFilters(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccess(c, toFacetsDTO(facets))
 }
 
 // GetPolicySummary handles GET /policies/{name}
@@ -87,10 +99,14 @@ func (h *PolicyHandler) GetPolicySummary(c *gin.Context) {
 // ListPolicyVersions handles GET /policies/{name}/versions
 func (h *PolicyHandler) ListPolicyVersions(c *gin.Context) {
 	name := c.Param("name")
-	page := getIntQuery(c, "page", 1)
-	pageSize := getIntQuery(c, "pageSize", 20)
+	filters := policy.VersionFilters{
+		Page:     getIntQuery(c, "page", 1),
+		PageSize: getIntQuery(c, "pageSize", 20),
+		Cursor:   c.Query("cursor"),
+	}
+	filters.IncludeTotal = getBoolQuery(c, "includeTotal", filters.Cursor == "")
 
-	versions, pagination, err := h.service.ListPolicyVersions(c.Request.Context(), name, page, pageSize)
+	versions, pagination, err := h.service.ListPolicyVersions(c.Request.Context(), name, filters)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -102,14 +118,7 @@ func (h *PolicyHandler) ListPolicyVersions(c *gin.Context) {
 		items = append(items, toPolicyDTO(v))
 	}
 
-	paginationDTO := dto.PaginationDTO{
-		Page:       pagination.Page,
-		PageSize:   pagination.PageSize,
-		TotalItems: pagination.TotalItems,
-		TotalPages: pagination.TotalPages,
-	}
-
-	middleware.SendSuccessWithPagination(c, items, paginationDTO)
+	middleware.SendSuccessWithPagination(c, items, toPaginationDTO(pagination))
 }
 
 // GetLatestVersion handles GET /policies/{name}/versions/latest
@@ -158,18 +167,40 @@ func (h *PolicyHandler) GetPolicyDefinition(c *gin.Context) {
 	c.Data(200, "text/yaml", definition)
 }
 
+// DownloadPolicyVersion handles GET /policies/{name}/versions/{version}/download
+func (h *PolicyHandler) DownloadPolicyVersion(c *gin.Context) {
+	name := c.Param("name")
+	version := c.Param("version")
+
+	policyVersion, err := h.service.GetPolicyVersion(c.Request.Context(), name, version)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if policyVersion.DownloadURL == nil || *policyVersion.DownloadURL == "" {
+		_ = c.Error(errs.DownloadNotAvailable(name, version))
+		return
+	}
+
+	h.recorder.Record(policyVersion.PolicyName, policyVersion.Version, stats.EventDownload)
+	c.Redirect(http.StatusFound, *policyVersion.DownloadURL)
+}
+
 // GetAllDocs handles GET /policies/{name}/versions/{version}/docs
 func (h *PolicyHandler) GetAllDocs(c *gin.Context) {
 	name := c.Param("name")
 	version := c.Param("version")
 
-	docs, err := h.service.GetAllDocs(c.Request.Context(), name, version)
+	fallback := getBoolQuery(c, "fallback", false)
+
+	docs, err := h.service.GetAllDocs(c.Request.Context(), name, version, fallback)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	response := toDocsAllResponseDTO(docs)
+	response := toDocsAllResponseDTO(docs, fallback)
 	middleware.SendSuccess(c, response)
 }
 
@@ -179,17 +210,15 @@ func (h *PolicyHandler) GetSingleDoc(c *gin.Context) {
 	version := c.Param("version")
 	page := c.Param("page")
 
-	content, err := h.service.GetSingleDoc(c.Request.Context(), name, version, page)
+	fallback := getBoolQuery(c, "fallback", false)
+
+	doc, err := h.service.GetSingleDoc(c.Request.Context(), name, version, page, fallback)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	response := dto.DocsSingleResponseDTO{
-		Page:    page,
-		Format:  "markdown",
-		Content: content,
-	}
+	response := toDocsSingleResponseDTO(doc, fallback)
 
 	middleware.SendSuccess(c, response)
 }
@@ -205,6 +234,17 @@ func (h *PolicyHandler) GetCategories(c *gin.Context) {
 	middleware.SendSuccess(c, categories)
 }
 
+// GetTags handles GET /policies/tags
+func (h *PolicyHandler) GetTags(c *gin.Context) {
+	tags, err := h.service.GetDistinctTags(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccess(c, tags)
+}
+
 // GetProviders handles GET /policies/providers
 func (h *PolicyHandler) GetProviders(c *gin.Context) {
 	providers, err := h.service.GetDistinctProviders(c.Request.Context())
@@ -285,6 +325,8 @@ func (h *PolicyHandler) ResolvePolicies(c *gin.Context) {
 	// Convert to response DTO
 	response := make([]dto.ResolvePolicyVersion, 0, len(resolved))
 	for _, item := range resolved {
+		h.recorder.Record(item.Name, item.Version, stats.EventResolve)
+
 		var checksumDTO dto.ChecksumDTO
 		if item.Checksum != nil {
 			checksumDTO = dto.ChecksumDTO{
@@ -305,6 +347,25 @@ func (h *PolicyHandler) ResolvePolicies(c *gin.Context) {
 
 // Helper functions
 
+// parsePolicyFilters reads the search, filter and paging query parameters shared by listing endpoints
+func parsePolicyFilters(c *gin.Context) policy.PolicyFilters {
+	filters := policy.PolicyFilters{
+		Search:     c.Query("search"),
+		Categories: parseCommaSeparatedValues(c, "category", "categories"),
+		Providers:  parseCommaSeparatedValues(c, "provider", "providers"),
+		Platforms:  parseCommaSeparatedValues(c, "platform", "platforms"),
+		Tags:       parseCommaSeparatedValues(c, "tag", "tags"),
+		Page:       getIntQuery(c, "page", 1),
+		PageSize:   getIntQuery(c, "pageSize", 20),
+		Cursor:     c.Query("cursor"),
+		Sort:       c.Query("sort"),
+	}
+	filters.MatchAllTags = c.Query("tagMatch") == policy.TagMatchAll
+	// Counting is skipped by default when paging with a cursor
+	filters.IncludeTotal = getBoolQuery(c, "includeTotal", filters.Cursor == "")
+	return filters
+}
+
 func getIntQuery(c *gin.Context, key string, defaultValue int) int {
 	valueStr := c.Query(key)
 	if valueStr == "" {
@@ -317,6 +378,33 @@ func getIntQuery(c *gin.Context, key string, defaultValue int) int {
 	return value
 }
 
+func getBoolQuery(c *gin.Context, key string, defaultValue bool) bool {
+	valueStr := c.Query(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// toPaginationDTO converts pagination info to DTO, omitting totals that were not counted
+func toPaginationDTO(p *policy.PaginationInfo) dto.PaginationDTO {
+	paginationDTO := dto.PaginationDTO{
+		Page:       p.Page,
+		PageSize:   p.PageSize,
+		NextCursor: p.NextCursor,
+	}
+	if p.HasTotal {
+		totalItems, totalPages := p.TotalItems, p.TotalPages
+		paginationDTO.TotalItems = &totalItems
+		paginationDTO.TotalPages = &totalPages
+	}
+	return paginationDTO
+}
+
 // toPolicyDTO converts policy version to standardized DTO
 func toPolicyDTO(v *policy.PolicyVersion) dto.PolicyDTO {
 	desc := ""
@@ -383,25 +471,53 @@ func toPolicyDTO(v *policy.PolicyVersion) dto.PolicyDTO {
 	}
 }
 
-func toDocsAllResponseDTO(docs map[string]string) dto.DocsAllResponseDTO {
+// toFacetsDTO converts facet counts to DTO
+func toFacetsDTO(f *policy.PolicyFacets) *dto.FacetsDTO {
+	convert := func(counts []policy.FacetCount) []dto.FacetCountDTO {
+		items := make([]dto.FacetCountDTO, 0, len(counts))
+		for _, fc := range counts {
+			items = append(items, dto.FacetCountDTO{Value: fc.Value, Count: fc.Count})
+		}
+		return items
+	}
+
+	return &dto.FacetsDTO{
+		Categories: convert(f.Categories),
+		Providers:  convert(f.Providers),
+		Platforms:  convert(f.Platforms),
+		Tags:       convert(f.Tags),
+	}
+}
+
+func toDocsAllResponseDTO(docs map[string]*policy.DocContent, fallback bool) dto.DocsAllResponseDTO {
 	var response dto.DocsAllResponseDTO
 
 	// Define the order of pages
 	pages := []string{"overview", "configuration", "examples", "faq"}
 
 	for _, page := range pages {
-		if content, ok := docs[page]; ok {
-			response = append(response, dto.DocsSingleResponseDTO{
-				Page:    page,
-				Format:  "markdown",
-				Content: content,
-			})
+		if doc, ok := docs[page]; ok {
+			response = append(response, toDocsSingleResponseDTO(doc, fallback))
 		}
 	}
 
 	return response
 }
 
+// toDocsSingleResponseDTO converts a documentation page; the source version is only reported when
+// fallback was requested, so responses without it keep their original shape
+func toDocsSingleResponseDTO(doc *policy.DocContent, fallback bool) dto.DocsSingleResponseDTO {
+	response := dto.DocsSingleResponseDTO{
+		Page:    doc.Page,
+		Format:  "markdown",
+		Content: doc.ContentMd,
+	}
+	if fallback {
+		response.SourceVersion = doc.SourceVersion
+	}
+	return response
+}
+
 // parseCommaSeparatedValues parses comma-separated values from query parameters
 // Supports both singular and plural parameter names for backward compatibility
 func parseCommaSeparatedValues(c *gin.Context, singularParam, pluralParam string) []string {
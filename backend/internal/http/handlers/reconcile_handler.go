@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wso2/policyhub/internal/http/dto"
+	"github.com/wso2/policyhub/internal/http/middleware"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/policy"
+	"github.com/wso2/policyhub/internal/reconcile"
+)
+
+// ReconcileHandler handles reconciliation and drift HTTP requests
+type ReconcileHandler struct {
+	reconciler    *reconcile.Reconciler
+	policyService *policy.Service
+	logger        *logging.Logger
+}
+
+// NewReconcileHandler creates a new reconcile handler
+func NewReconcileHandler(reconciler *reconcile.Reconciler, policyService *policy.Service, logger *logging.Logger) *ReconcileHandler {
+	return &ReconcileHandler{
+		reconciler:    reconciler,
+		policyService: policyService,
+		logger:        logger,
+	}
+}
+
+// TriggerReconciliation handles POST /reconciliations
+func (h *ReconcileHandler) TriggerReconciliation(c *gin.Context) {
+	result, err := h.reconciler.Trigger()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccessWithStatus(c, http.StatusAccepted, toReconciliationDTO(result))
+}
+
+// GetLatestReconciliation handles GET /reconciliations/latest
+func (h *ReconcileHandler) GetLatestReconciliation(c *gin.Context) {
+	result, err := h.reconciler.LastResult()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccess(c, toReconciliationDTO(result))
+}
+
+// ListDrift handles GET /drift
+func (h *ReconcileHandler) ListDrift(c *gin.Context) {
+	versions, err := h.policyService.ListDriftedVersions(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	driftDTOs := make([]dto.DriftedVersionDTO, 0, len(versions))
+	for _, v := range versions {
+		items := make([]dto.DriftItemDTO, 0, len(v.Drift))
+		for _, item := range v.Drift {
+			items = append(items, dto.DriftItemDTO{
+				Field:    item.Field,
+				Stored:   item.Stored,
+				Upstream: item.Upstream,
+			})
+		}
+		driftDTOs = append(driftDTOs, dto.DriftedVersionDTO{
+			PolicyName:   v.PolicyName,
+			Version:      v.Version,
+			Drift:        items,
+			DetectedAt:   v.DetectedAt,
+			ReconciledAt: v.ReconciledAt,
+		})
+	}
+
+	middleware.SendSuccess(c, driftDTOs)
+}
+
+func toReconciliationDTO(result *reconcile.RunResult) dto.ReconciliationDTO {
+	runDTO := dto.ReconciliationDTO{
+		Trigger:     string(result.Trigger),
+		Status:      string(result.Status),
+		StartedAt:   result.StartedAt,
+		FinishedAt:  result.FinishedAt,
+		Checked:     result.Checked,
+		DocsUpdated: result.DocsUpdated,
+		Drifted:     result.Drifted,
+		Failed:      result.Failed,
+		Failures:    make([]dto.ReconcileFailureDTO, 0, len(result.Failures)),
+	}
+
+	if result.FinishedAt != nil {
+		durationMs := result.FinishedAt.Sub(result.StartedAt).Milliseconds()
+		runDTO.DurationMs = &durationMs
+	}
+
+	for _, f := range result.Failures {
+		runDTO.Failures = append(runDTO.Failures, dto.ReconcileFailureDTO{
+			PolicyName: f.PolicyName,
+			Version:    f.Version,
+			Error:      *toErrorDTO(f.Error),
+		})
+	}
+
+	if result.Error != nil {
+		runDTO.Error = toErrorDTO(result.Error)
+	}
+
+	return runDTO
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/wso2/policyhub/internal/http/dto"
+	"github.com/wso2/policyhub/internal/http/middleware"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/stats"
+)
+
+// StatsHandler handles usage statistics HTTP requests
+type StatsHandler struct {
+	service *stats.Service
+	logger  *logging.Logger
+}
+
+// NewStatsHandler creates a new stats handler
+func NewStatsHandler(service *stats.Service, logger *logging.Logger) *StatsHandler {
+	return &StatsHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetSummary handles GET /stats/summary
+func (h *StatsHandler) GetSummary(c *gin.Context) {
+	summary, err := h.service.GetSummary(c.Request.Context(), getIntQuery(c, "days", stats.DefaultWindowDays))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccess(c, toUsageSummaryDTO(summary))
+}
+
+// GetTopPolicies handles GET /stats/top
+func (h *StatsHandler) GetTopPolicies(c *gin.Context) {
+	metric := c.DefaultQuery("metric", stats.MetricTotal)
+	days := getIntQuery(c, "days", stats.DefaultWindowDays)
+	limit := getIntQuery(c, "limit", stats.DefaultTopLimit)
+
+	policies, err := h.service.GetTopPolicies(c.Request.Context(), metric, days, limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items := make([]dto.PolicyUsageDTO, 0, len(policies))
+	for _, p := range policies {
+		items = append(items, dto.PolicyUsageDTO{
+			PolicyName:     p.PolicyName,
+			UsageCountsDTO: toUsageCountsDTO(p.UsageCounts),
+		})
+	}
+
+	middleware.SendSuccess(c, items)
+}
+
+// GetPolicyStats handles GET /policies/{name}/stats
+func (h *StatsHandler) GetPolicyStats(c *gin.Context) {
+	name := c.Param("name")
+
+	summary, err := h.service.GetPolicyStats(c.Request.Context(), name, getIntQuery(c, "days", stats.DefaultWindowDays))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccess(c, toUsageSummaryDTO(summary))
+}
+
+// Helper functions
+
+func toUsageCountsDTO(counts stats.UsageCounts) dto.UsageCountsDTO {
+	return dto.UsageCountsDTO{
+		Resolves:  counts.Resolves,
+		Downloads: counts.Downloads,
+		Total:     counts.Total(),
+	}
+}
+
+// toUsageSummaryDTO converts a usage summary to DTO
+func toUsageSummaryDTO(summary *stats.UsageSummary) dto.UsageSummaryDTO {
+	trend := make([]dto.DailyUsageDTO, 0, len(summary.Trend))
+	for _, d := range summary.Trend {
+		trend = append(trend, dto.DailyUsageDTO{
+			Date:           d.Day.Format("2006-01-02"),
+			UsageCountsDTO: toUsageCountsDTO(d.UsageCounts),
+		})
+	}
+
+	var versions []dto.VersionUsageDTO
+	if summary.Versions != nil {
+		versions = make([]dto.VersionUsageDTO, 0, len(summary.Versions))
+		for _, v := range summary.Versions {
+			versions = append(versions, dto.VersionUsageDTO{
+				Version:        v.Version,
+				UsageCountsDTO: toUsageCountsDTO(v.UsageCounts),
+			})
+		}
+	}
+
+	return dto.UsageSummaryDTO{
+		PolicyName: summary.PolicyName,
+		Since:      summary.Since.Format("2006-01-02"),
+		Days:       summary.Days,
+		Totals:     toUsageCountsDTO(summary.Totals),
+		Trend:      trend,
+		Versions:   versions,
+	}
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/events"
+	"github.com/wso2/policyhub/internal/http/dto"
+	"github.com/wso2/policyhub/internal/http/middleware"
+	"github.com/wso2/policyhub/internal/logging"
+)
+
+// SubscriptionHandler handles outbound webhook subscriptions
+type SubscriptionHandler struct {
+	eventService *events.Service
+	logger       *logging.Logger
+}
+
+// NewSubscriptionHandler creates a new subscription handler
+func NewSubscriptionHandler(eventService *events.Service, logger *logging.Logger) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		eventService: eventService,
+		logger:       logger,
+	}
+}
+
+// CreateSubscription handles POST /internal/subscriptions
+func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
+	var req dto.WebhookSubscriptionRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	sub, err := h.eventService.CreateSubscription(c.Request.Context(), &events.Subscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		PolicyName: req.PolicyName,
+		Provider:   req.Provider,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// The secret is shown once so that the subscriber can verify signatures
+	subDTO := toSubscriptionDTO(sub)
+	subDTO.Secret = sub.Secret
+	middleware.SendSuccessWithStatus(c, http.StatusCreated, subDTO)
+}
+
+// ListSubscriptions handles GET /internal/subscriptions
+func (h *SubscriptionHandler) ListSubscriptions(c *gin.Context) {
+	subs, err := h.eventService.ListSubscriptions(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items := make([]dto.WebhookSubscriptionDTO, 0, len(subs))
+	for _, sub := range subs {
+		items = append(items, toSubscriptionDTO(sub))
+	}
+
+	middleware.SendSuccess(c, items)
+}
+
+// GetSubscription handles GET /internal/subscriptions/{id}
+func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
+	id, ok := subscriptionID(c)
+	if !ok {
+		return
+	}
+
+	sub, err := h.eventService.GetSubscription(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccess(c, toSubscriptionDTO(sub))
+}
+
+// DeleteSubscription handles DELETE /internal/subscriptions/{id}
+func (h *SubscriptionHandler) DeleteSubscription(c *gin.Context) {
+	id, ok := subscriptionID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.eventService.DeleteSubscription(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /internal/subscriptions/{id}/deliveries
+func (h *SubscriptionHandler) ListDeliveries(c *gin.Context) {
+	id, ok := subscriptionID(c)
+	if !ok {
+		return
+	}
+
+	filters := events.DeliveryFilters{
+		SubscriptionID: id,
+		Status:         c.Query("status"),
+		Page:           getIntQuery(c, "page", 1),
+		PageSize:       getIntQuery(c, "pageSize", 20),
+	}
+
+	deliveries, pagination, err := h.eventService.ListDeliveries(c.Request.Context(), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items := make([]dto.WebhookDeliveryDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		items = append(items, dto.WebhookDeliveryDTO{
+			ID:             delivery.ID,
+			EventID:        delivery.EventID,
+			SubscriptionID: delivery.SubscriptionID,
+			EventType:      delivery.EventType,
+			PolicyName:     delivery.PolicyName,
+			Version:        delivery.Version,
+			Status:         string(delivery.Status),
+			Attempts:       delivery.Attempts,
+			MaxAttempts:    delivery.MaxAttempts,
+			NextAttemptAt:  delivery.NextAttemptAt,
+			LastStatusCode: delivery.LastStatusCode,
+			LastError:      delivery.LastError,
+			CreatedAt:      delivery.CreatedAt,
+			UpdatedAt:      delivery.UpdatedAt,
+			DeliveredAt:    delivery.DeliveredAt,
+		})
+	}
+
+	middleware.SendSuccessWithPagination(c, items, toPaginationDTO(pagination))
+}
+
+// subscriptionID parses the subscription ID path parameter, reporting invalid values
+func subscriptionID(c *gin.Context) (int32, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil || id < 1 {
+		_ = c.Error(errs.NewValidationError("invalid subscription id", map[string]any{"id": c.Param("id")}))
+		return 0, false
+	}
+	return int32(id), true
+}
+
+// toSubscriptionDTO converts a subscription to DTO without its secret
+func toSubscriptionDTO(sub *events.Subscription) dto.WebhookSubscriptionDTO {
+	return dto.WebhookSubscriptionDTO{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: sub.EventTypes,
+		PolicyName: sub.PolicyName,
+		Provider:   sub.Provider,
+		CreatedAt:  sub.CreatedAt,
+		UpdatedAt:  sub.UpdatedAt,
+	}
+}
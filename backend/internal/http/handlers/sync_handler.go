@@ -10,27 +10,45 @@
 package handlers
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/wso2/policyhub/internal/errs"
 	"github.com/wso2/policyhub/internal/http/dto"
 	"github.com/wso2/policyhub/internal/http/middleware"
+	"github.com/wso2/policyhub/internal/jobs"
 	"github.com/wso2/policyhub/internal/logging"
 	"github.com/wso2/policyhub/internal/policy"
 	"github.com/wso2/policyhub/internal/sync"
 	"github.com/wso2/policyhub/internal/validation"
 )
 
+// Idempotency headers of the sync endpoint: a client-chosen key for the request, and the marker on
+// responses that replay an earlier request
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// multipartOverhead is the allowance for form fields and framing in a multipart package upload
+const multipartOverhead = 1 << 20
+
 // SyncHandler handles sync operations
 type SyncHandler struct {
 	syncService *sync.Service
+	jobService  *jobs.Service
 	logger      *logging.Logger
 }
 
 // NewSyncHandler creates a new sync handler
-func NewSyncHandler(syncService *sync.Service, logger *logging.Logger) *SyncHandler {
+func NewSyncHandler(syncService *sync.Service, jobService *jobs.Service, logger *logging.Logger) *SyncHandler {
 	return &SyncHandler{
 		syncService: syncService,
+		jobService:  jobService,
 		logger:      logger,
 	}
 }
@@ -79,6 +97,284 @@ func (h *SyncHandler) CreatePolicyVersion(c *gin.Context) {
 		return
 	}
 
+	syncReq := toSyncRequest(req)
+
+	// A dry run checks the sync in the request and writes nothing
+	if getBoolQuery(c, "dryRun", false) {
+		result, err := h.syncService.DryRun(c.Request.Context(), syncReq)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		middleware.SendSuccess(c, toDryRunDTO(result))
+		return
+	}
+
+	// Queue the sync; a worker fetches and persists the version
+	key := c.GetHeader(HeaderIdempotencyKey)
+	if key == "" {
+		job, err := h.jobService.Enqueue(c.Request.Context(), syncReq)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		middleware.SendSuccessWithStatus(c, http.StatusAccepted, toSyncJobDTO(job))
+		return
+	}
+
+	// A replayed request gets the job it was first queued as
+	job, replayed, err := h.jobService.EnqueueIdempotent(c.Request.Context(), syncReq, key)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if replayed {
+		c.Header(HeaderIdempotentReplayed, "true")
+		middleware.SendSuccess(c, toSyncJobDTO(job))
+		return
+	}
+	middleware.SendSuccessWithStatus(c, http.StatusAccepted, toSyncJobDTO(job))
+}
+
+// SyncBatch handles POST /policies/batch
+func (h *SyncHandler) SyncBatch(c *gin.Context) {
+	// Fetching and storing a large batch may outlast the server write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	var req dto.SyncBatchRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	syncReqs := make([]*sync.SyncRequest, 0, len(req))
+	for _, item := range req {
+		syncReqs = append(syncReqs, toSyncRequest(item))
+	}
+
+	result, err := h.syncService.SyncBatch(c.Request.Context(), syncReqs, getBoolQuery(c, "atomic", false))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items := make([]dto.SyncBatchItemDTO, 0, len(result.Items))
+	for _, item := range result.Items {
+		itemDTO := dto.SyncBatchItemDTO{
+			Index:      item.Index,
+			PolicyName: item.PolicyName,
+			Version:    item.Version,
+			Outcome:    string(item.Outcome),
+		}
+		if item.Error != nil {
+			itemDTO.Error = toErrorDTO(item.Error)
+		}
+		items = append(items, itemDTO)
+	}
+
+	middleware.SendSuccess(c, dto.SyncBatchResponseDTO{
+		Atomic:    result.Atomic,
+		Created:   result.Created,
+		Unchanged: result.Unchanged,
+		Failed:    result.Failed,
+		Items:     items,
+	})
+}
+
+// PublishPackage handles POST /packages. The package is either uploaded as the "package" field of a
+// multipart form, with optional policyName, version and sourceType fields, or downloaded from the URL
+// in a JSON body.
+func (h *SyncHandler) PublishPackage(c *gin.Context) {
+	maxSize := h.syncService.MaxPackageSize()
+
+	// Receiving, extracting and storing a large package may outlast the server read and write timeouts
+	rc := http.NewResponseController(c.Writer)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	var req sync.PackageRequest
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		// Leave room for the form fields and multipart framing around the package
+		if c.Request.ContentLength > maxSize+multipartOverhead {
+			_ = c.Error(errs.InvalidPackage("Package exceeds the maximum size", map[string]any{"maxBytes": maxSize}))
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+
+		fileHeader, err := c.FormFile("package")
+		if err != nil {
+			_ = c.Error(errs.NewValidationError("multipart field \"package\" is required", map[string]any{"error": err.Error()}))
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			_ = c.Error(errs.InvalidPackage("Failed to read package", map[string]any{"error": err.Error()}))
+			return
+		}
+		defer file.Close()
+		defer func() { _ = c.Request.MultipartForm.RemoveAll() }()
+
+		req = sync.PackageRequest{
+			PolicyName: c.PostForm("policyName"),
+			Version:    c.PostForm("version"),
+			SourceType: c.PostForm("sourceType"),
+			File:       file,
+		}
+	} else {
+		var body dto.PackagePublishRequestDTO
+		if err := c.ShouldBindJSON(&body); err != nil {
+			_ = c.Error(err)
+			return
+		}
+		req = sync.PackageRequest{
+			PolicyName: body.PolicyName,
+			Version:    body.Version,
+			SourceType: body.SourceType,
+			URL:        body.URL,
+		}
+	}
+
+	result, err := h.syncService.PublishPackage(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	status := http.StatusCreated
+	if result.Status == sync.StatusUnchanged {
+		status = http.StatusOK
+	}
+	middleware.SendSuccessWithStatus(c, status, dto.PackagePublishResultDTO{
+		PolicyName:    result.PolicyName,
+		Version:       result.Version,
+		Status:        result.Status,
+		DownloadURL:   result.DownloadURL,
+		AssetsBaseURL: result.AssetsBaseURL,
+		Checksum:      dto.ChecksumDTO{Algorithm: "sha256", Value: result.Checksum},
+		Size:          result.Size,
+		Docs:          result.Docs,
+		Assets:        result.Assets,
+	})
+}
+
+// GetSyncJob handles GET /internal/sync-jobs/{id}
+func (h *SyncHandler) GetSyncJob(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil || id < 1 {
+		_ = c.Error(errs.NewValidationError("invalid sync job id", map[string]any{"id": c.Param("id")}))
+		return
+	}
+
+	job, err := h.jobService.GetJob(c.Request.Context(), int32(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	middleware.SendSuccess(c, toSyncJobDTO(job))
+}
+
+// ListSyncJobs handles GET /internal/sync-jobs
+func (h *SyncHandler) ListSyncJobs(c *gin.Context) {
+	filters := jobs.JobFilters{
+		Status:     c.Query("status"),
+		PolicyName: c.Query("policyName"),
+		Page:       getIntQuery(c, "page", 1),
+		PageSize:   getIntQuery(c, "pageSize", 20),
+	}
+
+	syncJobs, pagination, err := h.jobService.ListJobs(c.Request.Context(), filters)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items := make([]dto.SyncJobDTO, 0, len(syncJobs))
+	for _, job := range syncJobs {
+		items = append(items, toSyncJobDTO(job))
+	}
+
+	middleware.SendSuccessWithPagination(c, items, toPaginationDTO(pagination))
+}
+
+// toSyncJobDTO converts a sync job to DTO
+func toSyncJobDTO(job *jobs.SyncJob) dto.SyncJobDTO {
+	jobDTO := dto.SyncJobDTO{
+		ID:          job.ID,
+		PolicyName:  job.PolicyName,
+		Version:     job.Version,
+		Status:      string(job.Status),
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		FinishedAt:  job.FinishedAt,
+		UpdatedAt:   job.UpdatedAt,
+	}
+
+	// The next attempt time is only meaningful while the job waits in the queue
+	if job.Status == jobs.StatusQueued {
+		jobDTO.NextAttemptAt = job.NextAttemptAt
+	}
+
+	// Duration of the last attempt
+	if job.StartedAt != nil && job.FinishedAt != nil {
+		durationMs := job.FinishedAt.Sub(*job.StartedAt).Milliseconds()
+		jobDTO.DurationMs = &durationMs
+	}
+
+	if job.LastError != nil {
+		jobDTO.LastError = &dto.SyncJobErrorDTO{
+			Code:      job.LastError.Code,
+			Message:   job.LastError.Message,
+			Details:   job.LastError.Details,
+			Retryable: job.LastError.Retryable,
+		}
+	}
+
+	if job.Result != nil {
+		jobDTO.Result = &dto.SyncResponseDTO{
+			PolicyName: job.Result.PolicyName,
+			Version:    job.Result.Version,
+			Status:     job.Result.Status,
+			Docs:       toDocResultDTOs(job.Result.Docs),
+		}
+	}
+
+	return jobDTO
+}
+
+// toDryRunDTO converts a dry run result to its DTO
+func toDryRunDTO(result *sync.DryRunResult) *dto.SyncDryRunDTO {
+	return &dto.SyncDryRunDTO{
+		PolicyName:       result.PolicyName,
+		Version:          result.Version,
+		Status:           result.Status,
+		DryRun:           result.DryRun,
+		IsLatest:         result.IsLatest,
+		CurrentLatest:    result.CurrentLatest,
+		DefinitionSHA256: result.DefinitionSHA256,
+		Docs:             toDocResultDTOs(result.Docs),
+	}
+}
+
+// toDocResultDTOs converts the doc report of a sync to DTOs
+func toDocResultDTOs(docs []sync.DocResult) []dto.DocResultDTO {
+	var docDTOs []dto.DocResultDTO
+	for _, doc := range docs {
+		docDTOs = append(docDTOs, dto.DocResultDTO{
+			Page:     doc.Page,
+			Status:   string(doc.Status),
+			URL:      doc.URL,
+			Required: doc.Required,
+			Error:    doc.Error,
+		})
+	}
+	return docDTOs
+}
+
+// toSyncRequest converts a sync request DTO, dropping unknown documentation types
+func toSyncRequest(req dto.SyncRequestDTO) *sync.SyncRequest {
 	// Validate documentation types
 	if req.Documentation != nil {
 		validDocTypes := policy.ValidDocTypes()
@@ -91,8 +387,7 @@ func (h *SyncHandler) CreatePolicyVersion(c *gin.Context) {
 		req.Documentation = filteredDocs
 	}
 
-	// Convert DTO to sync request
-	syncReq := &sync.SyncRequest{
+	return &sync.SyncRequest{
 		PolicyName:    req.PolicyName,
 		Version:       req.Version,
 		SourceType:    req.SourceType,
@@ -111,22 +406,8 @@ func (h *SyncHandler) CreatePolicyVersion(c *gin.Context) {
 		Documentation: req.Documentation,
 		AssetsBaseURL: req.AssetsBaseURL,
 		Checksum:      convertChecksumDTO(req.Checksum),
+		RequiredDocs:  req.RequiredDocs,
 	}
-
-	// Execute sync
-	result, err := h.syncService.SyncPolicy(c.Request.Context(), syncReq)
-	if err != nil {
-		_ = c.Error(err)
-		return
-	}
-
-	response := dto.SyncResponseDTO{
-		PolicyName: result.PolicyName,
-		Version:    result.Version,
-		Status:     result.Status,
-	}
-
-	middleware.SendSuccess(c, response)
 }
 
 // convertChecksumDTO converts *dto.ChecksumDTO to *policy.Checksum
@@ -139,3 +420,12 @@ func convertChecksumDTO(checksumDTO *dto.ChecksumDTO) *policy.Checksum {
 		Value:     checksumDTO.Value,
 	}
 }
+
+// toErrorDTO converts an application error reported inside a successful response
+func toErrorDTO(err *errs.AppError) *dto.ErrorDTO {
+	return &dto.ErrorDTO{
+		Code:    string(err.Code),
+		Message: err.Message,
+		Details: err.Details,
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/http/dto"
+	"github.com/wso2/policyhub/internal/http/middleware"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/webhook"
+)
+
+// maxWebhookPayloadSize bounds the size of an accepted webhook payload
+const maxWebhookPayloadSize = 5 << 20
+
+// WebhookHandler handles inbound repository webhooks
+type WebhookHandler struct {
+	receiver *webhook.Receiver
+	logger   *logging.Logger
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(receiver *webhook.Receiver, logger *logging.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		receiver: receiver,
+		logger:   logger,
+	}
+}
+
+// GitHub handles POST /webhooks/github
+func (h *WebhookHandler) GitHub(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookPayloadSize))
+	if err != nil {
+		_ = c.Error(errs.NewValidationError("failed to read webhook payload", map[string]any{"error": err.Error()}))
+		return
+	}
+
+	result, err := h.receiver.HandleGitHub(
+		c.Request.Context(),
+		c.GetHeader("X-GitHub-Event"),
+		c.GetHeader("X-GitHub-Delivery"),
+		c.GetHeader("X-Hub-Signature-256"),
+		body,
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	resultDTO := dto.WebhookResultDTO{
+		Event:      result.Event,
+		DeliveryID: result.DeliveryID,
+		Repository: result.Repository,
+		Tag:        result.Tag,
+		PolicyName: result.PolicyName,
+		Version:    result.Version,
+		Outcome:    string(result.Outcome),
+		Reason:     result.Reason,
+	}
+	if result.Job == nil {
+		middleware.SendSuccess(c, resultDTO)
+		return
+	}
+
+	jobDTO := toSyncJobDTO(result.Job)
+	resultDTO.Job = &jobDTO
+	middleware.SendSuccessWithStatus(c, http.StatusAccepted, resultDTO)
+}
@@ -79,6 +79,11 @@ func GetRequestID(c *gin.Context) string {
 
 // SendSuccess sends a successful response
 func SendSuccess(c *gin.Context, data interface{}) {
+	SendSuccessWithStatus(c, 200, data)
+}
+
+// SendSuccessWithStatus sends a successful response with the given status code (e.g. 202 Accepted)
+func SendSuccessWithStatus(c *gin.Context, status int, data interface{}) {
 	response := dto.BaseResponse{
 		Success: true,
 		Data:    data,
@@ -89,11 +94,16 @@ func SendSuccess(c *gin.Context, data interface{}) {
 			RequestID: GetRequestID(c),
 		},
 	}
-	c.JSON(200, response)
+	c.JSON(status, response)
 }
 
 // SendSuccessWithPagination sends a successful response with pagination
 func SendSuccessWithPagination(c *gin.Context, data interface{}, pagination dto.PaginationDTO) {
+	SendSuccessWithFacets(c, data, pagination, nil)
+}
+
+// SendSuccessWithFacets sends a successful paginated response with optional facet counts
+func SendSuccessWithFacets(c *gin.Context, data interface{}, pagination dto.PaginationDTO, facets *dto.FacetsDTO) {
 	response := dto.PaginatedResponse{
 		Success: true,
 		Data:    data,
@@ -103,6 +113,7 @@ func SendSuccessWithPagination(c *gin.Context, data interface{}, pagination dto.
 			Timestamp:  time.Now().UTC(),
 			RequestID:  GetRequestID(c),
 			Pagination: pagination,
+			Facets:     facets,
 		},
 	}
 	c.JSON(200, response)
@@ -16,6 +16,7 @@ import (
 
 	"github.com/wso2/policyhub/internal/errs"
 	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/policy"
 )
 
 // ValidationMiddleware provides common request validation
@@ -93,6 +94,23 @@ func (m *ValidationMiddleware) ValidatePagination() gin.HandlerFunc {
 	}
 }
 
+// ValidateTagMatch validates the tagMatch query parameter
+func (m *ValidationMiddleware) ValidateTagMatch() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tagMatch := c.Query("tagMatch")
+		if tagMatch != "" && tagMatch != policy.TagMatchAny && tagMatch != policy.TagMatchAll {
+			_ = c.Error(errs.NewValidationError("tagMatch must be either any or all", map[string]any{
+				"allowed_values": []string{policy.TagMatchAny, policy.TagMatchAll},
+				"provided":       tagMatch,
+			}))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // ValidateDocType validates doc type parameter
 func (m *ValidationMiddleware) ValidateDocType() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -12,12 +12,22 @@ package http
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/wso2/policyhub/internal/artifacts"
+	"github.com/wso2/policyhub/internal/bundle"
+	"github.com/wso2/policyhub/internal/changes"
 	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/crawler"
+	"github.com/wso2/policyhub/internal/events"
+	"github.com/wso2/policyhub/internal/feeds"
 	"github.com/wso2/policyhub/internal/http/handlers"
 	"github.com/wso2/policyhub/internal/http/middleware"
+	"github.com/wso2/policyhub/internal/jobs"
 	"github.com/wso2/policyhub/internal/logging"
 	"github.com/wso2/policyhub/internal/policy"
+	"github.com/wso2/policyhub/internal/reconcile"
+	"github.com/wso2/policyhub/internal/stats"
 	"github.com/wso2/policyhub/internal/sync"
+	"github.com/wso2/policyhub/internal/webhook"
 )
 
 // Router sets up all HTTP routes
@@ -25,6 +35,18 @@ func SetupRouter(
 	cfg *config.Config,
 	policyService *policy.Service,
 	syncService *sync.Service,
+	jobService *jobs.Service,
+	statsService *stats.Service,
+	recorder *stats.Recorder,
+	catalogCrawler *crawler.Crawler,
+	reconciler *reconcile.Reconciler,
+	webhookReceiver *webhook.Receiver,
+	eventService *events.Service,
+	changeService *changes.Service,
+	feedService *feeds.Service,
+	bundleExporter *bundle.Exporter,
+	bundleImporter *bundle.Importer,
+	artifactStore *artifacts.Store,
 	logger *logging.Logger,
 ) *gin.Engine {
 	// Set Gin mode
@@ -43,21 +65,33 @@ func SetupRouter(
 
 	// Handlers
 	healthHandler := handlers.NewHealthHandler()
-	policyHandler := handlers.NewPolicyHandler(policyService, logger)
-	syncHandler := handlers.NewSyncHandler(syncService, logger)
+	policyHandler := handlers.NewPolicyHandler(policyService, recorder, logger)
+	syncHandler := handlers.NewSyncHandler(syncService, jobService, logger)
+	statsHandler := handlers.NewStatsHandler(statsService, logger)
+	crawlerHandler := handlers.NewCrawlerHandler(catalogCrawler, logger)
+	reconcileHandler := handlers.NewReconcileHandler(reconciler, policyService, logger)
+	docRevisionHandler := handlers.NewDocRevisionHandler(policyService, logger)
+	webhookHandler := handlers.NewWebhookHandler(webhookReceiver, logger)
+	subscriptionHandler := handlers.NewSubscriptionHandler(eventService, logger)
+	changesHandler := handlers.NewChangesHandler(changeService, logger)
+	feedHandler := handlers.NewFeedHandler(feedService, logger)
+	bundleHandler := handlers.NewBundleHandler(bundleExporter, bundleImporter, logger)
+	artifactHandler := handlers.NewArtifactHandler(artifactStore, logger)
 
 	// API Version group
 	apiV1 := router.Group("/api/v1")
 
 	// Public routes under /api/v1
 	// Policy routes
-	apiV1.GET("/policies", validationMW.ValidatePagination(), policyHandler.ListPolicies)
+	apiV1.GET("/policies", validationMW.ValidatePagination(), validationMW.ValidateTagMatch(), policyHandler.ListPolicies)
 	apiV1.POST("/policies/resolve", policyHandler.ResolvePolicies)
 
 	// Metadata routes (must come before parameterized routes)
 	apiV1.GET("/policies/categories", policyHandler.GetCategories)
 	apiV1.GET("/policies/providers", policyHandler.GetProviders)
 	apiV1.GET("/policies/platforms", policyHandler.GetPlatforms)
+	apiV1.GET("/policies/tags", policyHandler.GetTags)
+	apiV1.GET("/policies/facets", validationMW.ValidateTagMatch(), policyHandler.GetFacets)
 
 	// Parameterized policy routes
 	apiV1.GET("/policies/:name", validationMW.ValidatePolicyName(), policyHandler.GetPolicySummary)
@@ -67,11 +101,50 @@ func SetupRouter(
 	apiV1.GET("/policies/:name/versions/:version/definition", validationMW.ValidatePolicyName(), validationMW.ValidateVersion(), policyHandler.GetPolicyDefinition)
 	apiV1.GET("/policies/:name/versions/:version/docs", validationMW.ValidatePolicyName(), validationMW.ValidateVersion(), policyHandler.GetAllDocs)
 	apiV1.GET("/policies/:name/versions/:version/docs/:page", validationMW.ValidatePolicyName(), validationMW.ValidateVersion(), validationMW.ValidateDocType(), policyHandler.GetSingleDoc)
+	apiV1.GET("/policies/:name/versions/:version/download", validationMW.ValidatePolicyName(), validationMW.ValidateVersion(), policyHandler.DownloadPolicyVersion)
+	apiV1.GET("/policies/:name/stats", validationMW.ValidatePolicyName(), statsHandler.GetPolicyStats)
+
+	// Usage statistics routes
+	apiV1.GET("/stats/summary", statsHandler.GetSummary)
+	apiV1.GET("/stats/top", statsHandler.GetTopPolicies)
+
+	// Change feed route
+	apiV1.GET("/changes", changesHandler.ListChanges)
+
+	// Atom release feed routes
+	apiV1.GET("/feeds/releases", feedHandler.CatalogFeed)
+	apiV1.GET("/feeds/policies/:name", validationMW.ValidatePolicyName(), feedHandler.PolicyFeed)
+	apiV1.GET("/feeds/providers/:provider", feedHandler.ProviderFeed)
+	apiV1.GET("/feeds/categories/:category", feedHandler.CategoryFeed)
+
+	// Stored package and image routes
+	apiV1.GET("/artifacts/*filepath", artifactHandler.GetArtifact)
 
 	// Internal routes under /api/v1/internal
 	internal := apiV1.Group("/internal")
 	internal.GET("/health", healthHandler.HealthCheck)
+	internal.POST("/policies/batch", syncHandler.SyncBatch)
 	internal.POST("/policies/:name/versions/:version", validationMW.ValidatePolicyName(), validationMW.ValidateVersion(), syncHandler.CreatePolicyVersion)
+	internal.POST("/packages", syncHandler.PublishPackage)
+	internal.GET("/policies/:name/versions/:version/docs/:page/revisions", validationMW.ValidatePolicyName(), validationMW.ValidateVersion(), validationMW.ValidateDocType(), docRevisionHandler.ListRevisions)
+	internal.GET("/policies/:name/versions/:version/docs/:page/revisions/:revision", validationMW.ValidatePolicyName(), validationMW.ValidateVersion(), validationMW.ValidateDocType(), docRevisionHandler.GetRevision)
+	internal.POST("/policies/:name/versions/:version/docs/:page/revisions/:revision/restore", validationMW.ValidatePolicyName(), validationMW.ValidateVersion(), validationMW.ValidateDocType(), docRevisionHandler.RestoreRevision)
+	internal.GET("/policies/:name/versions/:version/docs/:page/diff", validationMW.ValidatePolicyName(), validationMW.ValidateVersion(), validationMW.ValidateDocType(), docRevisionHandler.DiffRevisions)
+	internal.GET("/sync-jobs", validationMW.ValidatePagination(), syncHandler.ListSyncJobs)
+	internal.GET("/sync-jobs/:id", syncHandler.GetSyncJob)
+	internal.POST("/crawls", crawlerHandler.TriggerCrawl)
+	internal.GET("/crawls/latest", crawlerHandler.GetLatestCrawl)
+	internal.POST("/reconciliations", reconcileHandler.TriggerReconciliation)
+	internal.GET("/reconciliations/latest", reconcileHandler.GetLatestReconciliation)
+	internal.GET("/drift", reconcileHandler.ListDrift)
+	internal.POST("/webhooks/github", webhookHandler.GitHub)
+	internal.POST("/subscriptions", subscriptionHandler.CreateSubscription)
+	internal.GET("/subscriptions", subscriptionHandler.ListSubscriptions)
+	internal.GET("/subscriptions/:id", subscriptionHandler.GetSubscription)
+	internal.DELETE("/subscriptions/:id", subscriptionHandler.DeleteSubscription)
+	internal.GET("/subscriptions/:id/deliveries", validationMW.ValidatePagination(), subscriptionHandler.ListDeliveries)
+	internal.POST("/bundles/export", bundleHandler.ExportBundle)
+	internal.POST("/bundles/import", bundleHandler.ImportBundle)
 
 	return router
 }
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+package jobs
+
+import (
+	"time"
+
+	syncPkg "github.com/wso2/policyhub/internal/sync"
+)
+
+// Status represents the state of a sync job
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// ValidStatuses returns a map of valid job statuses
+func ValidStatuses() map[string]bool {
+	return map[string]bool{
+		string(StatusQueued):    true,
+		string(StatusRunning):   true,
+		string(StatusSucceeded): true,
+		string(StatusFailed):    true,
+	}
+}
+
+// SyncJob represents a persistent asynchronous sync job
+type SyncJob struct {
+	ID            int32
+	PolicyName    string
+	Version       string
+	Status        Status
+	Request       *syncPkg.SyncRequest
+	Attempts      int
+	MaxAttempts   int
+	NextAttemptAt *time.Time
+	LastError     *JobError
+	Result        *syncPkg.SyncResult
+	CreatedAt     time.Time
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+	UpdatedAt     time.Time
+}
+
+// JobError records the error of the most recent failed attempt
+type JobError struct {
+	Code      string         `json:"code"`
+	Message   string         `json:"message"`
+	Details   map[string]any `json:"details,omitempty"`
+	Retryable bool           `json:"retryable"`
+}
+
+// IdempotencyKey records the Idempotency-Key a sync request was queued with
+type IdempotencyKey struct {
+	Key         string
+	RequestHash string // SHA-256 of the request, to tell replays from reuse of a key
+	SyncJobID   int32
+}
+
+// JobFilters holds filtering and paging criteria for listing sync jobs
+type JobFilters struct {
+	Status     string
+	PolicyName string
+	Page       int
+	PageSize   int
+}
@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+package jobs
+
+import (
+	"context"
+	"time"
+
+	syncPkg "github.com/wso2/policyhub/internal/sync"
+)
+
+// Repository defines the interface for sync job data access
+type Repository interface {
+	CreateSyncJob(ctx context.Context, req *syncPkg.SyncRequest, maxAttempts int) (*SyncJob, error)
+	CreateSyncJobWithIdempotencyKey(ctx context.Context, req *syncPkg.SyncRequest, maxAttempts int, key *IdempotencyKey) (*SyncJob, error)
+	GetIdempotencyKey(ctx context.Context, key string) (*IdempotencyKey, error) // Returns nil when the key is unused
+	GetSyncJob(ctx context.Context, id int32) (*SyncJob, error)
+	ListSyncJobs(ctx context.Context, filters JobFilters) ([]*SyncJob, error)
+	CountSyncJobs(ctx context.Context, filters JobFilters) (int, error)
+
+	// Worker operations; ClaimSyncJob returns nil when no job is due. Completing, failing and retrying
+	// apply only while the given attempt still runs the job and report whether it did, so that an
+	// attempt whose job was requeued as stale cannot overwrite the state of a later attempt.
+	ClaimSyncJob(ctx context.Context) (*SyncJob, error)
+	CompleteSyncJob(ctx context.Context, id int32, attempt int, result *syncPkg.SyncResult) (bool, error)
+	FailSyncJob(ctx context.Context, id int32, attempt int, jobErr *JobError) (bool, error)
+	RetrySyncJob(ctx context.Context, id int32, attempt int, jobErr *JobError, nextAttemptAt time.Time) (bool, error)
+	// RequeueStaleSyncJobs requeues running jobs started before startedBefore that have attempts left
+	RequeueStaleSyncJobs(ctx context.Context, startedBefore time.Time) (int64, error)
+	// FailStaleSyncJobs fails running jobs started before startedBefore that have no attempts left
+	FailStaleSyncJobs(ctx context.Context, startedBefore time.Time, jobErr *JobError) (int64, error)
+}
@@ -0,0 +1,274 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/wso2/policyhub/internal/db"
+	"github.com/wso2/policyhub/internal/db/sqlc"
+	"github.com/wso2/policyhub/internal/errs"
+	syncPkg "github.com/wso2/policyhub/internal/sync"
+)
+
+// SQLCRepository implements Repository using sqlc-generated code
+type SQLCRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewSQLCRepository creates a new SQLC-based sync job repository
+func NewSQLCRepository(database *db.DB) Repository {
+	return &SQLCRepository{
+		queries: sqlc.New(database.Pool),
+	}
+}
+
+// Helper to convert pgtype.Timestamptz to *time.Time
+func pgtypeTimestamptzToPtr(ts pgtype.Timestamptz) *time.Time {
+	if ts.Valid {
+		return &ts.Time
+	}
+	return nil
+}
+
+// Mapper function to convert between sqlc and domain models
+
+func sqlcToSyncJob(sj sqlc.SyncJob) (*SyncJob, error) {
+	job := &SyncJob{
+		ID:            sj.ID,
+		PolicyName:    sj.PolicyName,
+		Version:       sj.Version,
+		Status:        Status(sj.Status),
+		Attempts:      int(sj.Attempts),
+		MaxAttempts:   int(sj.MaxAttempts),
+		NextAttemptAt: pgtypeTimestamptzToPtr(sj.NextAttemptAt),
+		CreatedAt:     sj.CreatedAt.Time,
+		StartedAt:     pgtypeTimestamptzToPtr(sj.StartedAt),
+		FinishedAt:    pgtypeTimestamptzToPtr(sj.FinishedAt),
+		UpdatedAt:     sj.UpdatedAt.Time,
+	}
+
+	job.Request = &syncPkg.SyncRequest{}
+	if err := json.Unmarshal(sj.Request, job.Request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sync request: %w", err)
+	}
+
+	if len(sj.LastError) > 0 {
+		job.LastError = &JobError{}
+		if err := json.Unmarshal(sj.LastError, job.LastError); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal last error: %w", err)
+		}
+	}
+
+	if len(sj.Result) > 0 {
+		job.Result = &syncPkg.SyncResult{}
+		if err := json.Unmarshal(sj.Result, job.Result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+		}
+	}
+
+	return job, nil
+}
+
+func (r *SQLCRepository) CreateSyncJob(ctx context.Context, req *syncPkg.SyncRequest, maxAttempts int) (*SyncJob, error) {
+	request, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sync request: %w", err)
+	}
+
+	sj, err := r.queries.CreateSyncJob(ctx, sqlc.CreateSyncJobParams{
+		PolicyName:  req.PolicyName,
+		Version:     req.Version,
+		Request:     request,
+		MaxAttempts: int32(maxAttempts),
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to create sync job", map[string]any{"error": err.Error()})
+	}
+
+	return sqlcToSyncJob(sj)
+}
+
+func (r *SQLCRepository) CreateSyncJobWithIdempotencyKey(ctx context.Context, req *syncPkg.SyncRequest, maxAttempts int, key *IdempotencyKey) (*SyncJob, error) {
+	request, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sync request: %w", err)
+	}
+
+	sj, err := r.queries.CreateSyncJobWithIdempotencyKey(ctx, sqlc.CreateSyncJobWithIdempotencyKeyParams{
+		PolicyName:     req.PolicyName,
+		Version:        req.Version,
+		Request:        request,
+		MaxAttempts:    int32(maxAttempts),
+		IdempotencyKey: key.Key,
+		RequestHash:    key.RequestHash,
+	})
+	if err != nil {
+		if errs.IsUniqueConstraintError(err) {
+			// Returned as is so that the service can look up the job the key was used for
+			return nil, err
+		}
+		return nil, errs.NewDatabaseError("failed to create sync job", map[string]any{"error": err.Error()})
+	}
+
+	return sqlcToSyncJob(sj)
+}
+
+func (r *SQLCRepository) GetIdempotencyKey(ctx context.Context, key string) (*IdempotencyKey, error) {
+	row, err := r.queries.GetSyncIdempotencyKey(ctx, key)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errs.NewDatabaseError("failed to get idempotency key", map[string]any{"error": err.Error()})
+	}
+
+	return &IdempotencyKey{
+		Key:         row.IdempotencyKey,
+		RequestHash: row.RequestHash,
+		SyncJobID:   row.SyncJobID,
+	}, nil
+}
+
+func (r *SQLCRepository) GetSyncJob(ctx context.Context, id int32) (*SyncJob, error) {
+	sj, err := r.queries.GetSyncJob(ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.SyncJobNotFound(id)
+		}
+		return nil, errs.NewDatabaseError("failed to get sync job", map[string]any{"error": err.Error()})
+	}
+
+	return sqlcToSyncJob(sj)
+}
+
+func (r *SQLCRepository) ListSyncJobs(ctx context.Context, filters JobFilters) ([]*SyncJob, error) {
+	rows, err := r.queries.ListSyncJobs(ctx, sqlc.ListSyncJobsParams{
+		Column1: filters.Status,
+		Column2: filters.PolicyName,
+		Limit:   int32(filters.PageSize),
+		Offset:  int32((filters.Page - 1) * filters.PageSize),
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list sync jobs", map[string]any{"error": err.Error()})
+	}
+
+	jobs := make([]*SyncJob, 0, len(rows))
+	for _, row := range rows {
+		job, err := sqlcToSyncJob(row)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+func (r *SQLCRepository) CountSyncJobs(ctx context.Context, filters JobFilters) (int, error) {
+	count, err := r.queries.CountSyncJobs(ctx, sqlc.CountSyncJobsParams{
+		Column1: filters.Status,
+		Column2: filters.PolicyName,
+	})
+	if err != nil {
+		return 0, errs.NewDatabaseError("failed to count sync jobs", map[string]any{"error": err.Error()})
+	}
+
+	return int(count), nil
+}
+
+func (r *SQLCRepository) ClaimSyncJob(ctx context.Context) (*SyncJob, error) {
+	sj, err := r.queries.ClaimSyncJob(ctx)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errs.NewDatabaseError("failed to claim sync job", map[string]any{"error": err.Error()})
+	}
+
+	return sqlcToSyncJob(sj)
+}
+
+func (r *SQLCRepository) CompleteSyncJob(ctx context.Context, id int32, attempt int, result *syncPkg.SyncResult) (bool, error) {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal sync result: %w", err)
+	}
+
+	count, err := r.queries.CompleteSyncJob(ctx, sqlc.CompleteSyncJobParams{ID: id, Result: raw, Attempts: int32(attempt)})
+	if err != nil {
+		return false, errs.NewDatabaseError("failed to complete sync job", map[string]any{"error": err.Error()})
+	}
+
+	return count > 0, nil
+}
+
+func (r *SQLCRepository) FailSyncJob(ctx context.Context, id int32, attempt int, jobErr *JobError) (bool, error) {
+	raw, err := json.Marshal(jobErr)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal job error: %w", err)
+	}
+
+	count, err := r.queries.FailSyncJob(ctx, sqlc.FailSyncJobParams{ID: id, LastError: raw, Attempts: int32(attempt)})
+	if err != nil {
+		return false, errs.NewDatabaseError("failed to mark sync job as failed", map[string]any{"error": err.Error()})
+	}
+
+	return count > 0, nil
+}
+
+func (r *SQLCRepository) RetrySyncJob(ctx context.Context, id int32, attempt int, jobErr *JobError, nextAttemptAt time.Time) (bool, error) {
+	raw, err := json.Marshal(jobErr)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal job error: %w", err)
+	}
+
+	count, err := r.queries.RetrySyncJob(ctx, sqlc.RetrySyncJobParams{
+		ID:            id,
+		LastError:     raw,
+		NextAttemptAt: pgtype.Timestamptz{Time: nextAttemptAt, Valid: true},
+		Attempts:      int32(attempt),
+	})
+	if err != nil {
+		return false, errs.NewDatabaseError("failed to reschedule sync job", map[string]any{"error": err.Error()})
+	}
+
+	return count > 0, nil
+}
+
+func (r *SQLCRepository) RequeueStaleSyncJobs(ctx context.Context, startedBefore time.Time) (int64, error) {
+	count, err := r.queries.RequeueStaleSyncJobs(ctx, pgtype.Timestamptz{Time: startedBefore, Valid: true})
+	if err != nil {
+		return 0, errs.NewDatabaseError("failed to requeue stale sync jobs", map[string]any{"error": err.Error()})
+	}
+
+	return count, nil
+}
+
+func (r *SQLCRepository) FailStaleSyncJobs(ctx context.Context, startedBefore time.Time, jobErr *JobError) (int64, error) {
+	raw, err := json.Marshal(jobErr)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal job error: %w", err)
+	}
+
+	count, err := r.queries.FailStaleSyncJobs(ctx, sqlc.FailStaleSyncJobsParams{
+		StartedAt: pgtype.Timestamptz{Time: startedBefore, Valid: true},
+		LastError: raw,
+	})
+	if err != nil {
+		return 0, errs.NewDatabaseError("failed to fail stale sync jobs", map[string]any{"error": err.Error()})
+	}
+
+	return count, nil
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+package jobs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/policy"
+	syncPkg "github.com/wso2/policyhub/internal/sync"
+)
+
+// maxIdempotencyKeyLength matches the sync_idempotency_key column
+const maxIdempotencyKeyLength = 255
+
+// Service handles sync job business logic
+type Service struct {
+	repo        Repository
+	pool        *WorkerPool
+	maxAttempts int
+	logger      *logging.Logger
+}
+
+// NewService creates a new sync job service
+func NewService(repo Repository, pool *WorkerPool, cfg *config.SyncJobsConfig, logger *logging.Logger) *Service {
+	return &Service{
+		repo:        repo,
+		pool:        pool,
+		maxAttempts: cfg.MaxAttempts,
+		logger:      logger,
+	}
+}
+
+// Enqueue validates a sync request and stores it as a queued job
+func (s *Service) Enqueue(ctx context.Context, req *syncPkg.SyncRequest) (*SyncJob, error) {
+	// Reject invalid requests up front instead of failing them in a worker
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	job, err := s.repo.CreateSyncJob(ctx, req, s.maxAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Sync job queued",
+		zap.Int32("job_id", job.ID),
+		zap.String("policy", job.PolicyName),
+		zap.String("version", job.Version))
+
+	s.pool.Notify()
+	return job, nil
+}
+
+// EnqueueIdempotent queues a sync request under an Idempotency-Key. Replaying a key with the same
+// request returns the job it was first queued as, with replayed set; using it for a different
+// request is rejected.
+func (s *Service) EnqueueIdempotent(ctx context.Context, req *syncPkg.SyncRequest, key string) (*SyncJob, bool, error) {
+	if err := validateIdempotencyKey(key); err != nil {
+		return nil, false, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, false, err
+	}
+
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return nil, false, errs.NewInternalError("failed to hash sync request", map[string]any{"error": err.Error()})
+	}
+	sum := sha256.Sum256(raw)
+	idempotencyKey := &IdempotencyKey{Key: key, RequestHash: hex.EncodeToString(sum[:])}
+
+	if job, err := s.replay(ctx, idempotencyKey); job != nil || err != nil {
+		return job, job != nil, err
+	}
+
+	job, err := s.repo.CreateSyncJobWithIdempotencyKey(ctx, req, s.maxAttempts, idempotencyKey)
+	if err != nil {
+		if !errs.IsUniqueConstraintError(err) {
+			return nil, false, err
+		}
+		// The key was taken by a concurrent request since the lookup
+		job, err := s.replay(ctx, idempotencyKey)
+		return job, job != nil, err
+	}
+
+	s.logger.Info("Sync job queued",
+		zap.Int32("job_id", job.ID),
+		zap.String("policy", job.PolicyName),
+		zap.String("version", job.Version),
+		zap.Bool("idempotency_key", true))
+
+	s.pool.Notify()
+	return job, false, nil
+}
+
+// replay returns the job an idempotency key was already used for, or nil when it is unused
+func (s *Service) replay(ctx context.Context, key *IdempotencyKey) (*SyncJob, error) {
+	stored, err := s.repo.GetIdempotencyKey(ctx, key.Key)
+	if err != nil || stored == nil {
+		return nil, err
+	}
+	if stored.RequestHash != key.RequestHash {
+		return nil, errs.IdempotencyKeyReused(key.Key)
+	}
+	return s.repo.GetSyncJob(ctx, stored.SyncJobID)
+}
+
+// validateIdempotencyKey accepts 1 to 255 printable ASCII characters
+func validateIdempotencyKey(key string) error {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return errs.NewValidationError("Idempotency-Key must be 1 to 255 characters", nil)
+	}
+	for _, r := range key {
+		if r < 0x21 || r > 0x7e {
+			return errs.NewValidationError("Idempotency-Key must only contain printable ASCII characters", nil)
+		}
+	}
+	return nil
+}
+
+// GetJob retrieves a sync job by ID
+func (s *Service) GetJob(ctx context.Context, id int32) (*SyncJob, error) {
+	return s.repo.GetSyncJob(ctx, id)
+}
+
+// ListJobs retrieves a page of sync jobs, newest first
+func (s *Service) ListJobs(ctx context.Context, filters JobFilters) ([]*SyncJob, *policy.PaginationInfo, error) {
+	if filters.Page < 1 {
+		filters.Page = 1
+	}
+	if filters.PageSize < policy.MinPageSize || filters.PageSize > policy.MaxPageSize {
+		filters.PageSize = policy.DefaultPageSize
+	}
+	if filters.Status != "" && !ValidStatuses()[filters.Status] {
+		return nil, nil, errs.NewValidationError("invalid status", map[string]any{
+			"allowed_values": []Status{StatusQueued, StatusRunning, StatusSucceeded, StatusFailed},
+			"provided":       filters.Status,
+		})
+	}
+
+	jobs, err := s.repo.ListSyncJobs(ctx, filters)
+	if err != nil {
+		return nil, nil, errs.SanitizeDatabaseError("listing sync jobs")
+	}
+
+	total, err := s.repo.CountSyncJobs(ctx, filters)
+	if err != nil {
+		return nil, nil, errs.SanitizeDatabaseError("counting sync jobs")
+	}
+
+	return jobs, &policy.PaginationInfo{
+		Page:       filters.Page,
+		PageSize:   filters.PageSize,
+		HasTotal:   true,
+		TotalItems: total,
+		TotalPages: policy.CalculateTotalPages(total, filters.PageSize),
+	}, nil
+}
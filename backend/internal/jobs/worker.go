@@ -0,0 +1,286 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+package jobs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/logging"
+	syncPkg "github.com/wso2/policyhub/internal/sync"
+)
+
+// staleJobGrace is how long past the job timeout a running job is left alone before it counts as
+// stale, so that an attempt that timed out still gets to record its outcome
+const staleJobGrace = time.Minute
+
+// WorkerPool processes queued sync jobs with a fixed number of workers
+type WorkerPool struct {
+	repo           Repository
+	syncService    *syncPkg.Service
+	logger         *logging.Logger
+	workers        int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	pollInterval   time.Duration
+	jobTimeout     time.Duration
+	wake           chan struct{}
+	stop           chan struct{}
+	wg             sync.WaitGroup
+}
+
+// NewWorkerPool creates a new worker pool; call Start to begin processing
+func NewWorkerPool(repo Repository, syncService *syncPkg.Service, cfg *config.SyncJobsConfig, logger *logging.Logger) *WorkerPool {
+	return &WorkerPool{
+		repo:           repo,
+		syncService:    syncService,
+		logger:         logger,
+		workers:        cfg.Workers,
+		initialBackoff: time.Duration(cfg.InitialBackoffSeconds) * time.Second,
+		maxBackoff:     time.Duration(cfg.MaxBackoffSeconds) * time.Second,
+		pollInterval:   time.Duration(cfg.PollIntervalSeconds) * time.Second,
+		jobTimeout:     time.Duration(cfg.TimeoutSeconds) * time.Second,
+		wake:           make(chan struct{}, 1),
+		stop:           make(chan struct{}),
+	}
+}
+
+// Start launches the workers and the stale job reaper
+func (p *WorkerPool) Start() {
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.work(i)
+	}
+
+	p.wg.Add(1)
+	go p.reapStaleJobs()
+
+	p.logger.Info("Sync worker pool started", zap.Int("workers", p.workers))
+}
+
+// Notify wakes an idle worker after a job was queued
+func (p *WorkerPool) Notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Stop signals the workers to exit and waits for running jobs to finish
+func (p *WorkerPool) Stop(ctx context.Context) {
+	close(p.stop)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.logger.Info("Sync worker pool stopped")
+	case <-ctx.Done():
+		// Interrupted jobs are requeued by the reaper once they exceed the job timeout and its grace period
+		p.logger.Warn("Sync worker pool did not stop before shutdown deadline")
+	}
+}
+
+func (p *WorkerPool) work(id int) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain all due jobs before waiting again
+		for p.processNext() {
+			select {
+			case <-p.stop:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-p.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNext claims and runs one due job; it reports whether a job was processed
+func (p *WorkerPool) processNext() bool {
+	job, err := p.repo.ClaimSyncJob(context.Background())
+	if err != nil {
+		p.logger.Error("Failed to claim sync job", zap.Error(err))
+		return false
+	}
+	if job == nil {
+		return false
+	}
+
+	p.run(job)
+	return true
+}
+
+func (p *WorkerPool) run(job *SyncJob) {
+	startTime := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.jobTimeout)
+	defer cancel()
+
+	result, err := p.syncService.SyncPolicy(ctx, job.Request)
+	if err == nil {
+		recorded, err := p.repo.CompleteSyncJob(context.Background(), job.ID, job.Attempts, result)
+		if err != nil {
+			p.logger.Error("Failed to record sync job result", zap.Int32("job_id", job.ID), zap.Error(err))
+			return
+		}
+		if !recorded {
+			p.logSuperseded(job)
+			return
+		}
+		p.logger.Info("Sync job succeeded",
+			zap.Int32("job_id", job.ID),
+			zap.String("policy", job.PolicyName),
+			zap.String("version", job.Version),
+			zap.Int("attempt", job.Attempts),
+			zap.Duration("duration", time.Since(startTime)))
+		return
+	}
+
+	jobErr := toJobError(err)
+	if jobErr.Retryable && job.Attempts < job.MaxAttempts {
+		nextAttemptAt := time.Now().Add(p.backoff(job.Attempts))
+		recorded, err := p.repo.RetrySyncJob(context.Background(), job.ID, job.Attempts, jobErr, nextAttemptAt)
+		if err != nil {
+			p.logger.Error("Failed to reschedule sync job", zap.Int32("job_id", job.ID), zap.Error(err))
+			return
+		}
+		if !recorded {
+			p.logSuperseded(job)
+			return
+		}
+		p.logger.Warn("Sync job attempt failed, retrying",
+			zap.Int32("job_id", job.ID),
+			zap.String("policy", job.PolicyName),
+			zap.String("version", job.Version),
+			zap.Int("attempt", job.Attempts),
+			zap.Time("next_attempt_at", nextAttemptAt),
+			zap.String("error", jobErr.Message))
+		return
+	}
+
+	recorded, err := p.repo.FailSyncJob(context.Background(), job.ID, job.Attempts, jobErr)
+	if err != nil {
+		p.logger.Error("Failed to mark sync job as failed", zap.Int32("job_id", job.ID), zap.Error(err))
+		return
+	}
+	if !recorded {
+		p.logSuperseded(job)
+		return
+	}
+	p.logger.Warn("Sync job failed",
+		zap.Int32("job_id", job.ID),
+		zap.String("policy", job.PolicyName),
+		zap.String("version", job.Version),
+		zap.Int("attempts", job.Attempts),
+		zap.String("code", jobErr.Code),
+		zap.String("error", jobErr.Message))
+}
+
+// logSuperseded reports an attempt that finished after its job was requeued as stale; its outcome is
+// discarded in favour of the later attempt
+func (p *WorkerPool) logSuperseded(job *SyncJob) {
+	p.logger.Warn("Sync job attempt finished after it was requeued as stale, discarding its outcome",
+		zap.Int32("job_id", job.ID),
+		zap.String("policy", job.PolicyName),
+		zap.String("version", job.Version),
+		zap.Int("attempt", job.Attempts))
+}
+
+// backoff returns the exponential delay before the next attempt
+func (p *WorkerPool) backoff(attempt int) time.Duration {
+	delay := p.initialBackoff
+	for i := 1; i < attempt && delay < p.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.maxBackoff {
+		delay = p.maxBackoff
+	}
+	return delay
+}
+
+// reapStaleJobs periodically requeues running jobs whose worker has disappeared. A job only counts as
+// stale once it has run longer than the attempt timeout plus a grace period; checking more often than
+// that bounds how long a stale job waits to be noticed.
+func (p *WorkerPool) reapStaleJobs() {
+	defer p.wg.Done()
+
+	staleAfter := p.jobTimeout + staleJobGrace
+	ticker := time.NewTicker(staleAfter / 2)
+	defer ticker.Stop()
+
+	for {
+		startedBefore := time.Now().Add(-staleAfter)
+
+		// Jobs whose last allowed attempt never finished fail instead of running forever
+		failed, err := p.repo.FailStaleSyncJobs(context.Background(), startedBefore, &JobError{
+			Code:    string(errs.CodeInternalServerError),
+			Message: "The worker running the last attempt stopped before it finished",
+		})
+		if err != nil {
+			p.logger.Error("Failed to fail stale sync jobs", zap.Error(err))
+		} else if failed > 0 {
+			p.logger.Warn("Failed stale sync jobs without attempts left", zap.Int64("count", failed))
+		}
+
+		count, err := p.repo.RequeueStaleSyncJobs(context.Background(), startedBefore)
+		if err != nil {
+			p.logger.Error("Failed to requeue stale sync jobs", zap.Error(err))
+		} else if count > 0 {
+			p.logger.Warn("Requeued stale sync jobs", zap.Int64("count", count))
+			p.Notify()
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// toJobError converts a sync error to its stored form and decides whether it is transient.
+// Fetch failures are retried unless the remote answered with a client error other than 408/429.
+func toJobError(err error) *JobError {
+	var appErr *errs.AppError
+	if !errors.As(err, &appErr) {
+		return &JobError{Code: string(errs.CodeInternalServerError), Message: err.Error()}
+	}
+
+	jobErr := &JobError{Code: string(appErr.Code), Message: appErr.Message, Details: appErr.Details}
+	if appErr.Code == errs.CodeSyncFetchFailed {
+		status, hasStatus := appErr.Details["status"].(int)
+		jobErr.Retryable = !hasStatus ||
+			status >= http.StatusInternalServerError ||
+			status == http.StatusRequestTimeout ||
+			status == http.StatusTooManyRequests
+	}
+	return jobErr
+}
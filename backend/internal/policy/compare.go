@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package policy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/wso2/policyhub/internal/errs"
+)
+
+// FieldDiff describes a field whose incoming value differs from the stored version
+type FieldDiff struct {
+	Field    string `json:"field"`
+	Stored   any    `json:"stored"`
+	Incoming any    `json:"incoming"`
+}
+
+// CompareWithStored compares a version about to be published with the stored version of the same
+// name. It reports whether that version is stored and which fields differ: the definition (by
+// SHA-256), the package checksum and the catalog metadata. Docs, source and download URL are not
+// compared.
+func (s *Service) CompareWithStored(ctx context.Context, version *PolicyVersion) (bool, []FieldDiff, error) {
+	stored, err := s.repo.GetPolicyVersion(ctx, version.PolicyName, version.Version)
+	if err != nil {
+		var appErr *errs.AppError
+		if errors.As(err, &appErr) && appErr.Code == errs.CodePolicyVersionNotFound {
+			return false, nil, nil
+		}
+		return false, nil, errs.SanitizeDatabaseError("comparing policy version")
+	}
+
+	incoming := *version
+	incoming.Tags = s.tags.NormalizeAll(version.Tags)
+	return true, diffVersions(stored, &incoming), nil
+}
+
+// diffVersions lists the compared fields that differ between two versions
+func diffVersions(stored, incoming *PolicyVersion) []FieldDiff {
+	var diffs []FieldDiff
+	add := func(field string, a, b any) {
+		diffs = append(diffs, FieldDiff{Field: field, Stored: a, Incoming: b})
+	}
+
+	if a, b := DefinitionHash(stored.DefinitionYAML), DefinitionHash(incoming.DefinitionYAML); a != b {
+		add("definitionSha256", a, b)
+	}
+	if !checksumsEqual(stored.Checksum, incoming.Checksum) {
+		add("checksum", stored.Checksum, incoming.Checksum)
+	}
+	if stored.DisplayName != incoming.DisplayName {
+		add("displayName", stored.DisplayName, incoming.DisplayName)
+	}
+	if stored.Provider != incoming.Provider {
+		add("provider", stored.Provider, incoming.Provider)
+	}
+	if a, b := derefString(stored.Description), derefString(incoming.Description); a != b {
+		add("description", a, b)
+	}
+	if !stringsEqual(stored.Categories, incoming.Categories) {
+		add("categories", stored.Categories, incoming.Categories)
+	}
+	if !stringsEqual(stored.Tags, incoming.Tags) {
+		add("tags", stored.Tags, incoming.Tags)
+	}
+	if !stringsEqual(stored.SupportedPlatforms, incoming.SupportedPlatforms) {
+		add("supportedPlatforms", stored.SupportedPlatforms, incoming.SupportedPlatforms)
+	}
+	if a, b := derefString(stored.IconPath), derefString(incoming.IconPath); a != b {
+		add("logoUrl", a, b)
+	}
+	if a, b := derefString(stored.BannerPath), derefString(incoming.BannerPath); a != b {
+		add("bannerUrl", a, b)
+	}
+	return diffs
+}
+
+// DefinitionHash returns the hex SHA-256 of a policy definition
+func DefinitionHash(definition string) string {
+	sum := sha256.Sum256([]byte(definition))
+	return hex.EncodeToString(sum[:])
+}
+
+// checksumsEqual compares checksums ignoring case, and hyphens in the algorithm name (SHA-256 and
+// sha256); SHA-256 digests are compared without their optional "sha256:" prefix
+func checksumsEqual(a, b *Checksum) bool {
+	if a == nil || a.Value == "" || b == nil || b.Value == "" {
+		return (a == nil || a.Value == "") && (b == nil || b.Value == "")
+	}
+	if digest, ok := a.SHA256(); ok {
+		other, ok := b.SHA256()
+		return ok && digest == other
+	}
+	return checksumAlgorithm(a) == checksumAlgorithm(b) && strings.EqualFold(a.Value, b.Value)
+}
+
+// stringsEqual compares string lists, treating nil and empty as equal
+func stringsEqual(a, b []string) bool {
+	return len(a) == 0 && len(b) == 0 || slices.Equal(a, b)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
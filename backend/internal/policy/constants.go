@@ -59,6 +59,71 @@ const (
 	VersionResolutionMajor = "major"
 )
 
+// Tag match modes for tag filtering
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
+// Listing sort orders
+const (
+	SortLatest  = "latest"
+	SortPopular = "popular"
+	SortName    = "name" // The only order that supports cursor pagination, as it does not change when versions are synced
+)
+
+// PopularityWindowDays is the number of recent days of usage that ranks policies for SortPopular
+const PopularityWindowDays = 30
+
+// Catalog event types recorded in the event outbox
+const (
+	EventVersionPublished  = "version.published"
+	EventVersionDeprecated = "version.deprecated" // Reserved for version lifecycle operations
+	EventVersionYanked     = "version.yanked"     // Reserved for version lifecycle operations
+	EventDocsUpdated       = "docs.updated"
+)
+
+// ValidEventTypes returns a map of valid catalog event types
+func ValidEventTypes() map[string]bool {
+	return map[string]bool{
+		EventVersionPublished:  true,
+		EventVersionDeprecated: true,
+		EventVersionYanked:     true,
+		EventDocsUpdated:       true,
+	}
+}
+
+// Change types recorded in the change log
+const (
+	ChangeVersionCreated  = "version.created"
+	ChangeMetadataUpdated = "metadata.updated" // Reserved; version metadata is immutable once published
+	ChangeDocsChanged     = "docs.changed"
+	ChangeStateChanged    = "state.changed"
+)
+
+// ValidChangeTypes returns a map of valid change log types
+func ValidChangeTypes() map[string]bool {
+	return map[string]bool{
+		ChangeVersionCreated:  true,
+		ChangeMetadataUpdated: true,
+		ChangeDocsChanged:     true,
+		ChangeStateChanged:    true,
+	}
+}
+
+// Origins of doc page revisions
+const (
+	DocOriginPublish   = "publish"   // Stored with a new version
+	DocOriginReconcile = "reconcile" // Refreshed from its source by the reconciler
+	DocOriginRestore   = "restore"   // An earlier revision restored through the API
+)
+
+// Parts of a synced version that can drift from their upstream source
+const (
+	DriftDefinition = "definition"
+	DriftArtifact   = "artifact"
+)
+
 // ValidDocTypes returns a map of valid documentation types
 func ValidDocTypes() map[string]bool {
 	return map[string]bool{
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package policy
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/wso2/policyhub/internal/errs"
+)
+
+// PageCursor identifies the last row of a page in keyset order (created_at DESC, id DESC)
+type PageCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int32     `json:"id"`
+}
+
+// NameCursor identifies the last policy of a page in policy name order
+type NameCursor struct {
+	Name string `json:"n"`
+}
+
+// EncodeCursor returns the opaque cursor string for the given row
+func EncodeCursor(v *PolicyVersion) string {
+	raw, _ := json.Marshal(PageCursor{CreatedAt: v.CreatedAt, ID: v.ID})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses an opaque cursor string produced by EncodeCursor
+func DecodeCursor(cursor string) (*PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errs.NewValidationError("invalid cursor", map[string]any{"cursor": cursor})
+	}
+
+	var c PageCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.CreatedAt.IsZero() || c.ID <= 0 {
+		return nil, errs.NewValidationError("invalid cursor", map[string]any{"cursor": cursor})
+	}
+
+	return &c, nil
+}
+
+// EncodeNameCursor returns the opaque cursor string for the given policy in name order
+func EncodeNameCursor(v *PolicyVersion) string {
+	raw, _ := json.Marshal(NameCursor{Name: v.PolicyName})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeNameCursor parses an opaque cursor string produced by EncodeNameCursor
+func DecodeNameCursor(cursor string) (*NameCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errs.NewValidationError("invalid cursor", map[string]any{"cursor": cursor})
+	}
+
+	var c NameCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.Name == "" {
+		return nil, errs.NewValidationError("invalid cursor", map[string]any{"cursor": cursor})
+	}
+
+	return &c, nil
+}
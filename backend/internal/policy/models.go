@@ -12,6 +12,8 @@ package policy
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -21,6 +23,20 @@ type Checksum struct {
 	Value     string `json:"value"`
 }
 
+// SHA256 returns the lower-case hex digest of a SHA-256 checksum, accepting "sha256" and "SHA-256"
+// as the algorithm and a "sha256:" prefix on the value; ok is false for other checksums
+func (c *Checksum) SHA256() (digest string, ok bool) {
+	if c == nil || checksumAlgorithm(c) != "sha256" {
+		return "", false
+	}
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(c.Value)), "sha256:"), true
+}
+
+// checksumAlgorithm returns the algorithm name of a checksum in lower case without hyphens
+func checksumAlgorithm(c *Checksum) string {
+	return strings.ReplaceAll(strings.ToLower(c.Algorithm), "-", "")
+}
+
 // Scan implements the sql.Scanner interface for database retrieval
 func (c *Checksum) Scan(value interface{}) error {
 	if value == nil {
@@ -70,6 +86,122 @@ type PolicyDoc struct {
 	UpdatedAt       time.Time
 }
 
+// DocContent is the content of a documentation page with the version it is stored for, which is an
+// earlier release than the requested version when the page falls back to it
+type DocContent struct {
+	Page          string
+	ContentMd     string
+	SourceVersion string
+}
+
+// RevisionInfo describes where a change to a documentation page came from
+type RevisionInfo struct {
+	Origin string
+	Actor  string // Empty when the change was not made on behalf of a caller
+}
+
+// DocRevision is one stored content of a documentation page. Listings leave ContentMd empty.
+type DocRevision struct {
+	ID              int64
+	PolicyVersionID int32
+	Page            string
+	Revision        int
+	ContentMd       string
+	Origin          string
+	Actor           string
+	Size            int // Content length in bytes
+	CreatedAt       time.Time
+}
+
+// DocDiff is a line diff between two revisions of a documentation page
+type DocDiff struct {
+	Page         string
+	FromRevision int
+	ToRevision   int
+	Added        int
+	Removed      int
+	Unified      string // Unified diff, empty when the revisions have the same content
+}
+
+// VersionWithDocs groups a new policy version with its documentation pages for a transactional write;
+// the docs' PolicyVersionID is assigned when the version is inserted
+type VersionWithDocs struct {
+	Version *PolicyVersion
+	Docs    []*PolicyDoc
+	Source  *VersionSource // Recorded for reconciliation when set
+}
+
+// VersionWriteError reports which item of a multi-version write could not be stored
+type VersionWriteError struct {
+	Index      int
+	PolicyName string
+	Version    string
+	Err        error
+}
+
+func (e *VersionWriteError) Error() string {
+	return fmt.Sprintf("item %d (%s %s): %v", e.Index, e.PolicyName, e.Version, e.Err)
+}
+
+func (e *VersionWriteError) Unwrap() error {
+	return e.Err
+}
+
+// VersionSource records where the content of a synced version was fetched from, so that the
+// version can be reconciled with its sources later
+type VersionSource struct {
+	PolicyVersionID int32
+	SourceType      string
+	DefinitionURL   string            // Empty when the definition was given inline
+	DocURLs         map[string]string // Page name to URL
+	AssetsBaseURL   string
+	ReconciledAt    *time.Time
+	Drift           []DriftItem // Upstream differences found by the last reconciliation
+	DriftDetectedAt *time.Time
+	LastError       string // Set when the last reconciliation could not fetch every source
+}
+
+// DriftItem is a stored part of a version that no longer matches its upstream source
+type DriftItem struct {
+	Field    string `json:"field"`
+	Stored   string `json:"stored"`             // SHA-256 of the stored content
+	Upstream string `json:"upstream,omitempty"` // SHA-256 of the upstream content; empty when it is gone
+}
+
+// DriftedVersion is a version whose upstream sources no longer match what is stored
+type DriftedVersion struct {
+	PolicyName   string
+	Version      string
+	Drift        []DriftItem
+	DetectedAt   time.Time
+	ReconciledAt time.Time
+}
+
+// VersionEvent is the payload of catalog events about a policy version
+type VersionEvent struct {
+	PolicyName  string   `json:"policyName"`
+	Version     string   `json:"version"`
+	DisplayName string   `json:"displayName"`
+	Provider    string   `json:"provider"`
+	IsLatest    bool     `json:"isLatest"`
+	Categories  []string `json:"categories"`
+	Tags        []string `json:"tags"`
+	DownloadURL *string  `json:"downloadUrl,omitempty"`
+}
+
+// DocsEvent is the payload of catalog events about a documentation page
+type DocsEvent struct {
+	PolicyName string `json:"policyName"`
+	Version    string `json:"version"`
+	Provider   string `json:"provider"`
+	Page       string `json:"page"`
+}
+
+// VersionState is the payload of state.changed changes
+type VersionState struct {
+	IsLatest bool `json:"isLatest"`
+}
+
 // StringArray is a custom type for JSONB string arrays
 type StringArray []string
 
@@ -103,8 +235,33 @@ type PolicyFilters struct {
 	Categories []string
 	Providers  []string
 	Platforms  []string
-	Page       int
-	PageSize   int
+	Tags       []string
+	// MatchAllTags requires every tag to be present instead of any of them
+	MatchAllTags bool
+	Page         int
+	PageSize     int
+
+	// Cursor switches to keyset pagination; Page is ignored when set
+	Cursor string
+	// IncludeTotal controls whether the total item count is computed
+	IncludeTotal bool
+	// Sort selects the listing order (SortLatest, SortPopular or SortName)
+	Sort string
+}
+
+// VersionFilters holds paging criteria for listing the versions of a policy
+type VersionFilters struct {
+	Page         int
+	PageSize     int
+	Cursor       string
+	IncludeTotal bool
+}
+
+// VersionSelection selects versions across the catalog; empty criteria match every version
+type VersionSelection struct {
+	PolicyNames []string
+	Provider    string
+	Platform    string
 }
 
 // PaginationInfo holds pagination metadata
@@ -113,6 +270,11 @@ type PaginationInfo struct {
 	PageSize   int
 	TotalItems int
 	TotalPages int
+
+	// HasTotal reports whether TotalItems and TotalPages were computed
+	HasTotal bool
+	// NextCursor is the opaque cursor for the next page, empty on the last page
+	NextCursor string
 }
 
 // CalculateTotalPages calculates total pages from total items and page size
@@ -127,6 +289,20 @@ func CalculateTotalPages(totalItems, pageSize int) int {
 	return pages
 }
 
+// FacetCount is the number of matching policies carrying a facet value
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// PolicyFacets holds facet counts for each filterable dimension
+type PolicyFacets struct {
+	Categories []FacetCount
+	Providers  []FacetCount
+	Platforms  []FacetCount
+	Tags       []FacetCount
+}
+
 // PolicyResolveRequest represents a policy resolution request
 type PolicyResolveRequest struct {
 	Name              string
@@ -11,23 +11,39 @@ package policy
 
 import (
 	"context"
+	"time"
 )
 
 // Repository defines the interface for policy data access
 type Repository interface {
 	ListPolicies(ctx context.Context, filters PolicyFilters) ([]*PolicyVersion, error)
+	// ListPoliciesByName lists up to limit policies in name order, after the cursor or, without one, from the page offset
+	ListPoliciesByName(ctx context.Context, filters PolicyFilters, after *NameCursor, limit int) ([]*PolicyVersion, error)
+	ListPoliciesByPopularity(ctx context.Context, filters PolicyFilters, since time.Time) ([]*PolicyVersion, error)
 	CountPolicies(ctx context.Context, filters PolicyFilters) (int, error)
 
 	// Metadata operations
 	GetDistinctCategories(ctx context.Context) ([]string, error)
 	GetDistinctProviders(ctx context.Context) ([]string, error)
 	GetDistinctPlatforms(ctx context.Context) ([]string, error)
+	GetDistinctTags(ctx context.Context) ([]string, error)
+
+	// Facet operations (latest versions only)
+	GetCategoryFacets(ctx context.Context, filters PolicyFilters) ([]FacetCount, error)
+	GetProviderFacets(ctx context.Context, filters PolicyFilters) ([]FacetCount, error)
+	GetPlatformFacets(ctx context.Context, filters PolicyFilters) ([]FacetCount, error)
+	GetTagFacets(ctx context.Context, filters PolicyFilters) ([]FacetCount, error)
 
 	GetPolicyVersion(ctx context.Context, name string, version string) (*PolicyVersion, error)
+	GetPolicyVersionByID(ctx context.Context, id int32) (*PolicyVersion, error)
 	ListPolicyVersions(ctx context.Context, name string, page, pageSize int) ([]*PolicyVersion, error)
+	ListPolicyVersionsAfter(ctx context.Context, name string, after *PageCursor, limit int) ([]*PolicyVersion, error)
 	CountPolicyVersions(ctx context.Context, name string) (int, error)
+	// SelectPolicyVersions returns every matching version ordered by policy name and semantic version
+	SelectPolicyVersions(ctx context.Context, selection VersionSelection) ([]*PolicyVersion, error)
 	GetLatestPolicyVersion(ctx context.Context, name string) (*PolicyVersion, error)
 	CreatePolicyVersion(ctx context.Context, version *PolicyVersion) (*PolicyVersion, error)
+	CreatePolicyVersionsWithDocs(ctx context.Context, items []*VersionWithDocs) ([]*PolicyVersion, error)
 
 	// Bulk strategy-based policy retrieval
 	BulkGetPolicyVersionsByExact(ctx context.Context, requests []ExactVersionRequest) ([]ResolvePolicyVersion, error)
@@ -38,5 +54,19 @@ type Repository interface {
 	// Documentation operations
 	GetPolicyDoc(ctx context.Context, versionID int32, page string) (*PolicyDoc, error)
 	ListPolicyDocs(ctx context.Context, versionID int32) ([]*PolicyDoc, error)
-	UpsertPolicyDoc(ctx context.Context, doc *PolicyDoc) (*PolicyDoc, error)
+	// ListFallbackDocs returns, for each page, the doc of the closest release of a policy's major line
+	// below major.minor.patch
+	ListFallbackDocs(ctx context.Context, name string, major, minor, patch int) ([]*DocContent, error)
+	// UpsertPolicyDoc stores a page and records a revision described by info when its content changes
+	UpsertPolicyDoc(ctx context.Context, doc *PolicyDoc, info RevisionInfo) (*PolicyDoc, error)
+	// ListDocRevisions returns the revisions of a page without their content, newest first
+	ListDocRevisions(ctx context.Context, versionID int32, page string) ([]*DocRevision, error)
+	GetDocRevision(ctx context.Context, versionID int32, page string, revision int) (*DocRevision, error)
+	GetLatestDocRevision(ctx context.Context, versionID int32, page string) (*DocRevision, error)
+
+	// Reconciliation operations
+	// ListVersionSourcesDue returns up to limit sources not reconciled since reconciledBefore, least recently reconciled first
+	ListVersionSourcesDue(ctx context.Context, reconciledBefore time.Time, limit int) ([]*VersionSource, error)
+	RecordReconciliation(ctx context.Context, versionID int32, drift []DriftItem, lastError string) error
+	ListDriftedVersions(ctx context.Context) ([]*DriftedVersion, error)
 }
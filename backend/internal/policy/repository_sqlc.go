@@ -13,6 +13,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -61,6 +63,14 @@ func ptrToPgtypeDate(t *time.Time) pgtype.Date {
 	return pgtype.Date{}
 }
 
+// Helper to convert a keyset cursor to the (created_at, id) query arguments
+func cursorToPgtype(after *PageCursor) (pgtype.Timestamptz, int32) {
+	if after == nil {
+		return pgtype.Timestamptz{}, 0
+	}
+	return pgtype.Timestamptz{Time: after.CreatedAt, Valid: true}, after.ID
+}
+
 // Helper to convert *time.Time to sql.NullTime
 // Mapper functions to convert between sqlc and domain models
 
@@ -225,6 +235,45 @@ func sqlcToPolicyDoc(spd sqlc.PolicyDoc) *PolicyDoc {
 	}
 }
 
+func sqlcToVersionSource(sps sqlc.PolicySource) (*VersionSource, error) {
+	source := &VersionSource{
+		PolicyVersionID: sps.PolicyVersionID,
+		SourceType:      sps.SourceType,
+		DefinitionURL:   sps.DefinitionUrl.String,
+		AssetsBaseURL:   sps.AssetsBaseUrl.String,
+		LastError:       sps.LastError.String,
+	}
+	if err := json.Unmarshal(sps.DocUrls, &source.DocURLs); err != nil {
+		return nil, errs.NewInternalError("failed to decode doc URLs", map[string]any{"error": err.Error()})
+	}
+	if sps.Drift != nil {
+		if err := json.Unmarshal(sps.Drift, &source.Drift); err != nil {
+			return nil, errs.NewInternalError("failed to decode drift", map[string]any{"error": err.Error()})
+		}
+	}
+	if sps.ReconciledAt.Valid {
+		source.ReconciledAt = &sps.ReconciledAt.Time
+	}
+	if sps.DriftDetectedAt.Valid {
+		source.DriftDetectedAt = &sps.DriftDetectedAt.Time
+	}
+	return source, nil
+}
+
+func sqlcToDocRevision(spr sqlc.PolicyDocRevision) *DocRevision {
+	return &DocRevision{
+		ID:              spr.ID,
+		PolicyVersionID: spr.PolicyVersionID,
+		Page:            spr.Page,
+		Revision:        int(spr.Revision),
+		ContentMd:       spr.ContentMd,
+		Origin:          spr.Origin,
+		Actor:           spr.Actor.String,
+		Size:            len(spr.ContentMd),
+		CreatedAt:       spr.CreatedAt.Time,
+	}
+}
+
 // filterRowToPolicyVersion converts FilterPoliciesByMultipleRow to PolicyVersion
 func filterRowToPolicyVersion(row sqlc.FilterPoliciesByMultipleRow) (*PolicyVersion, error) {
 	var categories, tags, platforms []string
@@ -320,6 +369,8 @@ func (r *SQLCRepository) ListPolicies(ctx context.Context, filters PolicyFilters
 		Column2: filters.Categories,
 		Column3: filters.Providers,
 		Column4: filters.Platforms,
+		Column5: filters.Tags,
+		Column6: filters.MatchAllTags,
 		Limit:   limit,
 		Offset:  offset,
 	})
@@ -340,6 +391,73 @@ func (r *SQLCRepository) ListPolicies(ctx context.Context, filters PolicyFilters
 	return policies, nil
 }
 
+func (r *SQLCRepository) ListPoliciesByName(ctx context.Context, filters PolicyFilters, after *NameCursor, limit int) ([]*PolicyVersion, error) {
+	q := r.queries
+
+	afterName := ""
+	offset := int32((filters.Page - 1) * filters.PageSize)
+	if after != nil {
+		afterName = after.Name
+		offset = 0
+	}
+
+	rows, err := q.FilterPoliciesByName(ctx, sqlc.FilterPoliciesByNameParams{
+		Column1: filters.Search,
+		Column2: filters.Categories,
+		Column3: filters.Providers,
+		Column4: filters.Platforms,
+		Column5: filters.Tags,
+		Column6: filters.MatchAllTags,
+		Column7: afterName,
+		Limit:   int32(limit),
+		Offset:  offset,
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list policies", map[string]any{"error": err.Error()})
+	}
+
+	policies := make([]*PolicyVersion, 0, len(rows))
+	for _, row := range rows {
+		p, err := filterRowToPolicyVersion(sqlc.FilterPoliciesByMultipleRow(row))
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+
+	return policies, nil
+}
+
+func (r *SQLCRepository) ListPoliciesByPopularity(ctx context.Context, filters PolicyFilters, since time.Time) ([]*PolicyVersion, error) {
+	q := r.queries
+
+	rows, err := q.FilterPoliciesByPopularity(ctx, sqlc.FilterPoliciesByPopularityParams{
+		Column1: filters.Search,
+		Column2: filters.Categories,
+		Column3: filters.Providers,
+		Column4: filters.Platforms,
+		Column5: filters.Tags,
+		Column6: filters.MatchAllTags,
+		Column7: pgtype.Date{Time: since, Valid: true},
+		Limit:   int32(filters.PageSize),
+		Offset:  int32((filters.Page - 1) * filters.PageSize),
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list policies by popularity", map[string]any{"error": err.Error()})
+	}
+
+	policies := make([]*PolicyVersion, 0, len(rows))
+	for _, row := range rows {
+		p, err := filterRowToPolicyVersion(sqlc.FilterPoliciesByMultipleRow(row))
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+
+	return policies, nil
+}
+
 func (r *SQLCRepository) CountPolicies(ctx context.Context, filters PolicyFilters) (int, error) {
 	q := r.queries
 	var count int64
@@ -356,6 +474,8 @@ func (r *SQLCRepository) CountPolicies(ctx context.Context, filters PolicyFilter
 		Column2: filters.Categories,
 		Column3: filters.Providers,
 		Column4: filters.Platforms,
+		Column5: filters.Tags,
+		Column6: filters.MatchAllTags,
 	})
 
 	if err != nil {
@@ -397,6 +517,98 @@ func (r *SQLCRepository) GetDistinctPlatforms(ctx context.Context) ([]string, er
 	return platforms, nil
 }
 
+func (r *SQLCRepository) GetDistinctTags(ctx context.Context) ([]string, error) {
+	q := r.queries
+	tags, err := q.GetDistinctTags(ctx)
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to get distinct tags", map[string]any{"error": err.Error()})
+	}
+
+	return tags, nil
+}
+
+// Facet operations
+
+func (r *SQLCRepository) GetCategoryFacets(ctx context.Context, filters PolicyFilters) ([]FacetCount, error) {
+	rows, err := r.queries.GetCategoryFacets(ctx, sqlc.GetCategoryFacetsParams{
+		Column1: filters.Search,
+		Column2: filters.Categories,
+		Column3: filters.Providers,
+		Column4: filters.Platforms,
+		Column5: filters.Tags,
+		Column6: filters.MatchAllTags,
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to get category facets", map[string]any{"error": err.Error()})
+	}
+
+	facets := make([]FacetCount, 0, len(rows))
+	for _, row := range rows {
+		facets = append(facets, FacetCount{Value: row.Value, Count: int(row.Count)})
+	}
+	return facets, nil
+}
+
+func (r *SQLCRepository) GetProviderFacets(ctx context.Context, filters PolicyFilters) ([]FacetCount, error) {
+	rows, err := r.queries.GetProviderFacets(ctx, sqlc.GetProviderFacetsParams{
+		Column1: filters.Search,
+		Column2: filters.Categories,
+		Column3: filters.Providers,
+		Column4: filters.Platforms,
+		Column5: filters.Tags,
+		Column6: filters.MatchAllTags,
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to get provider facets", map[string]any{"error": err.Error()})
+	}
+
+	facets := make([]FacetCount, 0, len(rows))
+	for _, row := range rows {
+		facets = append(facets, FacetCount{Value: row.Value, Count: int(row.Count)})
+	}
+	return facets, nil
+}
+
+func (r *SQLCRepository) GetPlatformFacets(ctx context.Context, filters PolicyFilters) ([]FacetCount, error) {
+	rows, err := r.queries.GetPlatformFacets(ctx, sqlc.GetPlatformFacetsParams{
+		Column1: filters.Search,
+		Column2: filters.Categories,
+		Column3: filters.Providers,
+		Column4: filters.Platforms,
+		Column5: filters.Tags,
+		Column6: filters.MatchAllTags,
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to get platform facets", map[string]any{"error": err.Error()})
+	}
+
+	facets := make([]FacetCount, 0, len(rows))
+	for _, row := range rows {
+		facets = append(facets, FacetCount{Value: row.Value, Count: int(row.Count)})
+	}
+	return facets, nil
+}
+
+func (r *SQLCRepository) GetTagFacets(ctx context.Context, filters PolicyFilters) ([]FacetCount, error) {
+	rows, err := r.queries.GetTagFacets(ctx, sqlc.GetTagFacetsParams{
+		Column1: filters.Search,
+		Column2: filters.Categories,
+		Column3: filters.Providers,
+		Column4: filters.Platforms,
+		Column5: filters.Tags,
+		Column6: filters.MatchAllTags,
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to get tag facets", map[string]any{"error": err.Error()})
+	}
+
+	facets := make([]FacetCount, 0, len(rows))
+	for _, row := range rows {
+		facets = append(facets, FacetCount{Value: row.Value, Count: int(row.Count)})
+	}
+	return facets, nil
+}
+
 // PolicyVersion operations
 
 func (r *SQLCRepository) GetPolicyVersion(ctx context.Context, name string, version string) (*PolicyVersion, error) {
@@ -414,6 +626,17 @@ func (r *SQLCRepository) GetPolicyVersion(ctx context.Context, name string, vers
 	return sqlcToPolicyVersion(spv)
 }
 
+func (r *SQLCRepository) GetPolicyVersionByID(ctx context.Context, id int32) (*PolicyVersion, error) {
+	spv, err := r.queries.GetPolicyVersionByID(ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.NewNotFoundError(errs.CodePolicyVersionNotFound, "Policy version not found", map[string]any{"id": id})
+		}
+		return nil, errs.NewDatabaseError("failed to get policy version", map[string]any{"error": err.Error()})
+	}
+	return sqlcToPolicyVersion(spv)
+}
+
 func (r *SQLCRepository) ListPolicyVersions(ctx context.Context, name string, page, pageSize int) ([]*PolicyVersion, error) {
 	q := r.queries
 	offset := int32((page - 1) * pageSize)
@@ -441,6 +664,32 @@ func (r *SQLCRepository) ListPolicyVersions(ctx context.Context, name string, pa
 	return versions, nil
 }
 
+func (r *SQLCRepository) ListPolicyVersionsAfter(ctx context.Context, name string, after *PageCursor, limit int) ([]*PolicyVersion, error) {
+	q := r.queries
+	afterCreatedAt, afterID := cursorToPgtype(after)
+
+	spvs, err := q.ListPolicyVersionsAfter(ctx, sqlc.ListPolicyVersionsAfterParams{
+		PolicyName: name,
+		Column2:    afterCreatedAt,
+		Column3:    afterID,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list policy versions", map[string]any{"error": err.Error()})
+	}
+
+	versions := make([]*PolicyVersion, 0, len(spvs))
+	for _, spv := range spvs {
+		pv, err := sqlcToPolicyVersion(spv)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, pv)
+	}
+
+	return versions, nil
+}
+
 func (r *SQLCRepository) CountPolicyVersions(ctx context.Context, name string) (int, error) {
 	q := r.queries
 	count, err := q.CountPolicyVersions(ctx, name)
@@ -450,6 +699,34 @@ func (r *SQLCRepository) CountPolicyVersions(ctx context.Context, name string) (
 	return int(count), nil
 }
 
+func (r *SQLCRepository) SelectPolicyVersions(ctx context.Context, selection VersionSelection) ([]*PolicyVersion, error) {
+	q := r.queries
+	names := selection.PolicyNames
+	if names == nil {
+		names = []string{}
+	}
+
+	spvs, err := q.SelectPolicyVersions(ctx, sqlc.SelectPolicyVersionsParams{
+		Column1: names,
+		Column2: selection.Provider,
+		Column3: selection.Platform,
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to select policy versions", map[string]any{"error": err.Error()})
+	}
+
+	versions := make([]*PolicyVersion, 0, len(spvs))
+	for _, spv := range spvs {
+		pv, err := sqlcToPolicyVersion(spv)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, pv)
+	}
+
+	return versions, nil
+}
+
 func (r *SQLCRepository) GetLatestPolicyVersion(ctx context.Context, name string) (*PolicyVersion, error) {
 	q := r.queries
 	spv, err := q.GetLatestPolicyVersion(ctx, name)
@@ -462,27 +739,45 @@ func (r *SQLCRepository) GetLatestPolicyVersion(ctx context.Context, name string
 	return sqlcToPolicyVersion(spv)
 }
 
-// determineIsLatestInTransaction determines if a version should be latest within a transaction
-func (r *SQLCRepository) determineIsLatestInTransaction(ctx context.Context, q *sqlc.Queries, policyName, newVersion string) (bool, error) {
+// determineIsLatestInTransaction determines if a version should be latest within a transaction.
+// It also returns the current latest version, empty when the policy has no versions yet.
+func (r *SQLCRepository) determineIsLatestInTransaction(ctx context.Context, q *sqlc.Queries, policyName, newVersion string) (bool, string, error) {
 	// Get the current latest version within this transaction
 	currentLatest, err := q.GetLatestPolicyVersion(ctx, policyName)
 	if err != nil {
 		// If no versions exist yet, this is the first version and should be latest
 		if err == pgx.ErrNoRows {
-			return true, nil
+			return true, "", nil
 		}
-		return false, errs.NewDatabaseError("failed to get current latest version", map[string]any{"error": err.Error()})
+		return false, "", errs.NewDatabaseError("failed to get current latest version", map[string]any{"error": err.Error()})
 	}
 
-	// Compare versions semantically using semver
-	result := semver.Compare(r.normalizeVersion(newVersion), r.normalizeVersion(currentLatest.Version))
-
 	// Version should be latest if it's greater than current latest
-	return result > 0, nil
+	return isNewerVersion(newVersion, currentLatest.Version), currentLatest.Version, nil
+}
+
+// isNewerVersion reports whether a version is semantically greater than another
+func isNewerVersion(version, than string) bool {
+	return semver.Compare(normalizeVersion(version), normalizeVersion(than)) > 0
+}
+
+// releaseParts returns the major, minor and patch numbers of a semantic version, ignoring any
+// prerelease or build suffix
+func releaseParts(version string) (major, minor, patch int, ok bool) {
+	canonical := semver.Canonical(normalizeVersion(version))
+	if canonical == "" {
+		return 0, 0, 0, false
+	}
+	core, _, _ := strings.Cut(strings.TrimPrefix(canonical, "v"), "-")
+	parts := strings.Split(core, ".")
+	major, _ = strconv.Atoi(parts[0])
+	minor, _ = strconv.Atoi(parts[1])
+	patch, _ = strconv.Atoi(parts[2])
+	return major, minor, patch, true
 }
 
 // normalizeVersion ensures version strings are in semver format (adds 'v' prefix if missing)
-func (r *SQLCRepository) normalizeVersion(version string) string {
+func normalizeVersion(version string) string {
 	if version == "" {
 		return "v0.0.0"
 	}
@@ -500,10 +795,82 @@ func (r *SQLCRepository) CreatePolicyVersion(ctx context.Context, version *Polic
 	}
 	defer tx.Rollback(ctx)
 
+	created, err := r.insertPolicyVersionInTransaction(ctx, sqlc.New(tx), version)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errs.NewDatabaseError("failed to commit transaction", map[string]any{"error": err.Error()})
+	}
+
+	return created, nil
+}
+
+func (r *SQLCRepository) CreatePolicyVersionsWithDocs(ctx context.Context, items []*VersionWithDocs) ([]*PolicyVersion, error) {
+	// A single transaction covers every version and doc page so that nothing is written on failure
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to start transaction", map[string]any{"error": err.Error()})
+	}
+	defer tx.Rollback(ctx)
+
 	q := sqlc.New(tx)
 
+	created := make([]*PolicyVersion, 0, len(items))
+	for idx, item := range items {
+		pv, err := r.insertPolicyVersionInTransaction(ctx, q, item.Version)
+		if err != nil {
+			return nil, &VersionWriteError{Index: idx, PolicyName: item.Version.PolicyName, Version: item.Version.Version, Err: err}
+		}
+
+		for _, doc := range item.Docs {
+			_, err := q.UpsertPolicyDoc(ctx, sqlc.UpsertPolicyDocParams{
+				PolicyVersionID: pv.ID,
+				Page:            doc.Page,
+				ContentMd:       doc.ContentMd,
+			})
+			if err != nil {
+				return nil, errs.NewDatabaseError("failed to upsert policy doc", map[string]any{"error": err.Error()})
+			}
+
+			if err := r.insertDocRevisionInTransaction(ctx, q, pv.ID, doc.Page, doc.ContentMd, RevisionInfo{Origin: DocOriginPublish}); err != nil {
+				return nil, err
+			}
+
+			err = r.insertChangeInTransaction(ctx, q, ChangeDocsChanged, pv.PolicyName, pv.Version, DocsEvent{
+				PolicyName: pv.PolicyName,
+				Version:    pv.Version,
+				Provider:   pv.Provider,
+				Page:       doc.Page,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if item.Source != nil {
+			if err := r.insertSourceInTransaction(ctx, q, pv.ID, item.Source); err != nil {
+				return nil, err
+			}
+		}
+
+		created = append(created, pv)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errs.NewDatabaseError("failed to commit transaction", map[string]any{"error": err.Error()})
+	}
+
+	return created, nil
+}
+
+// insertPolicyVersionInTransaction inserts a version and maintains the is_latest flags within a transaction.
+// Insert errors are returned unwrapped so that callers can detect unique constraint violations.
+func (r *SQLCRepository) insertPolicyVersionInTransaction(ctx context.Context, q *sqlc.Queries, version *PolicyVersion) (*PolicyVersion, error) {
 	// Determine if this version should be latest by comparing with current latest
-	isLatest, err := r.determineIsLatestInTransaction(ctx, q, version.PolicyName, version.Version)
+	isLatest, previousLatest, err := r.determineIsLatestInTransaction(ctx, q, version.PolicyName, version.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -550,12 +917,136 @@ func (r *SQLCRepository) CreatePolicyVersion(ctx context.Context, version *Polic
 		return nil, err
 	}
 
-	// Commit transaction
-	if err = tx.Commit(ctx); err != nil {
-		return nil, errs.NewDatabaseError("failed to commit transaction", map[string]any{"error": err.Error()})
+	created, err := sqlcToPolicyVersion(spv)
+	if err != nil {
+		return nil, err
 	}
 
-	return sqlcToPolicyVersion(spv)
+	versionEvent := VersionEvent{
+		PolicyName:  created.PolicyName,
+		Version:     created.Version,
+		DisplayName: created.DisplayName,
+		Provider:    created.Provider,
+		IsLatest:    created.IsLatest,
+		Categories:  created.Categories,
+		Tags:        created.Tags,
+		DownloadURL: created.DownloadURL,
+	}
+
+	// Subscribers are notified from the outbox once this transaction commits
+	err = r.insertEventInTransaction(ctx, q, EventVersionPublished, created.PolicyName, created.Version, created.Provider, versionEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.insertChangeInTransaction(ctx, q, ChangeVersionCreated, created.PolicyName, created.Version, versionEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	// The previous latest version lost its flag to this one
+	if created.IsLatest && previousLatest != "" {
+		err = r.insertChangeInTransaction(ctx, q, ChangeStateChanged, created.PolicyName, previousLatest, VersionState{IsLatest: false})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return created, nil
+}
+
+// lockDocPageInTransaction serializes the writers of a doc page until the transaction ends
+func (r *SQLCRepository) lockDocPageInTransaction(ctx context.Context, q *sqlc.Queries, versionID int32, page string) error {
+	err := q.LockPolicyDocPage(ctx, sqlc.LockPolicyDocPageParams{Column1: versionID, Column2: page})
+	if err != nil {
+		return errs.NewDatabaseError("failed to lock policy doc", map[string]any{"error": err.Error()})
+	}
+	return nil
+}
+
+// insertDocRevisionInTransaction records the content of a doc page as its next revision within a transaction.
+// The page is locked first, as the revision number is derived from the revisions stored so far.
+func (r *SQLCRepository) insertDocRevisionInTransaction(ctx context.Context, q *sqlc.Queries, versionID int32, page, content string, info RevisionInfo) error {
+	if err := r.lockDocPageInTransaction(ctx, q, versionID, page); err != nil {
+		return err
+	}
+
+	err := q.InsertPolicyDocRevision(ctx, sqlc.InsertPolicyDocRevisionParams{
+		PolicyVersionID: versionID,
+		Page:            page,
+		ContentMd:       content,
+		Origin:          info.Origin,
+		Actor:           pgtype.Text{String: info.Actor, Valid: info.Actor != ""},
+	})
+	if err != nil {
+		return errs.NewDatabaseError("failed to record policy doc revision", map[string]any{"error": err.Error()})
+	}
+	return nil
+}
+
+// insertSourceInTransaction records where a new version was fetched from within a transaction
+func (r *SQLCRepository) insertSourceInTransaction(ctx context.Context, q *sqlc.Queries, versionID int32, source *VersionSource) error {
+	docURLs := source.DocURLs
+	if docURLs == nil {
+		docURLs = map[string]string{}
+	}
+	docURLsJSON, _ := json.Marshal(docURLs)
+
+	err := q.InsertPolicySource(ctx, sqlc.InsertPolicySourceParams{
+		PolicyVersionID: versionID,
+		SourceType:      source.SourceType,
+		DefinitionUrl:   pgtype.Text{String: source.DefinitionURL, Valid: source.DefinitionURL != ""},
+		DocUrls:         docURLsJSON,
+		AssetsBaseUrl:   pgtype.Text{String: source.AssetsBaseURL, Valid: source.AssetsBaseURL != ""},
+	})
+	if err != nil {
+		return errs.NewDatabaseError("failed to record policy source", map[string]any{"error": err.Error()})
+	}
+	return nil
+}
+
+// insertEventInTransaction records a catalog event in the outbox within a transaction
+func (r *SQLCRepository) insertEventInTransaction(ctx context.Context, q *sqlc.Queries, eventType, policyName, version, provider string, payload any) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return errs.NewInternalError("failed to encode catalog event", map[string]any{"error": err.Error()})
+	}
+
+	err = q.InsertOutboxEvent(ctx, sqlc.InsertOutboxEventParams{
+		EventType:  eventType,
+		PolicyName: policyName,
+		Version:    version,
+		Provider:   provider,
+		Payload:    payloadJSON,
+	})
+	if err != nil {
+		return errs.NewDatabaseError("failed to record catalog event", map[string]any{"error": err.Error()})
+	}
+	return nil
+}
+
+// insertChangeInTransaction appends a catalog mutation to the change log within a transaction
+func (r *SQLCRepository) insertChangeInTransaction(ctx context.Context, q *sqlc.Queries, changeType, policyName, version string, payload any) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return errs.NewInternalError("failed to encode catalog change", map[string]any{"error": err.Error()})
+	}
+
+	// Held until commit so that feed readers never see a later id before an earlier one
+	if err := q.LockChangeLog(ctx); err != nil {
+		return errs.NewDatabaseError("failed to lock change log", map[string]any{"error": err.Error()})
+	}
+
+	err = q.InsertChange(ctx, sqlc.InsertChangeParams{
+		ChangeType: changeType,
+		PolicyName: policyName,
+		Version:    version,
+		Payload:    payloadJSON,
+	})
+	if err != nil {
+		return errs.NewDatabaseError("failed to record catalog change", map[string]any{"error": err.Error()})
+	}
+	return nil
 }
 
 // PolicyDoc operations
@@ -590,8 +1081,58 @@ func (r *SQLCRepository) ListPolicyDocs(ctx context.Context, versionID int32) ([
 	return docs, nil
 }
 
-func (r *SQLCRepository) UpsertPolicyDoc(ctx context.Context, doc *PolicyDoc) (*PolicyDoc, error) {
-	q := r.queries
+func (r *SQLCRepository) ListFallbackDocs(ctx context.Context, name string, major, minor, patch int) ([]*DocContent, error) {
+	rows, err := r.queries.ListFallbackPolicyDocs(ctx, sqlc.ListFallbackPolicyDocsParams{
+		PolicyName: name,
+		Column2:    int32(major),
+		Column3:    int32(minor),
+		Column4:    int32(patch),
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list fallback policy docs", map[string]any{"error": err.Error()})
+	}
+
+	docs := make([]*DocContent, 0, len(rows))
+	for _, row := range rows {
+		docs = append(docs, &DocContent{
+			Page:          row.Page,
+			ContentMd:     row.ContentMd,
+			SourceVersion: row.Version,
+		})
+	}
+
+	return docs, nil
+}
+
+func (r *SQLCRepository) UpsertPolicyDoc(ctx context.Context, doc *PolicyDoc, info RevisionInfo) (*PolicyDoc, error) {
+	// The docs.updated event and the change log entry are recorded in the same transaction as the change
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to start transaction", map[string]any{"error": err.Error()})
+	}
+	defer tx.Rollback(ctx)
+
+	q := sqlc.New(tx)
+
+	// Held until commit so that concurrent writers of the page neither both see a change nor number
+	// their revisions alike
+	if err := r.lockDocPageInTransaction(ctx, q, doc.PolicyVersionID, doc.Page); err != nil {
+		return nil, err
+	}
+
+	// Only changes to an existing page are events; pages stored with a new version are not.
+	// Both are recorded in the change log so that mirrors can follow every page.
+	created, changed := true, false
+	existing, err := q.GetPolicyDoc(ctx, sqlc.GetPolicyDocParams{
+		PolicyVersionID: doc.PolicyVersionID,
+		Page:            doc.Page,
+	})
+	if err == nil {
+		created, changed = false, existing.ContentMd != doc.ContentMd
+	} else if err != pgx.ErrNoRows {
+		return nil, errs.NewDatabaseError("failed to get policy doc", map[string]any{"error": err.Error()})
+	}
+
 	spd, err := q.UpsertPolicyDoc(ctx, sqlc.UpsertPolicyDocParams{
 		PolicyVersionID: doc.PolicyVersionID,
 		Page:            doc.Page,
@@ -600,9 +1141,160 @@ func (r *SQLCRepository) UpsertPolicyDoc(ctx context.Context, doc *PolicyDoc) (*
 	if err != nil {
 		return nil, errs.NewDatabaseError("failed to upsert policy doc", map[string]any{"error": err.Error()})
 	}
+
+	if created || changed {
+		if err := r.insertDocRevisionInTransaction(ctx, q, doc.PolicyVersionID, doc.Page, doc.ContentMd, info); err != nil {
+			return nil, err
+		}
+
+		spv, err := q.GetPolicyVersionByID(ctx, doc.PolicyVersionID)
+		if err != nil {
+			return nil, errs.NewDatabaseError("failed to get policy version", map[string]any{"error": err.Error()})
+		}
+		docsEvent := DocsEvent{
+			PolicyName: spv.PolicyName,
+			Version:    spv.Version,
+			Provider:   spv.Provider,
+			Page:       doc.Page,
+		}
+
+		if changed {
+			err = r.insertEventInTransaction(ctx, q, EventDocsUpdated, spv.PolicyName, spv.Version, spv.Provider, docsEvent)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = r.insertChangeInTransaction(ctx, q, ChangeDocsChanged, spv.PolicyName, spv.Version, docsEvent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errs.NewDatabaseError("failed to commit transaction", map[string]any{"error": err.Error()})
+	}
+
 	return sqlcToPolicyDoc(spd), nil
 }
 
+func (r *SQLCRepository) ListDocRevisions(ctx context.Context, versionID int32, page string) ([]*DocRevision, error) {
+	rows, err := r.queries.ListPolicyDocRevisions(ctx, sqlc.ListPolicyDocRevisionsParams{
+		PolicyVersionID: versionID,
+		Page:            page,
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list policy doc revisions", map[string]any{"error": err.Error()})
+	}
+
+	revisions := make([]*DocRevision, 0, len(rows))
+	for _, row := range rows {
+		revisions = append(revisions, &DocRevision{
+			ID:              row.ID,
+			PolicyVersionID: row.PolicyVersionID,
+			Page:            row.Page,
+			Revision:        int(row.Revision),
+			Origin:          row.Origin,
+			Actor:           row.Actor.String,
+			Size:            int(row.Size),
+			CreatedAt:       row.CreatedAt.Time,
+		})
+	}
+
+	return revisions, nil
+}
+
+func (r *SQLCRepository) GetDocRevision(ctx context.Context, versionID int32, page string, revision int) (*DocRevision, error) {
+	spr, err := r.queries.GetPolicyDocRevision(ctx, sqlc.GetPolicyDocRevisionParams{
+		PolicyVersionID: versionID,
+		Page:            page,
+		Revision:        int32(revision),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.NewNotFoundError(errs.CodeDocRevisionNotFound, "Documentation revision not found", map[string]any{"versionID": versionID, "page": page, "revision": revision})
+		}
+		return nil, errs.NewDatabaseError("failed to get policy doc revision", map[string]any{"error": err.Error()})
+	}
+	return sqlcToDocRevision(spr), nil
+}
+
+func (r *SQLCRepository) GetLatestDocRevision(ctx context.Context, versionID int32, page string) (*DocRevision, error) {
+	spr, err := r.queries.GetLatestPolicyDocRevision(ctx, sqlc.GetLatestPolicyDocRevisionParams{
+		PolicyVersionID: versionID,
+		Page:            page,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.NewNotFoundError(errs.CodeDocRevisionNotFound, "Documentation revision not found", map[string]any{"versionID": versionID, "page": page})
+		}
+		return nil, errs.NewDatabaseError("failed to get latest policy doc revision", map[string]any{"error": err.Error()})
+	}
+	return sqlcToDocRevision(spr), nil
+}
+
+// Reconciliation operations
+
+func (r *SQLCRepository) ListVersionSourcesDue(ctx context.Context, reconciledBefore time.Time, limit int) ([]*VersionSource, error) {
+	rows, err := r.queries.ListPolicySourcesDue(ctx, sqlc.ListPolicySourcesDueParams{
+		ReconciledAt: pgtype.Timestamptz{Time: reconciledBefore, Valid: true},
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list policy sources", map[string]any{"error": err.Error()})
+	}
+
+	sources := make([]*VersionSource, 0, len(rows))
+	for _, row := range rows {
+		source, err := sqlcToVersionSource(row)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+func (r *SQLCRepository) RecordReconciliation(ctx context.Context, versionID int32, drift []DriftItem, lastError string) error {
+	var driftJSON []byte
+	if len(drift) > 0 {
+		driftJSON, _ = json.Marshal(drift)
+	}
+
+	err := r.queries.UpdatePolicySourceReconciled(ctx, sqlc.UpdatePolicySourceReconciledParams{
+		PolicyVersionID: versionID,
+		Drift:           driftJSON,
+		LastError:       pgtype.Text{String: lastError, Valid: lastError != ""},
+	})
+	if err != nil {
+		return errs.NewDatabaseError("failed to record reconciliation", map[string]any{"error": err.Error()})
+	}
+	return nil
+}
+
+func (r *SQLCRepository) ListDriftedVersions(ctx context.Context) ([]*DriftedVersion, error) {
+	rows, err := r.queries.ListDriftedPolicySources(ctx)
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to list drifted policy versions", map[string]any{"error": err.Error()})
+	}
+
+	versions := make([]*DriftedVersion, 0, len(rows))
+	for _, row := range rows {
+		var drift []DriftItem
+		if err := json.Unmarshal(row.Drift, &drift); err != nil {
+			return nil, errs.NewInternalError("failed to decode drift", map[string]any{"error": err.Error()})
+		}
+		versions = append(versions, &DriftedVersion{
+			PolicyName:   row.PolicyName,
+			Version:      row.Version,
+			Drift:        drift,
+			DetectedAt:   row.DriftDetectedAt.Time,
+			ReconciledAt: row.ReconciledAt.Time,
+		})
+	}
+	return versions, nil
+}
+
 // Bulk strategy-based policy retrieval methods
 
 func (r *SQLCRepository) BulkGetPolicyVersionsByExact(ctx context.Context, requests []ExactVersionRequest) ([]ResolvePolicyVersion, error) {
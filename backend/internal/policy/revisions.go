@@ -0,0 +1,338 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/errs"
+)
+
+const (
+	// diffContext is the number of unchanged lines shown around each change
+	diffContext = 3
+	// maxDiffEdits bounds the work spent aligning two revisions; beyond it the diff replaces every line
+	maxDiffEdits = 1000
+)
+
+// ListDocRevisions returns the revisions of a documentation page without their content, newest first
+func (s *Service) ListDocRevisions(ctx context.Context, name, version, page string) ([]*DocRevision, error) {
+	policyVersion, err := s.GetPolicyVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repo.ListDocRevisions(ctx, policyVersion.ID, page)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("listing doc revisions")
+	}
+	if len(revisions) == 0 {
+		return nil, errs.DocNotFound(name, version, page)
+	}
+
+	return revisions, nil
+}
+
+// GetDocRevision retrieves a single revision of a documentation page with its content
+func (s *Service) GetDocRevision(ctx context.Context, name, version, page string, revision int) (*DocRevision, error) {
+	policyVersion, err := s.GetPolicyVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getDocRevision(ctx, policyVersion.ID, name, version, page, revision)
+}
+
+// DiffDocRevisions compares two revisions of a documentation page. A zero to compares against the
+// latest revision.
+func (s *Service) DiffDocRevisions(ctx context.Context, name, version, page string, from, to int) (*DocDiff, error) {
+	policyVersion, err := s.GetPolicyVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := s.getDocRevision(ctx, policyVersion.ID, name, version, page, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var toRevision *DocRevision
+	if to == 0 {
+		toRevision, err = s.repo.GetLatestDocRevision(ctx, policyVersion.ID, page)
+		if err != nil {
+			return nil, errs.SanitizeDatabaseError("getting latest doc revision")
+		}
+	} else {
+		toRevision, err = s.getDocRevision(ctx, policyVersion.ID, name, version, page, to)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ops := diffLines(splitLines(fromRevision.ContentMd), splitLines(toRevision.ContentMd))
+	diff := &DocDiff{
+		Page:         page,
+		FromRevision: fromRevision.Revision,
+		ToRevision:   toRevision.Revision,
+		Unified: unifiedDiff(
+			fmt.Sprintf("%s@%d", page, fromRevision.Revision),
+			fmt.Sprintf("%s@%d", page, toRevision.Revision),
+			ops,
+		),
+	}
+	for _, op := range ops {
+		switch op.kind {
+		case diffInsert:
+			diff.Added++
+		case diffDelete:
+			diff.Removed++
+		}
+	}
+
+	return diff, nil
+}
+
+// RestoreDocRevision makes the content of an earlier revision the current content of a documentation
+// page and returns the resulting latest revision. Restoring content equal to the current page records
+// no new revision.
+func (s *Service) RestoreDocRevision(ctx context.Context, name, version, page string, revision int, actor string) (*DocRevision, error) {
+	policyVersion, err := s.GetPolicyVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := s.getDocRevision(ctx, policyVersion.ID, name, version, page, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &PolicyDoc{PolicyVersionID: policyVersion.ID, Page: page, ContentMd: restored.ContentMd}
+	if _, err := s.repo.UpsertPolicyDoc(ctx, doc, RevisionInfo{Origin: DocOriginRestore, Actor: actor}); err != nil {
+		return nil, errs.SanitizeDatabaseError("restoring doc revision")
+	}
+
+	latest, err := s.repo.GetLatestDocRevision(ctx, policyVersion.ID, page)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("getting latest doc revision")
+	}
+
+	s.logger.Info("Doc revision restored",
+		zap.String("policyName", name),
+		zap.String("version", version),
+		zap.String("page", page),
+		zap.Int("restoredRevision", revision),
+		zap.Int("revision", latest.Revision),
+		zap.String("actor", actor))
+
+	return latest, nil
+}
+
+// getDocRevision retrieves a revision of a page of the given version, reporting a missing revision by
+// policy name and version
+func (s *Service) getDocRevision(ctx context.Context, versionID int32, name, version, page string, revision int) (*DocRevision, error) {
+	docRevision, err := s.repo.GetDocRevision(ctx, versionID, page, revision)
+	if err != nil {
+		var appErr *errs.AppError
+		if errors.As(err, &appErr) && appErr.Code == errs.CodeDocRevisionNotFound {
+			return nil, errs.DocRevisionNotFound(name, version, page, revision)
+		}
+		return nil, errs.SanitizeDatabaseError("getting doc revision")
+	}
+	return docRevision, nil
+}
+
+// Kinds of line diff operations, as prefixed in a unified diff
+const (
+	diffEqual  = ' '
+	diffDelete = '-'
+	diffInsert = '+'
+)
+
+// diffOp is one line of a line diff. Lines keep their trailing newline so that a missing newline at
+// the end of the content shows up as a change.
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// splitLines splits content into lines, each keeping its newline
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes a shortest line diff turning a into b using Myers' algorithm. Each step keeps
+// only the part of the frontier it reached, so memory grows with the square of the number of edits.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	limit := n + m
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		if d > maxDiffEdits {
+			return replaceLines(a, b)
+		}
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackDiff(a, b, trace)
+			}
+		}
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+	}
+
+	return replaceLines(a, b)
+}
+
+// backtrackDiff walks the frontiers recorded by diffLines back from the end of both inputs; trace
+// holds the frontier of every step before the one that reached the end
+func backtrackDiff(a, b []string, trace [][]int) []diffOp {
+	x, y := len(a), len(b)
+	var ops []diffOp
+
+	for d := len(trace); d > 0; d-- {
+		prev := trace[d-1]
+		at := func(k int) int { return prev[k+d-1] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, diffOp{kind: diffEqual, line: a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			ops = append(ops, diffOp{kind: diffInsert, line: b[y-1]})
+			y--
+		} else {
+			ops = append(ops, diffOp{kind: diffDelete, line: a[x-1]})
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		ops = append(ops, diffOp{kind: diffEqual, line: a[x-1]})
+		x--
+		y--
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// replaceLines is the diff that deletes every line of a and inserts every line of b
+func replaceLines(a, b []string) []diffOp {
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a {
+		ops = append(ops, diffOp{kind: diffDelete, line: line})
+	}
+	for _, line := range b {
+		ops = append(ops, diffOp{kind: diffInsert, line: line})
+	}
+	return ops
+}
+
+// unifiedDiff formats a line diff as a unified diff with diffContext lines of context. Changes closer
+// than twice the context share a hunk. It returns an empty string when nothing changed.
+func unifiedDiff(fromName, toName string, ops []diffOp) string {
+	// Line positions in a and b before each operation
+	aPos := make([]int, len(ops)+1)
+	bPos := make([]int, len(ops)+1)
+	for i, op := range ops {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if op.kind != diffInsert {
+			aPos[i+1]++
+		}
+		if op.kind != diffDelete {
+			bPos[i+1]++
+		}
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(ops); {
+		for i < len(ops) && ops[i].kind == diffEqual {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+
+		start := max(0, i-diffContext)
+		end := i + 1
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != diffEqual {
+				end = j + 1
+			} else if j-end >= 2*diffContext {
+				break
+			}
+		}
+		stop := min(len(ops), end+diffContext)
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n",
+			hunkRange(aPos[start], aPos[stop]-aPos[start]),
+			hunkRange(bPos[start], bPos[stop]-bPos[start]))
+		for _, op := range ops[start:stop] {
+			out.WriteByte(op.kind)
+			out.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				out.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		i = stop
+	}
+
+	return out.String()
+}
+
+// hunkRange formats the range of a hunk header from the number of lines before the hunk and its length
+func hunkRange(before, length int) string {
+	switch length {
+	case 0:
+		return fmt.Sprintf("%d,0", before)
+	case 1:
+		return fmt.Sprintf("%d", before+1)
+	default:
+		return fmt.Sprintf("%d,%d", before+1, length)
+	}
+}
@@ -12,9 +12,11 @@ package policy
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -25,18 +27,22 @@ import (
 // Service implements business logic for policies
 type Service struct {
 	repo   Repository
+	tags   *TagNormalizer
 	logger *logging.Logger
 }
 
 // NewService creates a new policy service
-func NewService(repo Repository, logger *logging.Logger) *Service {
+func NewService(repo Repository, tags *TagNormalizer, logger *logging.Logger) *Service {
 	return &Service{
 		repo:   repo,
+		tags:   tags,
 		logger: logger,
 	}
 }
 
-// ListPolicies retrieves a paginated list of policies with smart fallback to older versions
+// ListPolicies retrieves a paginated list of policies with smart fallback to older versions.
+// When filters.Cursor is set the page is read with keyset pagination instead of OFFSET; cursors are
+// issued for the name order only, since the other orders move policies as versions are synced.
 func (s *Service) ListPolicies(ctx context.Context, filters PolicyFilters) ([]*PolicyVersion, *PaginationInfo, error) {
 	// Validate and set defaults
 	if filters.Page < 1 {
@@ -45,24 +51,86 @@ func (s *Service) ListPolicies(ctx context.Context, filters PolicyFilters) ([]*P
 	if filters.PageSize < MinPageSize || filters.PageSize > MaxPageSize {
 		filters.PageSize = DefaultPageSize
 	}
+	filters.Tags = s.tags.NormalizeAll(filters.Tags)
 
-	// Database handles smart version selection AND pagination efficiently
-	policies, err := s.repo.ListPolicies(ctx, filters)
-	if err != nil {
-		return nil, nil, errs.SanitizeDatabaseError("listing policies")
+	if filters.Sort == "" {
+		filters.Sort = SortLatest
+		if filters.Cursor != "" {
+			filters.Sort = SortName
+		}
+	}
+	if filters.Sort != SortLatest && filters.Sort != SortPopular && filters.Sort != SortName {
+		return nil, nil, errs.NewValidationError("invalid sort", map[string]any{
+			"allowed_values": []string{SortLatest, SortPopular, SortName},
+			"provided":       filters.Sort,
+		})
+	}
+	if filters.Cursor != "" && filters.Sort != SortName {
+		return nil, nil, errs.NewValidationError("cursor pagination is only supported with name sort", map[string]any{
+			"allowed_values": []string{SortName},
+			"sort":           filters.Sort,
+		})
 	}
 
-	// Count unique policies for pagination
-	total, err := s.repo.CountPolicies(ctx, filters)
-	if err != nil {
-		return nil, nil, errs.SanitizeDatabaseError("counting policies")
+	var policies []*PolicyVersion
+	var hasMore bool
+	switch filters.Sort {
+	case SortPopular:
+		// Rank by usage over the recent window; ties fall back to the latest order
+		since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(PopularityWindowDays - 1))
+		var err error
+		policies, err = s.repo.ListPoliciesByPopularity(ctx, filters, since)
+		if err != nil {
+			return nil, nil, errs.SanitizeDatabaseError("listing policies")
+		}
+		hasMore = len(policies) == filters.PageSize
+	case SortName:
+		var after *NameCursor
+		var err error
+		if filters.Cursor != "" {
+			after, err = DecodeNameCursor(filters.Cursor)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		// Fetch one extra row to learn whether another page exists
+		policies, err = s.repo.ListPoliciesByName(ctx, filters, after, filters.PageSize+1)
+		if err != nil {
+			return nil, nil, errs.SanitizeDatabaseError("listing policies")
+		}
+		policies, hasMore = trimPage(policies, filters.PageSize)
+	default:
+		// Database handles smart version selection AND pagination efficiently
+		var err error
+		policies, err = s.repo.ListPolicies(ctx, filters)
+		if err != nil {
+			return nil, nil, errs.SanitizeDatabaseError("listing policies")
+		}
+		hasMore = len(policies) == filters.PageSize
 	}
 
 	pagination := &PaginationInfo{
-		Page:       filters.Page,
-		PageSize:   filters.PageSize,
-		TotalItems: total,
-		TotalPages: CalculateTotalPages(total, filters.PageSize),
+		Page:     filters.Page,
+		PageSize: filters.PageSize,
+	}
+
+	if filters.IncludeTotal {
+		// Count unique policies for pagination
+		total, err := s.repo.CountPolicies(ctx, filters)
+		if err != nil {
+			return nil, nil, errs.SanitizeDatabaseError("counting policies")
+		}
+		pagination.HasTotal = true
+		pagination.TotalItems = total
+		pagination.TotalPages = CalculateTotalPages(total, filters.PageSize)
+		if filters.Cursor == "" {
+			hasMore = filters.Page*filters.PageSize < total
+		}
+	}
+
+	if hasMore && len(policies) > 0 && filters.Sort == SortName {
+		pagination.NextCursor = EncodeNameCursor(policies[len(policies)-1])
 	}
 
 	return policies, pagination, nil
@@ -79,34 +147,78 @@ func (s *Service) GetPolicyWithLatestVersion(ctx context.Context, name string) (
 }
 
 // ListPolicyVersions retrieves versions for a policy
-func (s *Service) ListPolicyVersions(ctx context.Context, name string, page, pageSize int) ([]*PolicyVersion, *PaginationInfo, error) {
-	if page < 1 {
-		page = 1
+func (s *Service) ListPolicyVersions(ctx context.Context, name string, filters VersionFilters) ([]*PolicyVersion, *PaginationInfo, error) {
+	if filters.Page < 1 {
+		filters.Page = 1
 	}
-	if pageSize < MinPageSize || pageSize > MaxPageSize {
-		pageSize = DefaultPageSize
+	if filters.PageSize < MinPageSize || filters.PageSize > MaxPageSize {
+		filters.PageSize = DefaultPageSize
 	}
 
-	versions, err := s.repo.ListPolicyVersions(ctx, name, page, pageSize)
-	if err != nil {
-		return nil, nil, errs.NewDatabaseError("Failed to list versions", map[string]any{"error": err.Error()})
-	}
+	var versions []*PolicyVersion
+	var hasMore bool
+	if filters.Cursor != "" {
+		after, err := DecodeCursor(filters.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
 
-	total, err := s.repo.CountPolicyVersions(ctx, name)
-	if err != nil {
-		return nil, nil, errs.NewDatabaseError("Failed to count versions", map[string]any{"error": err.Error()})
+		versions, err = s.repo.ListPolicyVersionsAfter(ctx, name, after, filters.PageSize+1)
+		if err != nil {
+			return nil, nil, errs.NewDatabaseError("Failed to list versions", map[string]any{"error": err.Error()})
+		}
+		versions, hasMore = trimPage(versions, filters.PageSize)
+	} else {
+		var err error
+		versions, err = s.repo.ListPolicyVersions(ctx, name, filters.Page, filters.PageSize)
+		if err != nil {
+			return nil, nil, errs.NewDatabaseError("Failed to list versions", map[string]any{"error": err.Error()})
+		}
+		hasMore = len(versions) == filters.PageSize
 	}
 
 	pagination := &PaginationInfo{
-		Page:       page,
-		PageSize:   pageSize,
-		TotalItems: total,
-		TotalPages: CalculateTotalPages(total, pageSize),
+		Page:     filters.Page,
+		PageSize: filters.PageSize,
+	}
+
+	if filters.IncludeTotal {
+		total, err := s.repo.CountPolicyVersions(ctx, name)
+		if err != nil {
+			return nil, nil, errs.NewDatabaseError("Failed to count versions", map[string]any{"error": err.Error()})
+		}
+		pagination.HasTotal = true
+		pagination.TotalItems = total
+		pagination.TotalPages = CalculateTotalPages(total, filters.PageSize)
+		if filters.Cursor == "" {
+			hasMore = filters.Page*filters.PageSize < total
+		}
+	}
+
+	if hasMore && len(versions) > 0 {
+		pagination.NextCursor = EncodeCursor(versions[len(versions)-1])
 	}
 
 	return versions, pagination, nil
 }
 
+// trimPage drops the look-ahead row fetched by keyset queries and reports whether it existed
+func trimPage(items []*PolicyVersion, pageSize int) ([]*PolicyVersion, bool) {
+	if len(items) > pageSize {
+		return items[:pageSize], true
+	}
+	return items, false
+}
+
+// SelectPolicyVersions retrieves every version matching a selection, ordered by policy name and version
+func (s *Service) SelectPolicyVersions(ctx context.Context, selection VersionSelection) ([]*PolicyVersion, error) {
+	versions, err := s.repo.SelectPolicyVersions(ctx, selection)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("selecting policy versions")
+	}
+	return versions, nil
+}
+
 // GetPolicyVersion retrieves a specific policy version
 func (s *Service) GetPolicyVersion(ctx context.Context, name, version string) (*PolicyVersion, error) {
 	policyVersion, err := s.repo.GetPolicyVersion(ctx, name, version)
@@ -137,8 +249,9 @@ func (s *Service) GetPolicyDefinition(ctx context.Context, name, version string)
 	return []byte(policyVersion.DefinitionYAML), nil
 }
 
-// GetAllDocs retrieves all documentation pages for a version
-func (s *Service) GetAllDocs(ctx context.Context, name, version string) (map[string]string, error) {
+// GetAllDocs retrieves all documentation pages for a version, keyed by page. With fallback, pages the
+// version lacks are taken from the closest earlier release of the same major line.
+func (s *Service) GetAllDocs(ctx context.Context, name, version string, fallback bool) (map[string]*DocContent, error) {
 	policyVersion, err := s.GetPolicyVersion(ctx, name, version)
 	if err != nil {
 		return nil, err
@@ -149,27 +262,77 @@ func (s *Service) GetAllDocs(ctx context.Context, name, version string) (map[str
 		return nil, errs.NewDatabaseError("Failed to retrieve docs", map[string]any{"error": err.Error()})
 	}
 
-	result := make(map[string]string)
+	result := make(map[string]*DocContent)
 	for _, doc := range docs {
-		result[doc.Page] = doc.ContentMd
+		result[doc.Page] = &DocContent{Page: doc.Page, ContentMd: doc.ContentMd, SourceVersion: policyVersion.Version}
+	}
+
+	if fallback {
+		fallbackDocs, err := s.listFallbackDocs(ctx, policyVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range fallbackDocs {
+			if _, ok := result[doc.Page]; !ok {
+				result[doc.Page] = doc
+			}
+		}
 	}
 
 	return result, nil
 }
 
-// GetSingleDoc retrieves a single documentation page
-func (s *Service) GetSingleDoc(ctx context.Context, name, version, page string) (string, error) {
+// GetSingleDoc retrieves a single documentation page. With fallback, a page the version lacks is taken
+// from the closest earlier release of the same major line.
+func (s *Service) GetSingleDoc(ctx context.Context, name, version, page string, fallback bool) (*DocContent, error) {
 	policyVersion, err := s.GetPolicyVersion(ctx, name, version)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	doc, err := s.repo.GetPolicyDoc(ctx, policyVersion.ID, page)
+	if err == nil {
+		return &DocContent{Page: page, ContentMd: doc.ContentMd, SourceVersion: policyVersion.Version}, nil
+	}
+	if !fallback {
+		return nil, errs.DocNotFound(name, version, page)
+	}
+
+	fallbackDocs, err := s.listFallbackDocs(ctx, policyVersion)
 	if err != nil {
-		return "", errs.DocNotFound(name, version, page)
+		return nil, err
+	}
+	for _, doc := range fallbackDocs {
+		if doc.Page == page {
+			return doc, nil
+		}
+	}
+
+	return nil, errs.DocNotFound(name, version, page)
+}
+
+// listFallbackDocs returns, for each page, the doc of the closest release below a version in its major
+// line. Prereleases are never used as a fallback, and versions that are not semantic have none.
+func (s *Service) listFallbackDocs(ctx context.Context, policyVersion *PolicyVersion) ([]*DocContent, error) {
+	major, minor, patch, ok := releaseParts(policyVersion.Version)
+	if !ok {
+		return nil, nil
 	}
 
-	return doc.ContentMd, nil
+	docs, err := s.repo.ListFallbackDocs(ctx, policyVersion.PolicyName, major, minor, patch)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("listing fallback docs")
+	}
+	return docs, nil
+}
+
+// ListPolicyDocs retrieves all documentation pages of a version by its ID, ordered by page
+func (s *Service) ListPolicyDocs(ctx context.Context, versionID int32) ([]*PolicyDoc, error) {
+	docs, err := s.repo.ListPolicyDocs(ctx, versionID)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("listing policy docs")
+	}
+	return docs, nil
 }
 
 // CreatePolicyVersion creates a new policy version
@@ -178,6 +341,9 @@ func (s *Service) CreatePolicyVersion(ctx context.Context, version *PolicyVersio
 		zap.String("policyName", version.PolicyName),
 		zap.String("version", version.Version))
 
+	// Store tags in canonical form so that filtering and facets group equivalent spellings
+	version.Tags = s.tags.NormalizeAll(version.Tags)
+
 	// IsLatest will be determined atomically in the repository based on semantic versioning
 
 	// Attempt to create the version - database unique constraint will prevent duplicates
@@ -188,10 +354,7 @@ func (s *Service) CreatePolicyVersion(ctx context.Context, version *PolicyVersio
 			s.logger.Info("Policy version creation skipped - version already exists",
 				zap.String("policyName", version.PolicyName),
 				zap.String("version", version.Version))
-			return nil, errs.NewConflictError(errs.CodeValidationError, "Policy version already exists", map[string]any{
-				"policyName": version.PolicyName,
-				"version":    version.Version,
-			})
+			return nil, errs.PolicyVersionExists(version.PolicyName, version.Version)
 		}
 		s.logger.Error("Policy version creation failed - database error",
 			zap.String("policyName", version.PolicyName),
@@ -208,9 +371,68 @@ func (s *Service) CreatePolicyVersion(ctx context.Context, version *PolicyVersio
 	return created, nil
 }
 
-// UpsertPolicyDoc creates or updates a documentation page
-func (s *Service) UpsertPolicyDoc(ctx context.Context, doc *PolicyDoc) (*PolicyDoc, error) {
-	upserted, err := s.repo.UpsertPolicyDoc(ctx, doc)
+// CreatePolicyVersionsWithDocs creates several versions and their docs in a single transaction;
+// either all of them are stored or none
+func (s *Service) CreatePolicyVersionsWithDocs(ctx context.Context, items []*VersionWithDocs) ([]*PolicyVersion, error) {
+	for _, item := range items {
+		item.Version.Tags = s.tags.NormalizeAll(item.Version.Tags)
+	}
+
+	created, err := s.repo.CreatePolicyVersionsWithDocs(ctx, items)
+	if err != nil {
+		var itemErr *VersionWriteError
+		if errors.As(err, &itemErr) && errs.IsUniqueConstraintError(itemErr.Err) {
+			appErr := errs.PolicyVersionExists(itemErr.PolicyName, itemErr.Version)
+			appErr.Details["index"] = itemErr.Index
+			return nil, appErr
+		}
+		if errs.IsUniqueConstraintError(err) {
+			return nil, errs.NewConflictError(errs.CodePolicyVersionExists, "Policy version already exists", map[string]any{
+				"error": "a version in the batch was created concurrently",
+			})
+		}
+		s.logger.Error("Transactional policy version creation failed",
+			zap.Int("versions", len(items)),
+			zap.Error(err))
+		return nil, errs.SanitizeDatabaseError("creating policy versions")
+	}
+
+	s.logger.Info("Policy versions created in a single transaction", zap.Int("versions", len(created)))
+	return created, nil
+}
+
+// PolicyVersionExists reports whether the given version has already been published
+func (s *Service) PolicyVersionExists(ctx context.Context, name, version string) (bool, error) {
+	_, err := s.repo.GetPolicyVersion(ctx, name, version)
+	if err == nil {
+		return true, nil
+	}
+
+	var appErr *errs.AppError
+	if errors.As(err, &appErr) && appErr.Code == errs.CodePolicyVersionNotFound {
+		return false, nil
+	}
+	return false, errs.SanitizeDatabaseError("checking policy version")
+}
+
+// WouldBeLatest reports whether a version would become the latest version of its policy if it were
+// published now, along with the current latest version, which is empty for a new policy
+func (s *Service) WouldBeLatest(ctx context.Context, name, version string) (bool, string, error) {
+	latest, err := s.repo.GetLatestPolicyVersion(ctx, name)
+	if err != nil {
+		var appErr *errs.AppError
+		if errors.As(err, &appErr) && appErr.Code == errs.CodePolicyVersionNotFound {
+			return true, "", nil
+		}
+		return false, "", errs.SanitizeDatabaseError("getting latest policy version")
+	}
+	return isNewerVersion(version, latest.Version), latest.Version, nil
+}
+
+// UpsertPolicyDoc creates or updates a documentation page; a changed page is recorded as a new
+// revision described by info
+func (s *Service) UpsertPolicyDoc(ctx context.Context, doc *PolicyDoc, info RevisionInfo) (*PolicyDoc, error) {
+	upserted, err := s.repo.UpsertPolicyDoc(ctx, doc, info)
 	if err != nil {
 		return nil, errs.NewDatabaseError("Failed to upsert doc", map[string]any{"error": err.Error()})
 	}
@@ -218,6 +440,48 @@ func (s *Service) UpsertPolicyDoc(ctx context.Context, doc *PolicyDoc) (*PolicyD
 	return upserted, nil
 }
 
+// GetPolicyVersionByID retrieves a policy version by its ID
+func (s *Service) GetPolicyVersionByID(ctx context.Context, id int32) (*PolicyVersion, error) {
+	policyVersion, err := s.repo.GetPolicyVersionByID(ctx, id)
+	if err != nil {
+		var appErr *errs.AppError
+		if errors.As(err, &appErr) && appErr.Code == errs.CodePolicyVersionNotFound {
+			return nil, appErr
+		}
+		return nil, errs.SanitizeDatabaseError("getting policy version")
+	}
+	return policyVersion, nil
+}
+
+// ListVersionSourcesDue returns up to limit version sources not reconciled since reconciledBefore,
+// least recently reconciled first
+func (s *Service) ListVersionSourcesDue(ctx context.Context, reconciledBefore time.Time, limit int) ([]*VersionSource, error) {
+	sources, err := s.repo.ListVersionSourcesDue(ctx, reconciledBefore, limit)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("listing policy sources")
+	}
+	return sources, nil
+}
+
+// RecordReconciliation stores the outcome of reconciling a version with its sources. Empty drift
+// clears a previous drift flag.
+func (s *Service) RecordReconciliation(ctx context.Context, versionID int32, drift []DriftItem, lastError string) error {
+	if err := s.repo.RecordReconciliation(ctx, versionID, drift, lastError); err != nil {
+		return errs.SanitizeDatabaseError("recording reconciliation")
+	}
+	return nil
+}
+
+// ListDriftedVersions returns the versions whose upstream sources no longer match what is stored,
+// most recently detected first
+func (s *Service) ListDriftedVersions(ctx context.Context) ([]*DriftedVersion, error) {
+	versions, err := s.repo.ListDriftedVersions(ctx)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("listing drifted policy versions")
+	}
+	return versions, nil
+}
+
 // GetDistinctCategories retrieves all unique categories from policies
 func (s *Service) GetDistinctCategories(ctx context.Context) ([]string, error) {
 	categories, err := s.repo.GetDistinctCategories(ctx)
@@ -248,6 +512,57 @@ func (s *Service) GetDistinctPlatforms(ctx context.Context) ([]string, error) {
 	return platforms, nil
 }
 
+// GetDistinctTags retrieves all unique tags from latest policy versions
+func (s *Service) GetDistinctTags(ctx context.Context) ([]string, error) {
+	tags, err := s.repo.GetDistinctTags(ctx)
+	if err != nil {
+		return nil, errs.NewDatabaseError("Failed to get distinct tags", map[string]any{"error": err.Error()})
+	}
+
+	return tags, nil
+}
+
+// GetFacets counts matching latest policy versions per category, provider, platform and tag.
+// Each dimension ignores its own filter so that sibling values keep their counts (disjunctive faceting).
+func (s *Service) GetFacets(ctx context.Context, filters PolicyFilters) (*PolicyFacets, error) {
+	filters.Tags = s.tags.NormalizeAll(filters.Tags)
+
+	categoryFilters := filters
+	categoryFilters.Categories = nil
+	categories, err := s.repo.GetCategoryFacets(ctx, categoryFilters)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("counting category facets")
+	}
+
+	providerFilters := filters
+	providerFilters.Providers = nil
+	providers, err := s.repo.GetProviderFacets(ctx, providerFilters)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("counting provider facets")
+	}
+
+	platformFilters := filters
+	platformFilters.Platforms = nil
+	platforms, err := s.repo.GetPlatformFacets(ctx, platformFilters)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("counting platform facets")
+	}
+
+	tagFilters := filters
+	tagFilters.Tags = nil
+	tags, err := s.repo.GetTagFacets(ctx, tagFilters)
+	if err != nil {
+		return nil, errs.SanitizeDatabaseError("counting tag facets")
+	}
+
+	return &PolicyFacets{
+		Categories: categories,
+		Providers:  providers,
+		Platforms:  platforms,
+		Tags:       tags,
+	}, nil
+}
+
 // ResolvePolicyVersions resolves policy versions based on the provided requests
 func (s *Service) ResolvePolicyVersions(ctx context.Context, requests []*PolicyResolveRequest) ([]*PolicyResolveItem, error) {
 	if len(requests) == 0 {
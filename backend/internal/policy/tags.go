@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package policy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// TagNormalizer converts tags to their canonical form: lower case, whitespace runs
+// collapsed to a single hyphen, and configured synonyms replaced by their canonical tag
+type TagNormalizer struct {
+	synonyms map[string]string
+}
+
+// NewTagNormalizer creates a tag normalizer; synonym keys and values are normalized as well
+func NewTagNormalizer(synonyms map[string]string) *TagNormalizer {
+	normalized := make(map[string]string, len(synonyms))
+	for alias, canonical := range synonyms {
+		normalized[canonicalTag(alias)] = canonicalTag(canonical)
+	}
+	return &TagNormalizer{synonyms: normalized}
+}
+
+// Normalize returns the canonical form of a single tag
+func (n *TagNormalizer) Normalize(tag string) string {
+	tag = canonicalTag(tag)
+	if canonical, ok := n.synonyms[tag]; ok {
+		return canonical
+	}
+	return tag
+}
+
+// NormalizeAll normalizes tags, dropping empty and duplicate entries while keeping their order
+func (n *TagNormalizer) NormalizeAll(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = n.Normalize(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
+// Fingerprint returns a short hash of the normalized synonyms; it changes whenever the synonyms do
+func (n *TagNormalizer) Fingerprint() string {
+	h := sha256.New()
+	for _, alias := range slices.Sorted(maps.Keys(n.synonyms)) {
+		h.Write([]byte(alias + "=" + n.synonyms[alias] + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// canonicalTag lower-cases a tag and joins its words with hyphens
+func canonicalTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+}
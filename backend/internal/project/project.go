@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package project
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/policy"
+)
+
+// Layout of a policy project, as described in the custom policy guide
+const (
+	MetadataFile = "metadata.json"
+	DocsDir      = "docs"
+)
+
+// AssetDirs hold the images of a project: logos and banners in assets/, images referenced by docs in images/
+var AssetDirs = []string{"assets", "images"}
+
+// DefinitionFiles are the definition file names looked up, in order, when none is given
+var DefinitionFiles = []string{"policy-definition.yaml", "policy-definition.yml", "policy-definition.json"}
+
+// maxFileSize bounds the size of the metadata, definition and doc files of a project
+const maxFileSize = 10 << 20
+
+// Metadata is the metadata.json of a project; name and version identify the policy version
+type Metadata struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	policy.PolicyMetadata
+}
+
+// Project is a policy project read from a directory or an extracted package
+type Project struct {
+	Metadata       Metadata
+	DefinitionFile string
+	Definition     []byte
+	Docs           map[string]string // Page name to markdown
+	Assets         []string          // Files below the asset directories, relative to the project root
+}
+
+// Read reads a project from the root of fsys. definitionFile overrides the definition lookup when set.
+func Read(fsys fs.FS, definitionFile string) (*Project, error) {
+	p := &Project{Docs: make(map[string]string)}
+
+	body, err := readFile(fsys, MetadataFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &p.Metadata); err != nil {
+		return nil, errs.NewValidationError("invalid policy metadata", map[string]any{
+			"file":  MetadataFile,
+			"error": err.Error(),
+		})
+	}
+
+	candidates := DefinitionFiles
+	if definitionFile != "" {
+		candidates = []string{definitionFile}
+	}
+	for _, name := range candidates {
+		if _, statErr := fs.Stat(fsys, name); statErr == nil {
+			p.DefinitionFile = name
+			break
+		}
+	}
+	if p.DefinitionFile == "" {
+		return nil, errs.NewValidationError("policy definition not found", map[string]any{"files": candidates})
+	}
+	if p.Definition, err = readFile(fsys, p.DefinitionFile); err != nil {
+		return nil, err
+	}
+	var definition interface{}
+	if err := yaml.Unmarshal(p.Definition, &definition); err != nil {
+		return nil, errs.NewValidationError("invalid policy definition YAML", map[string]any{
+			"file":  p.DefinitionFile,
+			"error": err.Error(),
+		})
+	}
+
+	docs, err := fs.ReadDir(fsys, DocsDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, errs.NewValidationError("failed to read docs directory", map[string]any{"error": err.Error()})
+	}
+	for _, d := range docs {
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			continue
+		}
+		content, err := readFile(fsys, path.Join(DocsDir, d.Name()))
+		if err != nil {
+			return nil, err
+		}
+		p.Docs[strings.TrimSuffix(d.Name(), ".md")] = string(content)
+	}
+
+	for _, assetDir := range AssetDirs {
+		err = fs.WalkDir(fsys, assetDir, func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.Type().IsRegular() && !isHidden(name) {
+				p.Assets = append(p.Assets, name)
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, errs.NewValidationError("failed to read asset directory", map[string]any{"dir": assetDir, "error": err.Error()})
+		}
+	}
+
+	return p, nil
+}
+
+// Pages returns the doc page names in order
+func (p *Project) Pages() []string {
+	pages := make([]string, 0, len(p.Docs))
+	for page := range p.Docs {
+		pages = append(pages, page)
+	}
+	sort.Strings(pages)
+	return pages
+}
+
+// readFile reads a project file that must exist
+func readFile(fsys fs.FS, name string) ([]byte, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, errs.NewValidationError("required project file is missing", map[string]any{"file": name})
+		}
+		return nil, errs.NewValidationError("failed to read project file", map[string]any{"file": name, "error": err.Error()})
+	}
+	defer f.Close()
+
+	body, err := io.ReadAll(io.LimitReader(f, maxFileSize+1))
+	if err != nil {
+		return nil, errs.NewValidationError("failed to read project file", map[string]any{"file": name, "error": err.Error()})
+	}
+	if len(body) > maxFileSize {
+		return nil, errs.NewValidationError("project file is too large", map[string]any{"file": name, "maxBytes": maxFileSize})
+	}
+	return body, nil
+}
+
+// isHidden reports whether any element of a slash-separated path starts with a dot
+func isHidden(name string) bool {
+	for _, elem := range strings.Split(name, "/") {
+		if strings.HasPrefix(elem, ".") && elem != "." {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package project
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/wso2/policyhub/internal/errs"
+)
+
+// Package archive formats, named by their file extension
+const (
+	FormatTarGz = "tar.gz"
+	FormatZip   = "zip"
+)
+
+// maxEntries bounds the number of files extracted from one package
+const maxEntries = 10000
+
+// Unpack extracts a tar.gz or zip package into dir, writing at most maxSize bytes. It returns the
+// package format and the project root: dir itself, or the single top-level directory the package
+// wraps its files in.
+func Unpack(archive, dir string, maxSize int64) (string, string, error) {
+	format, err := detectFormat(archive)
+	if err != nil {
+		return "", "", err
+	}
+
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return "", "", errs.NewInternalError("failed to extract package", map[string]any{"error": err.Error()})
+	}
+	defer root.Close()
+	x := &extractor{root: root, remaining: maxSize}
+
+	switch format {
+	case FormatZip:
+		err = x.unzip(archive)
+	default:
+		err = x.untar(archive)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	return format, projectRoot(dir), nil
+}
+
+// detectFormat identifies a package by its leading bytes
+func detectFormat(archive string) (string, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return "", errs.NewInternalError("failed to read package", map[string]any{"error": err.Error()})
+	}
+	defer f.Close()
+
+	magic, _ := bufio.NewReader(f).Peek(4)
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		return FormatTarGz, nil
+	case bytes.HasPrefix(magic, []byte("PK\x03\x04")):
+		return FormatZip, nil
+	default:
+		return "", errs.InvalidPackage("Package must be a tar.gz or zip archive", nil)
+	}
+}
+
+// extractor writes package entries below a root directory
+type extractor struct {
+	root      *os.Root
+	remaining int64
+	entries   int
+}
+
+func (x *extractor) untar(archive string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return errs.NewInternalError("failed to read package", map[string]any{"error": err.Error()})
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return errs.InvalidPackage("Package is not a valid tar.gz archive", map[string]any{"error": err.Error()})
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errs.InvalidPackage("Package is not a valid tar.gz archive", map[string]any{"error": err.Error()})
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue // Directories are created as needed; links and devices are ignored
+		}
+		if err := x.extract(hdr.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func (x *extractor) unzip(archive string) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return errs.InvalidPackage("Package is not a valid zip archive", map[string]any{"error": err.Error()})
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return errs.InvalidPackage("Package is not a valid zip archive", map[string]any{"file": zf.Name, "error": err.Error()})
+		}
+		err = x.extract(zf.Name, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extract writes one file, rejecting paths that leave the package and enforcing the size budget
+func (x *extractor) extract(name string, r io.Reader) error {
+	name = strings.TrimPrefix(path.Clean(strings.ReplaceAll(name, "\\", "/")), "./")
+	if !fs.ValidPath(name) || name == "." {
+		return errs.InvalidPackage("Package contains an invalid path", map[string]any{"path": name})
+	}
+	x.entries++
+	if x.entries > maxEntries {
+		return errs.InvalidPackage("Package contains too many files", map[string]any{"maxFiles": maxEntries})
+	}
+
+	if err := x.mkdirAll(path.Dir(name)); err != nil {
+		return errs.NewInternalError("failed to extract package", map[string]any{"error": err.Error()})
+	}
+	f, err := x.root.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return errs.InvalidPackage("Package contains a file more than once", map[string]any{"path": name})
+		}
+		return errs.NewInternalError("failed to extract package", map[string]any{"error": err.Error()})
+	}
+	defer f.Close()
+
+	n, err := io.Copy(f, io.LimitReader(r, x.remaining+1))
+	if err != nil {
+		return errs.InvalidPackage("Package cannot be extracted", map[string]any{"path": name, "error": err.Error()})
+	}
+	if n > x.remaining {
+		return errs.InvalidPackage("Package exceeds the maximum extracted size", nil)
+	}
+	x.remaining -= n
+	return nil
+}
+
+func (x *extractor) mkdirAll(dir string) error {
+	if dir == "." {
+		return nil
+	}
+	if err := x.mkdirAll(path.Dir(dir)); err != nil {
+		return err
+	}
+	if err := x.root.Mkdir(dir, 0o755); err != nil && !errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("create %s: %w", dir, err)
+	}
+	return nil
+}
+
+// projectRoot returns the directory holding the project metadata: dir, or its only visible subdirectory
+func projectRoot(dir string) string {
+	if _, err := os.Stat(filepath.Join(dir, MetadataFile)); err == nil {
+		return dir
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return dir
+	}
+	var only string
+	for _, e := range entries {
+		if isHidden(e.Name()) || e.Name() == "__MACOSX" {
+			continue
+		}
+		if !e.IsDir() || only != "" {
+			return dir
+		}
+		only = e.Name()
+	}
+	if only == "" {
+		return dir
+	}
+	return filepath.Join(dir, only)
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package reconcile
+
+import (
+	"time"
+
+	"github.com/wso2/policyhub/internal/errs"
+)
+
+// Trigger identifies what started a reconciliation
+type Trigger string
+
+const (
+	TriggerManual   Trigger = "manual"
+	TriggerSchedule Trigger = "schedule"
+)
+
+// Status represents the state of a reconciliation
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed" // The versions to reconcile could not be listed
+)
+
+// Failure records a version whose sources could not all be fetched
+type Failure struct {
+	PolicyName string
+	Version    string
+	Error      *errs.AppError
+}
+
+// RunResult summarizes a reconciliation
+type RunResult struct {
+	Trigger     Trigger
+	Status      Status
+	StartedAt   time.Time
+	FinishedAt  *time.Time
+	Checked     int // Versions reconciled
+	DocsUpdated int // Doc pages refreshed from their source
+	Drifted     int // Checked versions flagged as drifted
+	Failed      int
+	Failures    []Failure
+	Error       *errs.AppError // Set when the versions to reconcile could not be listed
+}
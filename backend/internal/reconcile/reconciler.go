@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package reconcile
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/policy"
+	"github.com/wso2/policyhub/internal/runs"
+	syncPkg "github.com/wso2/policyhub/internal/sync"
+)
+
+// Reconciler periodically fetches the sources of synced versions again, refreshing doc pages that
+// changed upstream and flagging versions whose definition or artifact no longer matches upstream.
+// Each run takes the least recently reconciled versions, so that every version is visited in turn.
+type Reconciler struct {
+	policyService  *policy.Service
+	syncService    *syncPkg.Service
+	logger         *logging.Logger
+	interval       time.Duration
+	batchSize      int
+	checkArtifacts bool
+	runs           *runs.Tracker[RunResult]
+}
+
+// NewReconciler creates a new reconciler; call Start to enable scheduled runs
+func NewReconciler(policyService *policy.Service, syncService *syncPkg.Service, cfg *config.ReconcileConfig, logger *logging.Logger) *Reconciler {
+	return &Reconciler{
+		policyService:  policyService,
+		syncService:    syncService,
+		logger:         logger,
+		interval:       time.Duration(cfg.IntervalMinutes) * time.Minute,
+		batchSize:      cfg.BatchSize,
+		checkArtifacts: cfg.CheckArtifacts,
+		runs:           runs.NewTracker(copyResult, func(r *RunResult, at time.Time) { r.FinishedAt = &at }),
+	}
+}
+
+// Start launches the reconciliation schedule, if an interval is configured
+func (r *Reconciler) Start() {
+	if r.interval <= 0 {
+		return
+	}
+
+	r.runs.Schedule(r.interval, func() *RunResult { return newResult(TriggerSchedule) }, r.reconcile)
+
+	r.logger.Info("Reconciler scheduled",
+		zap.Duration("interval", r.interval),
+		zap.Int("batchSize", r.batchSize))
+}
+
+// Stop aborts a running reconciliation and waits for it to exit
+func (r *Reconciler) Stop(ctx context.Context) {
+	if !r.runs.Stop(ctx) {
+		r.logger.Warn("Reconciler did not stop before shutdown deadline")
+	}
+}
+
+// Trigger starts a reconciliation in the background and returns its initial state
+func (r *Reconciler) Trigger() (*RunResult, error) {
+	result := newResult(TriggerManual)
+	if !r.runs.Begin(result) {
+		return nil, errs.ReconcileInProgress()
+	}
+
+	r.runs.Go(func() { r.reconcile(result) })
+
+	return r.runs.Latest(), nil
+}
+
+// LastResult returns the state of the running reconciliation, or of the last finished one
+func (r *Reconciler) LastResult() (*RunResult, error) {
+	result := r.runs.Latest()
+	if result == nil {
+		return nil, errs.ReconcileNotFound()
+	}
+	return result, nil
+}
+
+// newResult returns the initial result of a run started by trigger
+func newResult(trigger Trigger) *RunResult {
+	return &RunResult{
+		Trigger:   trigger,
+		Status:    StatusRunning,
+		StartedAt: time.Now(),
+	}
+}
+
+// reconcile reconciles the versions that are due, least recently reconciled first
+func (r *Reconciler) reconcile(result *RunResult) {
+	ctx := r.runs.Context()
+	r.logger.Info("Reconciliation started", zap.String("trigger", string(result.Trigger)))
+
+	sources, err := r.policyService.ListVersionSourcesDue(ctx, result.StartedAt, r.batchSize)
+	if err != nil {
+		r.runs.Finish(func() {
+			result.Status = StatusFailed
+			result.Error = errs.AsAppError(err)
+		})
+		r.logger.Error("Failed to list versions to reconcile", zap.Error(err))
+		return
+	}
+
+	for _, source := range sources {
+		if ctx.Err() != nil {
+			r.runs.Finish(func() {
+				result.Status = StatusFailed
+				result.Error = errs.NewInternalError("reconciliation aborted", map[string]any{"error": ctx.Err().Error()})
+			})
+			return
+		}
+		r.reconcileVersion(ctx, result, source)
+	}
+
+	r.runs.Finish(func() { result.Status = StatusCompleted })
+	r.logger.Info("Reconciliation completed",
+		zap.Int("checked", result.Checked),
+		zap.Int("docsUpdated", result.DocsUpdated),
+		zap.Int("drifted", result.Drifted),
+		zap.Int("failed", result.Failed),
+		zap.Duration("duration", time.Since(result.StartedAt)))
+}
+
+// reconcileVersion reconciles one version and records the outcome
+func (r *Reconciler) reconcileVersion(ctx context.Context, result *RunResult, source *policy.VersionSource) {
+	reconciled, err := r.syncService.Reconcile(ctx, source, r.checkArtifacts)
+
+	r.runs.Update(func() {
+		result.Checked++
+		if reconciled != nil {
+			result.DocsUpdated += len(reconciled.UpdatedDocs)
+			if len(reconciled.Drift) > 0 {
+				result.Drifted++
+			}
+		}
+	})
+	if err == nil {
+		return
+	}
+
+	failure := Failure{Error: errs.AsAppError(err)}
+	if reconciled != nil {
+		failure.PolicyName, failure.Version = reconciled.PolicyName, reconciled.Version
+	}
+	r.runs.Update(func() {
+		result.Failed++
+		result.Failures = append(result.Failures, failure)
+	})
+	r.logger.Warn("Failed to reconcile policy version",
+		zap.String("policy", failure.PolicyName),
+		zap.String("version", failure.Version),
+		zap.String("error", failure.Error.Message))
+}
+
+// copyResult copies a reconciliation result with its failures
+func copyResult(r *RunResult) *RunResult {
+	result := *r
+	result.Failures = append([]Failure(nil), r.Failures...)
+	return &result
+}
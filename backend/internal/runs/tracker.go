@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package runs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Tracker runs a background operation at most once at a time, on a schedule or on demand, and
+// keeps the result of the latest run. Results are only changed through Update and Finish, and only
+// read through copies, so that a running operation can be reported while it progresses.
+type Tracker[R any] struct {
+	clone    func(*R) *R         // Copies a result, including its slices
+	finished func(*R, time.Time) // Records the time a run finished
+
+	mu      sync.Mutex
+	current *R // Latest run, running or finished
+	running bool
+
+	ctx    context.Context // Cancelled on Stop to abort a running operation
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewTracker creates a tracker for results copied with clone, whose finish time is set with finished
+func NewTracker[R any](clone func(*R) *R, finished func(*R, time.Time)) *Tracker[R] {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Tracker[R]{
+		clone:    clone,
+		finished: finished,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Context returns the context of runs; it is cancelled on Stop
+func (t *Tracker[R]) Context() context.Context {
+	return t.ctx
+}
+
+// Begin marks a run as started with its initial result; it reports false when another run is in
+// progress
+func (t *Tracker[R]) Begin(result *R) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.running {
+		return false
+	}
+	t.running = true
+	t.current = result
+	return true
+}
+
+// Go runs fn in the background; Stop waits for it
+func (t *Tracker[R]) Go(fn func()) {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		fn()
+	}()
+}
+
+// Schedule begins a run every interval with the result returned by start and performs it with run.
+// A run still going from the previous tick or a manual trigger is left alone.
+func (t *Tracker[R]) Schedule(interval time.Duration, start func() *R, run func(*R)) {
+	t.Go(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-t.ctx.Done():
+				return
+			case <-ticker.C:
+				if result := start(); t.Begin(result) {
+					run(result)
+				}
+			}
+		}
+	})
+}
+
+// Update applies a change to the running result
+func (t *Tracker[R]) Update(fn func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fn()
+}
+
+// Finish applies the final change to the running result, records its finish time and releases the run
+func (t *Tracker[R]) Finish(fn func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fn()
+	t.finished(t.current, time.Now())
+	t.running = false
+}
+
+// Latest returns a copy of the result of the running operation, or of the last finished one; nil
+// before the first run
+func (t *Tracker[R]) Latest() *R {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current == nil {
+		return nil
+	}
+	return t.clone(t.current)
+}
+
+// Stop aborts a running operation and the schedule and waits for them to exit; it reports false
+// when they did not exit before ctx was done
+func (t *Tracker[R]) Stop(ctx context.Context) bool {
+	t.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package stats
+
+import "time"
+
+// EventType identifies what kind of usage was recorded
+type EventType string
+
+const (
+	EventResolve  EventType = "resolve"
+	EventDownload EventType = "download"
+)
+
+// Ranking metrics for top-N queries
+const (
+	MetricTotal     = "total"
+	MetricResolves  = "resolves"
+	MetricDownloads = "downloads"
+)
+
+// Query window and limit constants
+const (
+	DefaultWindowDays = 30
+	MaxWindowDays     = 365
+	DefaultTopLimit   = 10
+	MaxTopLimit       = 100
+)
+
+// UsageKey identifies a daily usage counter
+type UsageKey struct {
+	Day        time.Time
+	PolicyName string
+	Version    string
+	EventType  EventType
+}
+
+// UsageCounts holds resolve and download counts
+type UsageCounts struct {
+	Resolves  int64
+	Downloads int64
+}
+
+// Total returns the combined number of resolves and downloads
+func (c UsageCounts) Total() int64 {
+	return c.Resolves + c.Downloads
+}
+
+// DailyUsage holds the counts for a single day
+type DailyUsage struct {
+	Day time.Time
+	UsageCounts
+}
+
+// VersionUsage holds the counts for a single policy version
+type VersionUsage struct {
+	Version string
+	UsageCounts
+}
+
+// PolicyUsage holds the counts for a single policy
+type PolicyUsage struct {
+	PolicyName string
+	UsageCounts
+}
+
+// UsageSummary holds totals and a daily trend over a time window
+type UsageSummary struct {
+	PolicyName string
+	Since      time.Time
+	Days       int
+	Totals     UsageCounts
+	Trend      []DailyUsage
+	Versions   []VersionUsage
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package stats
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/logging"
+)
+
+// Recorder buffers usage events and writes them as aggregated daily counters,
+// keeping database writes off the request path
+type Recorder struct {
+	repo          Repository
+	logger        *logging.Logger
+	enabled       bool
+	batchSize     int
+	flushInterval time.Duration
+	events        chan UsageKey
+	done          chan struct{}
+}
+
+// NewRecorder creates a new usage recorder; call Start to begin flushing
+func NewRecorder(repo Repository, cfg *config.StatsConfig, logger *logging.Logger) *Recorder {
+	return &Recorder{
+		repo:          repo,
+		logger:        logger,
+		enabled:       cfg.Enabled,
+		batchSize:     cfg.BatchSize,
+		flushInterval: time.Duration(cfg.FlushIntervalSeconds) * time.Second,
+		events:        make(chan UsageKey, cfg.BufferSize),
+		done:          make(chan struct{}),
+	}
+}
+
+// Start launches the background flush loop
+func (r *Recorder) Start() {
+	if !r.enabled {
+		close(r.done)
+		return
+	}
+	go r.run()
+}
+
+// Record queues a usage event without blocking; events are dropped when the buffer is full
+func (r *Recorder) Record(policyName, version string, eventType EventType) {
+	if !r.enabled {
+		return
+	}
+
+	key := UsageKey{
+		Day:        time.Now().UTC().Truncate(24 * time.Hour),
+		PolicyName: policyName,
+		Version:    version,
+		EventType:  eventType,
+	}
+
+	select {
+	case r.events <- key:
+	default:
+		r.logger.Debug("Usage event dropped, buffer full",
+			zap.String("policy", policyName),
+			zap.String("version", version),
+			zap.String("event_type", string(eventType)))
+	}
+}
+
+// Close stops accepting events and flushes everything still buffered
+func (r *Recorder) Close(ctx context.Context) {
+	if r.enabled {
+		close(r.events)
+	}
+
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		r.logger.Warn("Usage recorder did not flush before shutdown deadline")
+	}
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	pending := make(map[UsageKey]int64)
+	for {
+		select {
+		case key, ok := <-r.events:
+			if !ok {
+				r.flush(pending)
+				return
+			}
+			pending[key]++
+			if len(pending) >= r.batchSize {
+				r.flush(pending)
+				pending = make(map[UsageKey]int64)
+			}
+		case <-ticker.C:
+			if len(pending) > 0 {
+				r.flush(pending)
+				pending = make(map[UsageKey]int64)
+			}
+		}
+	}
+}
+
+func (r *Recorder) flush(counts map[UsageKey]int64) {
+	if len(counts) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.flushInterval)
+	defer cancel()
+
+	if err := r.repo.IncrementUsageCounts(ctx, counts); err != nil {
+		r.logger.Error("Failed to flush usage counts",
+			zap.Int("counters", len(counts)),
+			zap.Error(err))
+		return
+	}
+
+	r.logger.Debug("Usage counts flushed", zap.Int("counters", len(counts)))
+}
@@ -0,0 +1,26 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package stats
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for usage statistics data access
+type Repository interface {
+	IncrementUsageCounts(ctx context.Context, counts map[UsageKey]int64) error
+
+	// An empty policyName aggregates over the whole catalog
+	GetUsageTotals(ctx context.Context, since time.Time, policyName string) (UsageCounts, error)
+	GetUsageTrend(ctx context.Context, since time.Time, policyName string) ([]DailyUsage, error)
+	GetUsageByVersion(ctx context.Context, since time.Time, policyName string) ([]VersionUsage, error)
+	GetTopPolicies(ctx context.Context, since time.Time, metric string, limit int) ([]PolicyUsage, error)
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package stats
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/wso2/policyhub/internal/db"
+	"github.com/wso2/policyhub/internal/db/sqlc"
+	"github.com/wso2/policyhub/internal/errs"
+)
+
+// SQLCRepository implements Repository using sqlc-generated code
+type SQLCRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewSQLCRepository creates a new SQLC-based statistics repository
+func NewSQLCRepository(database *db.DB) Repository {
+	return &SQLCRepository{
+		queries: sqlc.New(database.Pool),
+	}
+}
+
+// Helper to convert a day to pgtype.Date
+func toPgtypeDate(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: t, Valid: true}
+}
+
+func (r *SQLCRepository) IncrementUsageCounts(ctx context.Context, counts map[UsageKey]int64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	days := make([]pgtype.Date, 0, len(counts))
+	policyNames := make([]string, 0, len(counts))
+	versions := make([]string, 0, len(counts))
+	eventTypes := make([]string, 0, len(counts))
+	values := make([]int64, 0, len(counts))
+	for key, count := range counts {
+		days = append(days, toPgtypeDate(key.Day))
+		policyNames = append(policyNames, key.PolicyName)
+		versions = append(versions, key.Version)
+		eventTypes = append(eventTypes, string(key.EventType))
+		values = append(values, count)
+	}
+
+	err := r.queries.IncrementUsageCounts(ctx, sqlc.IncrementUsageCountsParams{
+		Column1: days,
+		Column2: policyNames,
+		Column3: versions,
+		Column4: eventTypes,
+		Column5: values,
+	})
+	if err != nil {
+		return errs.NewDatabaseError("failed to increment usage counts", map[string]any{"error": err.Error()})
+	}
+
+	return nil
+}
+
+func (r *SQLCRepository) GetUsageTotals(ctx context.Context, since time.Time, policyName string) (UsageCounts, error) {
+	row, err := r.queries.GetUsageTotals(ctx, sqlc.GetUsageTotalsParams{
+		Column1: toPgtypeDate(since),
+		Column2: policyName,
+	})
+	if err != nil {
+		return UsageCounts{}, errs.NewDatabaseError("failed to get usage totals", map[string]any{"error": err.Error()})
+	}
+
+	return UsageCounts{Resolves: row.Resolves, Downloads: row.Downloads}, nil
+}
+
+func (r *SQLCRepository) GetUsageTrend(ctx context.Context, since time.Time, policyName string) ([]DailyUsage, error) {
+	rows, err := r.queries.GetUsageTrend(ctx, sqlc.GetUsageTrendParams{
+		Column1: toPgtypeDate(since),
+		Column2: policyName,
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to get usage trend", map[string]any{"error": err.Error()})
+	}
+
+	trend := make([]DailyUsage, 0, len(rows))
+	for _, row := range rows {
+		trend = append(trend, DailyUsage{
+			Day:         row.Day.Time,
+			UsageCounts: UsageCounts{Resolves: row.Resolves, Downloads: row.Downloads},
+		})
+	}
+
+	return trend, nil
+}
+
+func (r *SQLCRepository) GetUsageByVersion(ctx context.Context, since time.Time, policyName string) ([]VersionUsage, error) {
+	rows, err := r.queries.GetUsageByVersion(ctx, sqlc.GetUsageByVersionParams{
+		Column1: toPgtypeDate(since),
+		Column2: policyName,
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to get usage by version", map[string]any{"error": err.Error()})
+	}
+
+	versions := make([]VersionUsage, 0, len(rows))
+	for _, row := range rows {
+		versions = append(versions, VersionUsage{
+			Version:     row.Version,
+			UsageCounts: UsageCounts{Resolves: row.Resolves, Downloads: row.Downloads},
+		})
+	}
+
+	return versions, nil
+}
+
+func (r *SQLCRepository) GetTopPolicies(ctx context.Context, since time.Time, metric string, limit int) ([]PolicyUsage, error) {
+	rows, err := r.queries.GetTopPolicies(ctx, sqlc.GetTopPoliciesParams{
+		Column1: toPgtypeDate(since),
+		Column2: metric,
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		return nil, errs.NewDatabaseError("failed to get top policies", map[string]any{"error": err.Error()})
+	}
+
+	policies := make([]PolicyUsage, 0, len(rows))
+	for _, row := range rows {
+		policies = append(policies, PolicyUsage{
+			PolicyName:  row.PolicyName,
+			UsageCounts: UsageCounts{Resolves: row.Resolves, Downloads: row.Downloads},
+		})
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/logging"
+)
+
+// Service handles usage statistics business logic
+type Service struct {
+	repo   Repository
+	logger *logging.Logger
+}
+
+// NewService creates a new statistics service
+func NewService(repo Repository, logger *logging.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// GetSummary returns catalog-wide totals and the daily trend over the last days
+func (s *Service) GetSummary(ctx context.Context, days int) (*UsageSummary, error) {
+	since, err := windowStart(days)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.repo.GetUsageTotals(ctx, since, "")
+	if err != nil {
+		return nil, err
+	}
+
+	trend, err := s.repo.GetUsageTrend(ctx, since, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &UsageSummary{
+		Since:  since,
+		Days:   days,
+		Totals: totals,
+		Trend:  trend,
+	}, nil
+}
+
+// GetPolicyStats returns totals, the daily trend and per-version counts for a policy
+func (s *Service) GetPolicyStats(ctx context.Context, policyName string, days int) (*UsageSummary, error) {
+	since, err := windowStart(days)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.repo.GetUsageTotals(ctx, since, policyName)
+	if err != nil {
+		return nil, err
+	}
+
+	trend, err := s.repo.GetUsageTrend(ctx, since, policyName)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.repo.GetUsageByVersion(ctx, since, policyName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UsageSummary{
+		PolicyName: policyName,
+		Since:      since,
+		Days:       days,
+		Totals:     totals,
+		Trend:      trend,
+		Versions:   versions,
+	}, nil
+}
+
+// GetTopPolicies returns the most used policies ranked by the given metric
+func (s *Service) GetTopPolicies(ctx context.Context, metric string, days, limit int) ([]PolicyUsage, error) {
+	if metric != MetricTotal && metric != MetricResolves && metric != MetricDownloads {
+		return nil, errs.NewValidationError("invalid metric", map[string]any{
+			"allowed_values": []string{MetricTotal, MetricResolves, MetricDownloads},
+			"provided":       metric,
+		})
+	}
+	if limit < 1 || limit > MaxTopLimit {
+		return nil, errs.NewValidationError(fmt.Sprintf("limit must be between 1 and %d", MaxTopLimit), map[string]any{"limit": limit})
+	}
+
+	since, err := windowStart(days)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTopPolicies(ctx, since, metric, limit)
+}
+
+// WindowStart returns the first UTC day included in a window of the given number of days
+func WindowStart(days int) time.Time {
+	return time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+}
+
+// windowStart validates the window length and returns its first day
+func windowStart(days int) (time.Time, error) {
+	if days < 1 || days > MaxWindowDays {
+		return time.Time{}, errs.NewValidationError(fmt.Sprintf("days must be between 1 and %d", MaxWindowDays), map[string]any{"days": days})
+	}
+	return WindowStart(days), nil
+}
@@ -0,0 +1,248 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+package sync
+
+import (
+	"context"
+	"fmt"
+	stdsync "sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/policy"
+)
+
+// SyncBatch synchronizes many policy versions in one call. Every request is validated before
+// anything is fetched. Items are fetched with bounded concurrency. Versions that already exist
+// follow the re-publishing rules of SyncPolicy: identical content is unchanged and different
+// content is a conflict. In atomic mode nothing is written unless every remaining item can be
+// created, and all versions and docs are then stored in a single transaction.
+func (s *Service) SyncBatch(ctx context.Context, reqs []*SyncRequest, atomic bool) (*BatchResult, error) {
+	startTime := time.Now()
+
+	if err := validateBatch(reqs); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Batch policy synchronization started",
+		zap.Int("items", len(reqs)),
+		zap.Bool("atomic", atomic),
+		zap.Int("concurrency", s.batchConcurrency))
+
+	results := make([]BatchItemResult, len(reqs))
+	prepared := make([]*policy.VersionWithDocs, len(reqs))
+
+	forEachConcurrently(len(reqs), s.batchConcurrency, func(i int) {
+		req := reqs[i]
+		results[i] = BatchItemResult{Index: i, PolicyName: req.PolicyName, Version: req.Version}
+
+		if atomic {
+			// Only fetch here; writing happens once every item is known to be good
+			item, err := s.prepareVersion(ctx, req)
+			if err != nil {
+				results[i].Outcome, results[i].Error = BatchOutcomeFailed, errs.AsAppError(err)
+				return
+			}
+			if item == nil {
+				results[i].Outcome = BatchOutcomeUnchanged
+				return
+			}
+			prepared[i] = item
+			return
+		}
+
+		synced, err := s.SyncPolicy(ctx, req)
+		if err != nil {
+			results[i].Outcome, results[i].Error = BatchOutcomeFailed, errs.AsAppError(err)
+			return
+		}
+		if synced.Status == StatusUnchanged {
+			results[i].Outcome = BatchOutcomeUnchanged
+			return
+		}
+		results[i].Outcome = BatchOutcomeCreated
+	})
+
+	if atomic {
+		s.commitAtomicBatch(ctx, results, prepared)
+	}
+
+	batch := &BatchResult{Atomic: atomic, Items: results}
+	for _, r := range results {
+		switch r.Outcome {
+		case BatchOutcomeCreated:
+			batch.Created++
+		case BatchOutcomeUnchanged:
+			batch.Unchanged++
+		case BatchOutcomeFailed:
+			batch.Failed++
+		}
+	}
+
+	s.logger.Info("Batch policy synchronization completed",
+		zap.Int("created", batch.Created),
+		zap.Int("unchanged", batch.Unchanged),
+		zap.Int("failed", batch.Failed),
+		zap.Bool("atomic", atomic),
+		zap.Duration("duration", time.Since(startTime)))
+
+	return batch, nil
+}
+
+// commitAtomicBatch writes all prepared items in one transaction, or fails all of them
+func (s *Service) commitAtomicBatch(ctx context.Context, results []BatchItemResult, prepared []*policy.VersionWithDocs) {
+	var pending []int
+	failedIndex := -1
+	for i, r := range results {
+		switch {
+		case r.Outcome == BatchOutcomeFailed && failedIndex < 0:
+			failedIndex = i
+		case prepared[i] != nil:
+			pending = append(pending, i)
+		}
+	}
+
+	if failedIndex >= 0 {
+		abortPending(results, pending, failedIndex)
+		return
+	}
+
+	if len(pending) == 0 {
+		return
+	}
+
+	items := make([]*policy.VersionWithDocs, 0, len(pending))
+	for _, i := range pending {
+		items = append(items, prepared[i])
+	}
+
+	if _, err := s.policyService.CreatePolicyVersionsWithDocs(ctx, items); err != nil {
+		appErr := errs.AsAppError(err)
+		// A conflict names the item that could not be written; the others were only rolled back
+		if idx, ok := appErr.Details["index"].(int); ok && idx >= 0 && idx < len(pending) {
+			failedIndex = pending[idx]
+			appErr.Details["index"] = failedIndex
+			results[failedIndex].Outcome, results[failedIndex].Error = BatchOutcomeFailed, appErr
+			abortPending(results, pending, failedIndex)
+			return
+		}
+		for _, i := range pending {
+			results[i].Outcome, results[i].Error = BatchOutcomeFailed, appErr
+		}
+		return
+	}
+
+	for _, i := range pending {
+		results[i].Outcome = BatchOutcomeCreated
+	}
+}
+
+// abortPending fails the pending items of an atomic batch that was aborted by the item at failedIndex
+func abortPending(results []BatchItemResult, pending []int, failedIndex int) {
+	abortErr := errs.BatchAborted(failedIndex)
+	for _, i := range pending {
+		if i != failedIndex {
+			results[i].Outcome, results[i].Error = BatchOutcomeFailed, abortErr
+		}
+	}
+}
+
+// prepareVersion fetches the definition and docs of a request without writing anything. A version
+// that is already stored with identical content needs no write and yields nil; one stored with
+// different content is a conflict.
+func (s *Service) prepareVersion(ctx context.Context, req *SyncRequest) (*policy.VersionWithDocs, error) {
+	definition, docs, _, err := s.fetchContent(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &policy.VersionWithDocs{
+		Version: newPolicyVersion(req.PolicyName, req.Version, req.Metadata, definition, req),
+		Docs:    docs,
+		Source:  versionSource(req),
+	}
+
+	exists, err := s.policyService.PolicyVersionExists(ctx, req.PolicyName, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		if err := s.checkRepublish(ctx, item.Version); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	return item, nil
+}
+
+// forEachConcurrently calls fn for every index with at most limit calls in flight
+func forEachConcurrently(n, limit int, fn func(i int)) {
+	sem := make(chan struct{}, limit)
+	var wg stdsync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// validateBatch validates every request up front and rejects duplicate versions
+func validateBatch(reqs []*SyncRequest) error {
+	if len(reqs) == 0 {
+		return errs.NewValidationError("batch must contain at least one item", nil)
+	}
+	if len(reqs) > policy.MaxBatchSize {
+		return errs.NewValidationError(
+			fmt.Sprintf("too many policies in batch (max %d)", policy.MaxBatchSize),
+			map[string]any{
+				"maxBatchSize": policy.MaxBatchSize,
+				"provided":     len(reqs),
+			},
+		)
+	}
+
+	var invalid []map[string]any
+	seen := make(map[string]int, len(reqs))
+	for i, req := range reqs {
+		if err := req.Validate(); err != nil {
+			invalid = append(invalid, map[string]any{
+				"index":      i,
+				"policyName": req.PolicyName,
+				"version":    req.Version,
+				"error":      err.Message,
+			})
+			continue
+		}
+
+		key := req.PolicyName + "@" + req.Version
+		if first, ok := seen[key]; ok {
+			invalid = append(invalid, map[string]any{
+				"index":      i,
+				"policyName": req.PolicyName,
+				"version":    req.Version,
+				"error":      fmt.Sprintf("duplicate of item %d", first),
+			})
+			continue
+		}
+		seen[key] = i
+	}
+
+	if len(invalid) > 0 {
+		return errs.NewValidationError("invalid batch items", map[string]any{"items": invalid})
+	}
+	return nil
+}
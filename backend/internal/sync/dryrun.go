@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package sync
+
+import (
+	"context"
+
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/policy"
+	"go.uber.org/zap"
+)
+
+// DryRun runs every check of SyncPolicy without writing anything: it validates the request, fetches
+// the definition and docs, and compares the version with the stored one when it is already published.
+// The result reports the outcome the sync would have and whether the version would become the latest.
+// Checks that fail return the same errors SyncPolicy would.
+func (s *Service) DryRun(ctx context.Context, req *SyncRequest) (*DryRunResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	definition, _, docResults, err := s.fetchContent(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DryRunResult{
+		SyncResult: SyncResult{
+			PolicyName: req.PolicyName,
+			Version:    req.Version,
+			Status:     StatusSynced,
+			Docs:       docResults,
+		},
+		DryRun:           true,
+		DefinitionSHA256: policy.DefinitionHash(definition),
+	}
+
+	candidate := newPolicyVersion(req.PolicyName, req.Version, req.Metadata, definition, req)
+	stored, diffs, err := s.policyService.CompareWithStored(ctx, candidate)
+	if err != nil {
+		return nil, err
+	}
+	if stored {
+		if len(diffs) > 0 {
+			return nil, errs.PolicyVersionConflict(req.PolicyName, req.Version, diffs)
+		}
+		result.Status = StatusUnchanged
+	}
+
+	isLatest, currentLatest, err := s.policyService.WouldBeLatest(ctx, req.PolicyName, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	// An unchanged version keeps its flag: it is the latest exactly when it is the current latest
+	result.IsLatest = isLatest || (stored && currentLatest == req.Version)
+	result.CurrentLatest = currentLatest
+
+	s.logger.Info("Policy sync dry run completed",
+		zap.String("policy", req.PolicyName),
+		zap.String("version", req.Version),
+		zap.String("status", result.Status),
+		zap.Bool("is_latest", result.IsLatest))
+
+	return result, nil
+}
@@ -9,24 +9,124 @@
 
 package sync
 
-import "github.com/wso2/policyhub/internal/policy"
+import (
+	"io"
+
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/policy"
+)
 
 // SyncRequest represents a policy sync request
 type SyncRequest struct {
-	PolicyName    string
-	Version       string
-	SourceType    string
+	PolicyName    string                 `json:"policyName"`
+	Version       string                 `json:"version"`
+	SourceType    string                 `json:"sourceType"`
+	DownloadURL   string                 `json:"downloadUrl"`
+	DefinitionURL string                 `json:"definitionUrl"`
+	Metadata      *policy.PolicyMetadata `json:"metadata"`
+	Documentation map[string]string      `json:"documentation,omitempty"`
+	AssetsBaseURL string                 `json:"assetsBaseUrl,omitempty"`
+	Checksum      *policy.Checksum       `json:"checksum,omitempty"`
+
+	// DefinitionYAML and InlineDocs carry content read from a local source,
+	// in place of DefinitionURL and Documentation
+	DefinitionYAML string            `json:"definitionYaml,omitempty"`
+	InlineDocs     map[string]string `json:"inlineDocs,omitempty"`
+
+	// RequiredDocs lists doc pages that fail the sync when their URL is not found
+	RequiredDocs []string `json:"requiredDocs,omitempty"`
+}
+
+// SourceTypePackage is recorded for versions published from an uploaded or downloaded package
+const SourceTypePackage = "package"
+
+// PackageRequest publishes a policy from a package archive, either uploaded as File or downloaded
+// from URL. Name and version default to the ones in the package metadata.
+type PackageRequest struct {
+	PolicyName string
+	Version    string
+	SourceType string
+	URL        string
+	File       io.Reader
+}
+
+// PackageResult describes a version published from a package
+type PackageResult struct {
+	SyncResult
 	DownloadURL   string
-	DefinitionURL string
-	Metadata      *policy.PolicyMetadata
-	Documentation map[string]string
 	AssetsBaseURL string
-	Checksum      *policy.Checksum
+	Checksum      string
+	Size          int64
+	Docs          []string
+	Assets        int
 }
 
 // SyncResult represents the result of a sync operation
 type SyncResult struct {
+	PolicyName string      `json:"policyName"`
+	Version    string      `json:"version"`
+	Status     string      `json:"status"`
+	Docs       []DocResult `json:"docs,omitempty"`
+}
+
+// Sync result statuses
+const (
+	StatusSynced    = "synced"
+	StatusUnchanged = "unchanged" // The version was already stored with identical content
+)
+
+// DryRunResult reports what a sync would do without writing anything. Status is the outcome the
+// sync would have.
+type DryRunResult struct {
+	SyncResult
+	DryRun bool `json:"dryRun"`
+	// IsLatest reports whether the version would become the latest version of its policy
+	IsLatest         bool   `json:"isLatest"`
+	CurrentLatest    string `json:"currentLatest,omitempty"`
+	DefinitionSHA256 string `json:"definitionSha256"`
+}
+
+// DocStatus is the outcome of fetching one doc page
+type DocStatus string
+
+const (
+	DocStatusFetched DocStatus = "fetched" // Fetched, or given inline
+	DocStatusMissing DocStatus = "missing" // The URL answered 404 or 410
+	DocStatusFailed  DocStatus = "failed"
+)
+
+// DocResult reports how one doc page of a sync request was fetched
+type DocResult struct {
+	Page     string    `json:"page"`
+	Status   DocStatus `json:"status"`
+	URL      string    `json:"url,omitempty"` // Empty for inline pages
+	Required bool      `json:"required,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// BatchOutcome represents the outcome of a single batch item
+type BatchOutcome string
+
+const (
+	BatchOutcomeCreated   BatchOutcome = "created"
+	BatchOutcomeUnchanged BatchOutcome = "unchanged" // Version was already stored with identical content
+	BatchOutcomeFailed    BatchOutcome = "failed"
+)
+
+// BatchItemResult represents the outcome of one request in a batch sync
+type BatchItemResult struct {
+	Index      int
 	PolicyName string
 	Version    string
-	Status     string
+	Outcome    BatchOutcome
+	Error      *errs.AppError
+}
+
+// BatchResult represents the outcome of a batch sync
+type BatchResult struct {
+	Atomic    bool
+	Items     []BatchItemResult
+	Created   int
+	Unchanged int
+	Failed    int
 }
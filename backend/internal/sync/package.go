@@ -0,0 +1,275 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package sync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/artifacts"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/fetch"
+	"github.com/wso2/policyhub/internal/policy"
+	"github.com/wso2/policyhub/internal/project"
+	"github.com/wso2/policyhub/internal/validation"
+)
+
+// assetsDir is the directory next to a stored package that its images are served from
+const assetsDir = "files"
+
+// PublishPackage publishes a policy version from a package archive. The package is received into the
+// artifact store's staging area, checksummed and extracted; its metadata, definition, docs and images
+// become the catalog entry. The stored package and images are moved into place in one step before the
+// version and its docs are written in a single transaction, and removed again if that fails.
+func (s *Service) PublishPackage(ctx context.Context, req *PackageRequest) (*PackageResult, error) {
+	startTime := time.Now()
+
+	if !s.store.Addressable() {
+		return nil, errs.ArtifactStoreNotConfigured()
+	}
+	if (req.File == nil) == (req.URL == "") {
+		return nil, errs.NewValidationError("exactly one of package file or package URL is required", nil)
+	}
+	if req.URL != "" {
+		if err := validation.ValidateSourceURL(req.URL); err != nil {
+			return nil, errs.NewValidationError("invalid package URL", map[string]any{"error": err.Message})
+		}
+	}
+
+	stagingDir, err := s.store.TempDir()
+	if err != nil {
+		return nil, errs.NewInternalError("failed to stage package", map[string]any{"error": err.Error()})
+	}
+	defer os.RemoveAll(stagingDir)
+
+	archive := filepath.Join(stagingDir, "package")
+	digest, size, err := s.receivePackage(ctx, req, archive)
+	if err != nil {
+		return nil, err
+	}
+
+	extractDir := filepath.Join(stagingDir, "src")
+	if err := os.Mkdir(extractDir, 0o755); err != nil {
+		return nil, errs.NewInternalError("failed to stage package", map[string]any{"error": err.Error()})
+	}
+	format, root, err := project.Unpack(archive, extractDir, s.maxExtractedSize)
+	if err != nil {
+		return nil, err
+	}
+	proj, err := project.Read(os.DirFS(root), "")
+	if err != nil {
+		return nil, err
+	}
+
+	sourceType := req.SourceType
+	if sourceType == "" {
+		sourceType = SourceTypePackage
+	}
+	syncReq := &SyncRequest{
+		PolicyName:     valueOr(req.PolicyName, proj.Metadata.Name),
+		Version:        valueOr(req.Version, proj.Metadata.Version),
+		SourceType:     sourceType,
+		Metadata:       &proj.Metadata.PolicyMetadata,
+		Checksum:       &policy.Checksum{Algorithm: "sha256", Value: digest},
+		DefinitionYAML: string(proj.Definition),
+		InlineDocs:     proj.Docs,
+	}
+	packageName := syncReq.PolicyName + "-" + syncReq.Version + "." + format
+	syncReq.DownloadURL = s.store.URL(syncReq.PolicyName, syncReq.Version, packageName)
+	if len(proj.Assets) > 0 {
+		syncReq.AssetsBaseURL = s.store.URL(syncReq.PolicyName, syncReq.Version, assetsDir)
+		syncReq.Metadata.LogoURL = assetURL(syncReq.Metadata.LogoURL, syncReq.AssetsBaseURL, proj.Assets)
+		syncReq.Metadata.BannerURL = assetURL(syncReq.Metadata.BannerURL, syncReq.AssetsBaseURL, proj.Assets)
+	}
+
+	if err := syncReq.Validate(); err != nil {
+		return nil, err
+	}
+	docs, _, err := s.fetchDocs(ctx, syncReq)
+	if err != nil {
+		return nil, err
+	}
+	result := &PackageResult{
+		SyncResult: SyncResult{
+			PolicyName: syncReq.PolicyName,
+			Version:    syncReq.Version,
+			Status:     StatusSynced,
+		},
+		DownloadURL:   syncReq.DownloadURL,
+		AssetsBaseURL: syncReq.AssetsBaseURL,
+		Checksum:      digest,
+		Size:          size,
+		Docs:          proj.Pages(),
+		Assets:        len(proj.Assets),
+	}
+
+	// Re-publishing an identical package succeeds without storing anything
+	candidate := newPolicyVersion(syncReq.PolicyName, syncReq.Version, syncReq.Metadata, syncReq.DefinitionYAML, syncReq)
+	exists, err := s.policyService.PolicyVersionExists(ctx, syncReq.PolicyName, syncReq.Version)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		if err := s.checkRepublish(ctx, candidate); err != nil {
+			return nil, err
+		}
+		result.Status = StatusUnchanged
+		return result, nil
+	}
+
+	// Lay out <policy>/<version>/ in staging: the package and, below files/, its images
+	versionDir := filepath.Join(stagingDir, "version")
+	if err := stageVersionDir(versionDir, archive, packageName, root, proj.Assets); err != nil {
+		return nil, errs.NewInternalError("failed to stage package", map[string]any{"error": err.Error()})
+	}
+	if err := s.store.Commit(versionDir, syncReq.PolicyName, syncReq.Version); err != nil {
+		if errors.Is(err, artifacts.ErrExists) {
+			return nil, errs.PolicyVersionExists(syncReq.PolicyName, syncReq.Version)
+		}
+		return nil, errs.NewInternalError("failed to store package", map[string]any{"error": err.Error()})
+	}
+
+	item := &policy.VersionWithDocs{
+		Version: candidate,
+		Docs:    docs,
+	}
+	if _, err := s.policyService.CreatePolicyVersionsWithDocs(ctx, []*policy.VersionWithDocs{item}); err != nil {
+		if rmErr := s.store.Remove(syncReq.PolicyName, syncReq.Version); rmErr != nil {
+			s.logger.Error("Failed to remove stored package after publish failure",
+				zap.String("policy", syncReq.PolicyName),
+				zap.String("version", syncReq.Version),
+				zap.Error(rmErr))
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Policy package published",
+		zap.String("policy", syncReq.PolicyName),
+		zap.String("version", syncReq.Version),
+		zap.String("format", format),
+		zap.Int64("size", size),
+		zap.Int("docs", len(proj.Docs)),
+		zap.Int("assets", len(proj.Assets)),
+		zap.Duration("duration", time.Since(startTime)))
+
+	return result, nil
+}
+
+// MaxPackageSize returns the size limit for published packages, in bytes
+func (s *Service) MaxPackageSize() int64 {
+	return s.maxPackageSize
+}
+
+// receivePackage writes the uploaded or downloaded package to a file, enforcing the size limit,
+// and returns its sha256 digest and size
+func (s *Service) receivePackage(ctx context.Context, req *PackageRequest, dst string) (string, int64, error) {
+	src := req.File
+	if req.URL != "" {
+		s.logger.Debug("Downloading policy package", zap.String("url", req.URL))
+
+		kind := fetch.Package
+		kind.MaxSize = s.maxPackageSize
+		body, err := s.packageFetchers.Open(ctx, req.SourceType, req.URL, kind)
+		if err != nil {
+			if errors.Is(err, fetch.ErrTooLarge) {
+				return "", 0, packageTooLarge(s.maxPackageSize)
+			}
+			return "", 0, fetchError(req.URL, err)
+		}
+		defer body.Close()
+		src = body
+	}
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return "", 0, errs.NewInternalError("failed to stage package", map[string]any{"error": err.Error()})
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(src, s.maxPackageSize+1))
+	if err != nil {
+		if errors.Is(err, fetch.ErrTooLarge) {
+			return "", 0, packageTooLarge(s.maxPackageSize)
+		}
+		if req.URL != "" {
+			return "", 0, fetchError(req.URL, err)
+		}
+		return "", 0, errs.InvalidPackage("Failed to read package", map[string]any{"error": err.Error()})
+	}
+	if n > s.maxPackageSize {
+		return "", 0, packageTooLarge(s.maxPackageSize)
+	}
+	if n == 0 {
+		return "", 0, errs.InvalidPackage("Package is empty", nil)
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// stageVersionDir moves the package and its asset files into a new version directory
+func stageVersionDir(versionDir, archive, packageName, root string, assets []string) error {
+	if err := os.Mkdir(versionDir, 0o755); err != nil {
+		return err
+	}
+	if err := os.Rename(archive, filepath.Join(versionDir, packageName)); err != nil {
+		return err
+	}
+	for _, asset := range assets {
+		dst := filepath.Join(versionDir, assetsDir, filepath.FromSlash(asset))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if err := os.Rename(filepath.Join(root, filepath.FromSlash(asset)), dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assetURL resolves a logo or banner path that refers to a file in the package to its stored URL;
+// absolute URLs and paths to files the package does not contain are returned unchanged
+func assetURL(ref, assetsBaseURL string, assets []string) string {
+	if ref == "" || validation.ValidateURL(ref) == nil {
+		return ref
+	}
+	clean := path.Clean(ref)
+	for _, asset := range assets {
+		if asset == clean {
+			elems := strings.Split(asset, "/")
+			for i, e := range elems {
+				elems[i] = url.PathEscape(e)
+			}
+			return assetsBaseURL + "/" + strings.Join(elems, "/")
+		}
+	}
+	return ref
+}
+
+func packageTooLarge(maxSize int64) *errs.AppError {
+	return errs.InvalidPackage("Package exceeds the maximum size", map[string]any{"maxBytes": maxSize})
+}
+
+func valueOr(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
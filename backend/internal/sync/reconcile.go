@@ -0,0 +1,207 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package sync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/fetch"
+	"github.com/wso2/policyhub/internal/policy"
+)
+
+// ReconcileResult describes the reconciliation of one version with its sources
+type ReconcileResult struct {
+	PolicyName  string
+	Version     string
+	UpdatedDocs []string           // Pages refreshed from their source
+	Drift       []policy.DriftItem // Parts whose upstream no longer matches what is stored
+}
+
+// Reconcile fetches the sources of a synced version again. Doc pages that changed upstream are
+// stored as new revisions. The definition and the artifact are never changed; when they no longer
+// match upstream, the version is flagged as drifted instead. The outcome is recorded with the
+// source, and a failed fetch is returned after the pages that could be refreshed were stored.
+func (s *Service) Reconcile(ctx context.Context, source *policy.VersionSource, checkArtifact bool) (*ReconcileResult, error) {
+	pv, err := s.policyService.GetPolicyVersionByID(ctx, source.PolicyVersionID)
+	if err != nil {
+		return nil, err
+	}
+	result := &ReconcileResult{PolicyName: pv.PolicyName, Version: pv.Version}
+
+	var firstErr error
+	drift, err := s.checkDrift(ctx, pv, source, checkArtifact)
+	if err != nil {
+		// Drift can only be judged once every part was fetched; until then the previous flag stands
+		firstErr, drift = err, source.Drift
+	}
+	result.Drift = drift
+
+	result.UpdatedDocs, err = s.refreshDocs(ctx, pv, source)
+	if err != nil && firstErr == nil {
+		firstErr = err
+	}
+
+	var lastError string
+	if firstErr != nil {
+		appErr := errs.AsAppError(firstErr)
+		lastError = appErr.Message
+		if msg, ok := appErr.Details["error"].(string); ok {
+			lastError += ": " + msg
+		}
+	}
+	if err := s.policyService.RecordReconciliation(ctx, pv.ID, drift, lastError); err != nil {
+		return nil, err
+	}
+
+	if len(result.UpdatedDocs) > 0 {
+		s.logger.Info("Doc pages refreshed from their source",
+			zap.String("policy", pv.PolicyName),
+			zap.String("version", pv.Version),
+			zap.Strings("pages", result.UpdatedDocs))
+	}
+	if len(drift) > 0 && firstErr == nil {
+		s.logger.Warn("Policy version drifted from its upstream source",
+			zap.String("policy", pv.PolicyName),
+			zap.String("version", pv.Version),
+			zap.Int("differences", len(drift)))
+	}
+
+	return result, firstErr
+}
+
+// checkDrift compares the stored definition and artifact of a version with their upstream content
+func (s *Service) checkDrift(ctx context.Context, pv *policy.PolicyVersion, source *policy.VersionSource, checkArtifact bool) ([]policy.DriftItem, error) {
+	var drift []policy.DriftItem
+
+	if source.DefinitionURL != "" {
+		stored := policy.DefinitionHash(pv.DefinitionYAML)
+		body, err := s.fetchers.Get(ctx, source.SourceType, source.DefinitionURL, fetch.Definition)
+		switch {
+		case err == nil:
+			if upstream := policy.DefinitionHash(string(body)); upstream != stored {
+				drift = append(drift, policy.DriftItem{Field: policy.DriftDefinition, Stored: stored, Upstream: upstream})
+			}
+		case isMissing(fetchError(source.DefinitionURL, err)):
+			drift = append(drift, policy.DriftItem{Field: policy.DriftDefinition, Stored: stored})
+		default:
+			return nil, fetchError(source.DefinitionURL, err)
+		}
+	}
+
+	if stored, ok := verifiableArtifactDigest(pv); ok && checkArtifact {
+		upstream, err := s.artifactSHA256(ctx, source.SourceType, *pv.DownloadURL)
+		switch {
+		case err == nil:
+			if upstream != stored {
+				drift = append(drift, policy.DriftItem{Field: policy.DriftArtifact, Stored: stored, Upstream: upstream})
+			}
+		case isMissing(err):
+			drift = append(drift, policy.DriftItem{Field: policy.DriftArtifact, Stored: stored})
+		default:
+			return nil, err
+		}
+	}
+
+	return drift, nil
+}
+
+// verifiableArtifactDigest returns the SHA-256 checksum of a version with an external artifact.
+// Artifacts of published packages are stored by the hub itself and cannot drift.
+func verifiableArtifactDigest(pv *policy.PolicyVersion) (string, bool) {
+	if pv.DownloadURL == nil || (pv.SourceType != nil && *pv.SourceType == SourceTypePackage) {
+		return "", false
+	}
+	return pv.Checksum.SHA256()
+}
+
+// OpenArtifact opens the artifact download of a version with the fetchers used for packages, so
+// that it is subject to the same restrictions and credentials, and fails once more than maxSize
+// bytes are read
+func (s *Service) OpenArtifact(ctx context.Context, sourceType, url string, maxSize int64) (io.ReadCloser, error) {
+	kind := fetch.Package
+	kind.MaxSize = maxSize
+	body, err := s.packageFetchers.Open(ctx, sourceType, url, kind)
+	if err != nil {
+		return nil, fetchError(url, err)
+	}
+	return body, nil
+}
+
+// artifactSHA256 downloads an artifact and returns its hex SHA-256
+func (s *Service) artifactSHA256(ctx context.Context, sourceType, url string) (string, *errs.AppError) {
+	kind := fetch.Package
+	kind.MaxSize = s.maxPackageSize
+	body, err := s.packageFetchers.Open(ctx, sourceType, url, kind)
+	if err != nil {
+		if errors.Is(err, fetch.ErrTooLarge) {
+			return "", packageTooLarge(s.maxPackageSize)
+		}
+		return "", fetchError(url, err)
+	}
+	defer body.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, body); err != nil {
+		if errors.Is(err, fetch.ErrTooLarge) {
+			return "", packageTooLarge(s.maxPackageSize)
+		}
+		return "", fetchError(url, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// refreshDocs fetches the doc pages of a version again and stores the ones that changed. Pages that
+// are no longer found upstream keep their stored content.
+func (s *Service) refreshDocs(ctx context.Context, pv *policy.PolicyVersion, source *policy.VersionSource) ([]string, error) {
+	if len(source.DocURLs) == 0 {
+		return nil, nil
+	}
+
+	req := &SyncRequest{
+		PolicyName:    pv.PolicyName,
+		Version:       pv.Version,
+		SourceType:    source.SourceType,
+		Documentation: source.DocURLs,
+		AssetsBaseURL: source.AssetsBaseURL,
+	}
+	docs, _, err := s.fetchDocs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.policyService.ListPolicyDocs(ctx, pv.ID)
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]string, len(stored))
+	for _, doc := range stored {
+		current[doc.Page] = doc.ContentMd
+	}
+
+	var updated []string
+	for _, doc := range docs {
+		if content, ok := current[doc.Page]; ok && content == doc.ContentMd {
+			continue
+		}
+		doc.PolicyVersionID = pv.ID
+		if _, err := s.policyService.UpsertPolicyDoc(ctx, doc, policy.RevisionInfo{Origin: policy.DocOriginReconcile}); err != nil {
+			return updated, err
+		}
+		updated = append(updated, doc.Page)
+	}
+	return updated, nil
+}
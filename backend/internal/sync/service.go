@@ -11,14 +11,18 @@ package sync
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/wso2/policyhub/internal/artifacts"
+	"github.com/wso2/policyhub/internal/config"
 	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/fetch"
 	"github.com/wso2/policyhub/internal/logging"
 	"github.com/wso2/policyhub/internal/policy"
 	"github.com/wso2/policyhub/internal/validation"
@@ -28,19 +32,37 @@ import (
 
 // Service handles policy synchronization
 type Service struct {
-	policyService *policy.Service
-	logger        *logging.Logger
-	httpClient    *http.Client
+	policyService    *policy.Service
+	logger           *logging.Logger
+	fetchers         *fetch.Registry
+	batchConcurrency int
+	fetchConcurrency int
+
+	// Package publishing
+	store            *artifacts.Store
+	packageFetchers  *fetch.Registry
+	maxPackageSize   int64
+	maxExtractedSize int64
 }
 
-// NewService creates a new sync service
-func NewService(policyService *policy.Service, logger *logging.Logger) *Service {
+// NewService creates a new sync service; packages are published to the given artifact store
+func NewService(
+	policyService *policy.Service,
+	store *artifacts.Store,
+	cfg *config.SyncConfig,
+	fetchCfg *config.FetchConfig,
+	logger *logging.Logger,
+) *Service {
 	return &Service{
-		policyService: policyService,
-		logger:        logger,
-		httpClient: &http.Client{
-			Timeout: policy.HTTPTimeout,
-		},
+		policyService:    policyService,
+		logger:           logger,
+		fetchers:         fetch.New(fetchCfg, policy.HTTPTimeout),
+		batchConcurrency: cfg.BatchConcurrency,
+		fetchConcurrency: fetchCfg.Concurrency,
+		store:            store,
+		packageFetchers:  fetch.New(fetchCfg, time.Duration(cfg.PackageTimeoutSeconds)*time.Second),
+		maxPackageSize:   int64(cfg.MaxPackageSizeMB) << 20,
+		maxExtractedSize: int64(cfg.MaxExtractedSizeMB) << 20,
 	}
 }
 
@@ -59,7 +81,7 @@ func (r *SyncRequest) Validate() *errs.AppError {
 	if r.DownloadURL == "" {
 		return errs.NewValidationError("source URL is required", nil)
 	}
-	if r.DefinitionURL == "" {
+	if r.DefinitionURL == "" && r.DefinitionYAML == "" {
 		return errs.NewValidationError("definition URL is required", nil)
 	}
 	if r.Metadata == nil {
@@ -80,8 +102,19 @@ func (r *SyncRequest) Validate() *errs.AppError {
 	if err := validation.ValidateURL(r.DownloadURL); err != nil {
 		return errs.NewValidationError("invalid source URL", map[string]any{"error": err.Message})
 	}
-	if err := validation.ValidateURL(r.DefinitionURL); err != nil {
-		return errs.NewValidationError("invalid definition URL", map[string]any{"error": err.Message})
+	if r.DefinitionURL != "" {
+		if err := validation.ValidateSourceURL(r.DefinitionURL); err != nil {
+			return errs.NewValidationError("invalid definition URL", map[string]any{"error": err.Message})
+		}
+	}
+
+	// Required doc pages must be listed
+	for _, page := range r.RequiredDocs {
+		_, listed := r.Documentation[page]
+		_, inline := r.InlineDocs[page]
+		if !listed && !inline {
+			return errs.NewValidationError("required doc page is not listed in documentation", map[string]any{"page": page})
+		}
 	}
 
 	// Validate metadata
@@ -129,23 +162,29 @@ func (s *Service) SyncPolicy(ctx context.Context, req *SyncRequest) (*SyncResult
 
 	// Validate metadata matches request
 
-	// Fetch policy definition
-	definition, err := s.fetchPolicyDefinition(req.DefinitionURL)
-	if err != nil {
-		return nil, err
-	}
-
-	policyVersion, err := s.createPolicyVersion(ctx, req.PolicyName, req.Version, metadata, definition, req)
+	// Fetch all remote content before anything is written
+	definition, docs, docResults, err := s.fetchContent(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	// Sync documentation
-	if len(req.Documentation) > 0 {
-		if err := s.syncDocs(ctx, policyVersion.ID, req.Documentation, req.PolicyName, req.Version, req.AssetsBaseURL); err != nil {
-			s.logger.Warn("Failed to sync docs", zap.Error(err))
-			// Don't fail the entire sync if docs fail
+	// The version and all of its docs are stored in one transaction
+	candidate := newPolicyVersion(req.PolicyName, req.Version, metadata, definition, req)
+	item := &policy.VersionWithDocs{Version: candidate, Docs: docs, Source: versionSource(req)}
+	if _, err := s.policyService.CreatePolicyVersionsWithDocs(ctx, []*policy.VersionWithDocs{item}); err != nil {
+		if !isVersionExists(err) {
+			return nil, err
 		}
+		// Re-publishing identical content succeeds without touching the stored version
+		if err := s.checkRepublish(ctx, candidate); err != nil {
+			return nil, err
+		}
+		return &SyncResult{
+			PolicyName: req.PolicyName,
+			Version:    req.Version,
+			Status:     StatusUnchanged,
+			Docs:       docResults,
+		}, nil
 	}
 
 	if req.AssetsBaseURL != "" {
@@ -166,54 +205,93 @@ func (s *Service) SyncPolicy(ctx context.Context, req *SyncRequest) (*SyncResult
 		zap.String("policy", req.PolicyName),
 		zap.String("version", req.Version),
 		zap.Duration("duration", time.Since(startTime)),
-		zap.Int("docs_synced", len(req.Documentation)),
+		zap.Int("docs_synced", len(docs)),
 		zap.Bool("asset_urls_stored", req.AssetsBaseURL != ""))
 
 	return &SyncResult{
 		PolicyName: req.PolicyName,
 		Version:    req.Version,
-		Status:     "synced",
+		Status:     StatusSynced,
+		Docs:       docResults,
 	}, nil
 }
 
+// checkRepublish compares a version that is already stored with the one being published. It returns
+// nil when their content is identical and a conflict listing the differences otherwise.
+func (s *Service) checkRepublish(ctx context.Context, candidate *policy.PolicyVersion) error {
+	stored, diffs, err := s.policyService.CompareWithStored(ctx, candidate)
+	if err != nil {
+		return err
+	}
+	if !stored {
+		// Removed since the conflict was detected; report the original conflict
+		return errs.PolicyVersionExists(candidate.PolicyName, candidate.Version)
+	}
+	if len(diffs) > 0 {
+		s.logger.Warn("Policy version re-published with different content",
+			zap.String("policy", candidate.PolicyName),
+			zap.String("version", candidate.Version),
+			zap.Int("differences", len(diffs)))
+		return errs.PolicyVersionConflict(candidate.PolicyName, candidate.Version, diffs)
+	}
+
+	s.logger.Info("Policy version re-published with identical content",
+		zap.String("policy", candidate.PolicyName),
+		zap.String("version", candidate.Version))
+	return nil
+}
+
+// isVersionExists reports whether an error is the conflict of a version that is already stored
+func isVersionExists(err error) bool {
+	var appErr *errs.AppError
+	return errors.As(err, &appErr) && appErr.Code == errs.CodePolicyVersionExists
+}
+
 // fetchPolicyDefinition fetches policy-definition.yml as YAML
-func (s *Service) fetchPolicyDefinition(url string) (string, error) {
+func (s *Service) fetchPolicyDefinition(ctx context.Context, sourceType, url string) (string, error) {
 	s.logger.Debug("Fetching policy definition", zap.String("url", url))
 
-	resp, err := s.httpClient.Get(url)
+	body, err := s.fetchers.Get(ctx, sourceType, url, fetch.Definition)
 	if err != nil {
-		return "", errs.SyncFetchFailed(url, err)
+		return "", fetchError(url, err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", errs.SyncFetchFailed(url, fmt.Errorf("status code %d", resp.StatusCode))
+	if err := validateDefinitionYAML(body); err != nil {
+		return "", err
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", errs.SyncFetchFailed(url, err)
+	// Return YAML as string for storage
+	return string(body), nil
+}
+
+// loadDefinition returns the inline policy definition of a request, or fetches it from the definition URL
+func (s *Service) loadDefinition(ctx context.Context, req *SyncRequest) (string, error) {
+	if req.DefinitionYAML == "" {
+		return s.fetchPolicyDefinition(ctx, req.SourceType, req.DefinitionURL)
+	}
+	if err := validateDefinitionYAML([]byte(req.DefinitionYAML)); err != nil {
+		return "", err
 	}
+	return req.DefinitionYAML, nil
+}
 
-	// Validate YAML format
+// validateDefinitionYAML checks that a policy definition is well-formed YAML
+func validateDefinitionYAML(body []byte) error {
 	var yamlData interface{}
 	if err := yaml.Unmarshal(body, &yamlData); err != nil {
-		return "", errs.NewValidationError("invalid policy definition YAML", map[string]any{"error": err.Error()})
+		return errs.NewValidationError("invalid policy definition YAML", map[string]any{"error": err.Error()})
 	}
-
-	// Return YAML as string for storage
-	return string(body), nil
+	return nil
 }
 
-// createPolicyVersion creates a new policy version
-func (s *Service) createPolicyVersion(
-	ctx context.Context,
+// newPolicyVersion builds the policy version to store from a sync request and its fetched definition
+func newPolicyVersion(
 	policyName string,
 	version string,
 	metadata *policy.PolicyMetadata,
 	definition string,
 	req *SyncRequest,
-) (*policy.PolicyVersion, error) {
+) *policy.PolicyVersion {
 	policyVersion := &policy.PolicyVersion{
 		PolicyName:         policyName,
 		Version:            version,
@@ -256,55 +334,182 @@ func (s *Service) createPolicyVersion(
 		policyVersion.BannerPath = &metadata.BannerURL
 	}
 
-	return s.policyService.CreatePolicyVersion(ctx, policyVersion)
+	return policyVersion
 }
 
-// syncDocs synchronizes documentation files
-func (s *Service) syncDocs(ctx context.Context, versionID int32, documentation map[string]string, policyName, version, assetsBaseURL string) error {
-	for docType, docPath := range documentation {
-		content, err := s.fetchMarkdown(docPath)
-		if err != nil {
-			s.logger.Debug("Doc page not found", zap.String("docType", docType), zap.String("path", docPath), zap.Error(err))
-			continue // Skip missing docs
+// versionSource returns the sources of a request that the reconciler fetches again later;
+// inline content has no source
+func versionSource(req *SyncRequest) *policy.VersionSource {
+	source := &policy.VersionSource{
+		SourceType:    req.SourceType,
+		DocURLs:       make(map[string]string, len(req.Documentation)),
+		AssetsBaseURL: req.AssetsBaseURL,
+	}
+	if req.DefinitionYAML == "" {
+		source.DefinitionURL = req.DefinitionURL
+	}
+	for page, docURL := range req.Documentation {
+		if _, ok := req.InlineDocs[page]; !ok {
+			source.DocURLs[page] = docURL
 		}
+	}
+	return source
+}
+
+// fetchContent fetches the definition and the doc pages of a request. The definition is fetched
+// alongside the doc pages, which are fetched in parallel up to the configured limit.
+func (s *Service) fetchContent(ctx context.Context, req *SyncRequest) (string, []*policy.PolicyDoc, []DocResult, error) {
+	var (
+		definition string
+		defErr     error
+		done       = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		definition, defErr = s.loadDefinition(ctx, req)
+	}()
+
+	docs, docResults, err := s.fetchDocs(ctx, req)
+	<-done
+	if defErr != nil {
+		return "", nil, nil, defErr
+	}
+	if err != nil {
+		return "", nil, docResults, err
+	}
+	return definition, docs, docResults, nil
+}
 
-		// Rewrite image references
-		content = s.rewriteImageReferences(content, assetsBaseURL)
+// fetchDocs fetches the doc pages of a request and adds its inline pages, which take precedence over
+// fetched pages of the same type. Every page is reported as fetched, missing or failed. Nothing is
+// returned unless all pages could be fetched: a failed fetch fails the sync, as does a missing page
+// the request lists as required. Other missing pages are skipped.
+func (s *Service) fetchDocs(ctx context.Context, req *SyncRequest) ([]*policy.PolicyDoc, []DocResult, error) {
+	required := make(map[string]bool, len(req.RequiredDocs))
+	for _, page := range req.RequiredDocs {
+		required[page] = true
+	}
 
-		doc := &policy.PolicyDoc{
-			PolicyVersionID: versionID,
-			Page:            docType,
-			ContentMd:       content,
+	var pages []string
+	for _, page := range sortedKeys(req.Documentation) {
+		if _, ok := req.InlineDocs[page]; !ok {
+			pages = append(pages, page)
 		}
+	}
 
-		if _, err := s.policyService.UpsertPolicyDoc(ctx, doc); err != nil {
-			return err
+	// Pages are fetched in parallel; the outcomes are collected in page order
+	contents := make([]string, len(pages))
+	fetchErrs := make([]*errs.AppError, len(pages))
+	forEachConcurrently(len(pages), s.fetchConcurrency, func(i int) {
+		contents[i], fetchErrs[i] = s.fetchMarkdown(ctx, req.SourceType, req.Documentation[pages[i]])
+	})
+
+	var (
+		docs         []*policy.PolicyDoc
+		results      []DocResult
+		fetchErr     *errs.AppError
+		missingPages []string
+	)
+	for i, page := range pages {
+		result := DocResult{Page: page, URL: req.Documentation[page], Required: required[page]}
+
+		switch err := fetchErrs[i]; {
+		case err == nil:
+			result.Status = DocStatusFetched
+			docs = append(docs, &policy.PolicyDoc{
+				Page:      page,
+				ContentMd: s.rewriteImageReferences(contents[i], req.AssetsBaseURL),
+			})
+		case isMissing(err):
+			result.Status = DocStatusMissing
+			if result.Required {
+				missingPages = append(missingPages, page)
+			}
+		default:
+			result.Status, result.Error = DocStatusFailed, errorMessage(err)
+			if fetchErr == nil {
+				fetchErr = err
+			}
 		}
+		s.logger.Debug("Fetched doc page", zap.String("docType", page), zap.String("status", string(result.Status)))
+		results = append(results, result)
+	}
 
-		s.logger.Debug("Synced doc page", zap.String("docType", docType))
+	for _, page := range sortedKeys(req.InlineDocs) {
+		docs = append(docs, &policy.PolicyDoc{
+			Page:      page,
+			ContentMd: s.rewriteImageReferences(req.InlineDocs[page], req.AssetsBaseURL),
+		})
+		results = append(results, DocResult{Page: page, Status: DocStatusFetched, Required: required[page]})
 	}
 
-	return nil
+	if fetchErr != nil {
+		// Keeps the code and status of the first failure so that transient failures are retried
+		fetchErr.Details["docs"] = results
+		return nil, results, fetchErr
+	}
+	if len(missingPages) > 0 {
+		return nil, results, errs.RequiredDocsMissing(req.PolicyName, req.Version, missingPages, results)
+	}
+	return docs, results, nil
+}
+
+// Fetch fetches a URL with the fetchers of syncs, under the same restrictions and credentials
+func (s *Service) Fetch(ctx context.Context, sourceType, url string, kind fetch.Kind) ([]byte, error) {
+	body, err := s.fetchers.Get(ctx, sourceType, url, kind)
+	if err != nil {
+		return nil, fetchError(url, err)
+	}
+	return body, nil
 }
 
 // fetchMarkdown fetches markdown content from a URL
-func (s *Service) fetchMarkdown(url string) (string, error) {
-	resp, err := s.httpClient.Get(url)
+func (s *Service) fetchMarkdown(ctx context.Context, sourceType, url string) (string, *errs.AppError) {
+	body, err := s.fetchers.Get(ctx, sourceType, url, fetch.Markdown)
 	if err != nil {
-		return "", err
+		return "", fetchError(url, err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("status code %d", resp.StatusCode)
+	return string(body), nil
+}
+
+// fetchError converts a failed fetch to its sync error. Refused fetches are not retried.
+func fetchError(url string, err error) *errs.AppError {
+	var statusErr *fetch.StatusError
+	switch {
+	case errors.As(err, &statusErr):
+		return errs.SyncFetchStatus(url, statusErr.StatusCode)
+	case errors.Is(err, fetch.ErrNotFound):
+		// Missing local files are reported like a 404 response: not retried, and skipped as docs
+		return errs.SyncFetchStatus(url, http.StatusNotFound)
+	case fetch.IsRejected(err):
+		return errs.SyncFetchRejected(url, err)
+	default:
+		return errs.SyncFetchFailed(url, err)
 	}
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
+// isMissing reports whether a fetch failed because the page does not exist
+func isMissing(err *errs.AppError) bool {
+	status, _ := err.Details["status"].(int)
+	return status == http.StatusNotFound || status == http.StatusGone
+}
+
+// errorMessage returns the underlying error of a fetch failure
+func errorMessage(err *errs.AppError) string {
+	if msg, ok := err.Details["error"].(string); ok {
+		return msg
 	}
+	return err.Message
+}
 
-	return string(body), nil
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // rewriteImageReferences rewrites relative image paths to absolute asset URLs
@@ -97,6 +97,25 @@ func ValidateURL(urlStr string) *errs.AppError {
 	return nil
 }
 
+// ValidateSourceURL validates a URL that content is fetched from. Any scheme is accepted here;
+// whether a fetcher exists for it is decided when fetching.
+func ValidateSourceURL(urlStr string) *errs.AppError {
+	if urlStr == "" {
+		return errs.NewValidationError("URL cannot be empty", nil)
+	}
+
+	parsedURL, err := url.Parse(urlStr)
+	if err != nil {
+		return errs.NewValidationError("invalid URL format", map[string]any{"error": err.Error()})
+	}
+
+	if parsedURL.Scheme == "" {
+		return errs.NewValidationError("URL must be absolute", nil)
+	}
+
+	return nil
+}
+
 // ValidateCategories validates category names
 func ValidateCategories(categories []string) *errs.AppError {
 	for _, category := range categories {
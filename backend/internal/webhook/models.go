@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package webhook
+
+import (
+	"github.com/wso2/policyhub/internal/jobs"
+)
+
+// GitHub event types handled by the receiver
+const (
+	EventPing    = "ping"
+	EventPush    = "push"
+	EventRelease = "release"
+)
+
+// Outcome represents what the receiver did with a delivery
+type Outcome string
+
+const (
+	OutcomeQueued  Outcome = "queued"  // A sync job was queued for the version
+	OutcomeIgnored Outcome = "ignored" // The delivery does not publish a new version
+)
+
+// Result describes the handling of one webhook delivery
+type Result struct {
+	Event      string
+	DeliveryID string
+	Repository string
+	Tag        string
+	PolicyName string
+	Version    string
+	Outcome    Outcome
+	Reason     string        // Why the delivery was ignored
+	Job        *jobs.SyncJob // Set when a sync job was queued
+}
+
+// gitHubPayload holds the fields of push and release payloads used by the receiver
+type gitHubPayload struct {
+	Ref     string `json:"ref"`     // push: refs/tags/<tag> for tag pushes
+	Created bool   `json:"created"` // push: the ref was created
+	Deleted bool   `json:"deleted"` // push: the ref was deleted
+	Action  string `json:"action"`  // release: published, created, edited, ...
+	Release *struct {
+		TagName string `json:"tag_name"`
+		Draft   bool   `json:"draft"`
+	} `json:"release"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
@@ -0,0 +1,255 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com). All Rights Reserved.
+ *
+ * This software is the property of WSO2 LLC. and its suppliers, if any.
+ * Dissemination of any information or reproduction of any material contained
+ * herein in any form is strictly forbidden, unless permitted by WSO2 expressly.
+ * You may not alter or remove any copyright or other notice from copies of this content.
+ */
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/errs"
+	"github.com/wso2/policyhub/internal/fetch"
+	"github.com/wso2/policyhub/internal/jobs"
+	"github.com/wso2/policyhub/internal/logging"
+	"github.com/wso2/policyhub/internal/policy"
+	syncPkg "github.com/wso2/policyhub/internal/sync"
+)
+
+// signaturePrefix prefixes the hex HMAC-SHA256 digest in X-Hub-Signature-256
+const signaturePrefix = "sha256="
+
+// source is a configured repository with its compiled tag pattern
+type source struct {
+	config.WebhookSource
+	tagPattern *regexp.Regexp
+}
+
+// Receiver turns repository release events into sync jobs
+type Receiver struct {
+	policyService *policy.Service
+	syncService   *syncPkg.Service
+	jobService    *jobs.Service
+	logger        *logging.Logger
+	secret        []byte
+	sources       map[string]*source // Keyed by lower-case owner/name
+}
+
+// NewReceiver creates a new webhook receiver. Version metadata is fetched with the fetchers of the
+// sync service, under its fetch restrictions.
+func NewReceiver(policyService *policy.Service, syncService *syncPkg.Service, jobService *jobs.Service, cfg *config.WebhookConfig, logger *logging.Logger) *Receiver {
+	sources := make(map[string]*source, len(cfg.Sources))
+	for _, s := range cfg.Sources {
+		sources[strings.ToLower(s.Repository)] = &source{
+			WebhookSource: s,
+			tagPattern:    regexp.MustCompile(s.TagPattern), // Validated with the configuration
+		}
+	}
+
+	return &Receiver{
+		policyService: policyService,
+		syncService:   syncService,
+		jobService:    jobService,
+		logger:        logger,
+		secret:        []byte(cfg.Secret),
+		sources:       sources,
+	}
+}
+
+// HandleGitHub verifies a GitHub delivery and queues a sync for the tag it publishes
+func (r *Receiver) HandleGitHub(ctx context.Context, event, deliveryID, signature string, body []byte) (*Result, error) {
+	if len(r.secret) == 0 {
+		return nil, errs.WebhookNotConfigured()
+	}
+	if !r.verifySignature(signature, body) {
+		r.logger.Warn("Rejected webhook delivery with invalid signature",
+			zap.String("event", event),
+			zap.String("delivery_id", deliveryID))
+		return nil, errs.InvalidSignature()
+	}
+
+	result := &Result{Event: event, DeliveryID: deliveryID}
+	if event == EventPing {
+		return result.ignore("ping"), nil
+	}
+	if event != EventPush && event != EventRelease {
+		return result.ignore("unsupported event"), nil
+	}
+
+	var payload gitHubPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errs.NewValidationError("invalid webhook payload", map[string]any{"error": err.Error()})
+	}
+	result.Repository = payload.Repository.FullName
+
+	tag, reason := releasedTag(event, &payload)
+	if tag == "" {
+		return result.ignore(reason), nil
+	}
+	result.Tag = tag
+
+	src, ok := r.sources[strings.ToLower(payload.Repository.FullName)]
+	if !ok {
+		return nil, errs.WebhookSourceNotFound(payload.Repository.FullName)
+	}
+
+	result.PolicyName, result.Version, ok = src.mapTag(tag)
+	if !ok {
+		return result.ignore("tag does not match the source tag pattern"), nil
+	}
+
+	exists, err := r.policyService.PolicyVersionExists(ctx, result.PolicyName, result.Version)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		// Redeliveries and repeated events for a published version
+		return result.ignore("version already exists"), nil
+	}
+
+	req, err := r.buildRequest(ctx, src, result)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := r.jobService.Enqueue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Outcome, result.Job = OutcomeQueued, job
+	r.logger.Info("Webhook delivery queued policy sync",
+		zap.String("delivery_id", deliveryID),
+		zap.String("repository", result.Repository),
+		zap.String("tag", tag),
+		zap.Int32("job_id", job.ID))
+
+	return result, nil
+}
+
+// verifySignature checks the X-Hub-Signature-256 header against the payload
+func (r *Receiver) verifySignature(signature string, body []byte) bool {
+	digest, ok := strings.CutPrefix(signature, signaturePrefix)
+	if !ok {
+		return false
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, r.secret)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// releasedTag returns the tag published by a push or release event, or why the event publishes none
+func releasedTag(event string, payload *gitHubPayload) (string, string) {
+	switch event {
+	case EventRelease:
+		if payload.Action != "published" || payload.Release == nil {
+			return "", "release action is not published"
+		}
+		if payload.Release.Draft {
+			return "", "draft release"
+		}
+		return payload.Release.TagName, ""
+	default:
+		tag, ok := strings.CutPrefix(payload.Ref, "refs/tags/")
+		if !ok {
+			return "", "push is not a tag push"
+		}
+		if payload.Deleted || !payload.Created {
+			return "", "tag was not created"
+		}
+		return tag, ""
+	}
+}
+
+// mapTag extracts the policy name and version from a tag
+func (s *source) mapTag(tag string) (string, string, bool) {
+	match := s.tagPattern.FindStringSubmatch(tag)
+	if match == nil {
+		return "", "", false
+	}
+
+	name := s.PolicyName
+	if name == "" {
+		name = match[s.tagPattern.SubexpIndex("name")]
+	}
+	return name, match[s.tagPattern.SubexpIndex("version")], true
+}
+
+// buildRequest expands the source URL templates and fetches the version metadata. Values taken
+// from the payload are escaped so that they cannot change the structure of the URLs.
+func (r *Receiver) buildRequest(ctx context.Context, src *source, result *Result) (*syncPkg.SyncRequest, error) {
+	expand := strings.NewReplacer(
+		"{repository}", result.Repository,
+		"{tag}", url.PathEscape(result.Tag),
+		"{name}", url.PathEscape(result.PolicyName),
+		"{version}", url.PathEscape(result.Version),
+	).Replace
+
+	sourceType := src.SourceType
+	if sourceType == "" {
+		sourceType = "github"
+	}
+
+	metadata, err := r.fetchMetadata(ctx, sourceType, expand(src.MetadataURLTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	req := &syncPkg.SyncRequest{
+		PolicyName:    result.PolicyName,
+		Version:       result.Version,
+		SourceType:    sourceType,
+		DownloadURL:   expand(src.DownloadURLTemplate),
+		DefinitionURL: expand(src.DefinitionURLTemplate),
+		Metadata:      metadata,
+	}
+	if src.AssetsURLTemplate != "" {
+		req.AssetsBaseURL = expand(src.AssetsURLTemplate)
+	}
+	if len(src.DocsURLTemplates) > 0 {
+		req.Documentation = make(map[string]string, len(src.DocsURLTemplates))
+		for page, tmpl := range src.DocsURLTemplates {
+			req.Documentation[page] = expand(tmpl)
+		}
+	}
+
+	return req, nil
+}
+
+// fetchMetadata fetches and decodes the metadata.json of a version
+func (r *Receiver) fetchMetadata(ctx context.Context, sourceType, url string) (*policy.PolicyMetadata, error) {
+	body, err := r.syncService.Fetch(ctx, sourceType, url, fetch.JSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata policy.PolicyMetadata
+	if err := json.Unmarshal(body, &metadata); err != nil {
+		return nil, errs.NewValidationError("invalid policy metadata", map[string]any{"url": url, "error": err.Error()})
+	}
+	return &metadata, nil
+}
+
+func (res *Result) ignore(reason string) *Result {
+	res.Outcome, res.Reason = OutcomeIgnored, reason
+	return res
+}
@@ -21,15 +21,31 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/wso2/policyhub/internal/artifacts"
+	"github.com/wso2/policyhub/internal/bundle"
+	"github.com/wso2/policyhub/internal/changes"
+	"github.com/wso2/policyhub/internal/cli"
 	"github.com/wso2/policyhub/internal/config"
+	"github.com/wso2/policyhub/internal/crawler"
 	"github.com/wso2/policyhub/internal/db"
+	"github.com/wso2/policyhub/internal/events"
+	"github.com/wso2/policyhub/internal/feeds"
 	httpPkg "github.com/wso2/policyhub/internal/http"
+	"github.com/wso2/policyhub/internal/jobs"
 	"github.com/wso2/policyhub/internal/logging"
 	"github.com/wso2/policyhub/internal/policy"
+	"github.com/wso2/policyhub/internal/reconcile"
+	"github.com/wso2/policyhub/internal/stats"
 	"github.com/wso2/policyhub/internal/sync"
+	"github.com/wso2/policyhub/internal/webhook"
 )
 
 func main() {
+	// Subcommands (export, import, publish, ...) run against the catalog and exit without starting the server
+	if len(os.Args) > 1 {
+		os.Exit(cli.Run(os.Args[1:]))
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -56,19 +72,66 @@ func main() {
 	defer database.Close()
 
 	// Create database schema
-	if err := db.CreateSchema(database.Pool, logger.Logger); err != nil {
+	tagNormalizer := policy.NewTagNormalizer(cfg.Catalog.TagSynonyms)
+	if err := db.CreateSchema(database.Pool, tagNormalizer, logger.Logger); err != nil {
 		logger.Fatal("Failed to create database schema", zap.Error(err))
 	}
 
-	// Initialize repository
+	// Initialize repositories
 	policyRepo := policy.NewSQLCRepository(database)
+	statsRepo := stats.NewSQLCRepository(database)
+	jobRepo := jobs.NewSQLCRepository(database)
+	eventRepo := events.NewSQLCRepository(database)
+	changeRepo := changes.NewSQLCRepository(database)
+	feedRepo := feeds.NewSQLCRepository(database)
 
 	// Initialize services
-	policyService := policy.NewService(policyRepo, logger)
-	syncService := sync.NewService(policyService, logger)
+	artifactStore := artifacts.NewStore(&cfg.Artifacts)
+	policyService := policy.NewService(policyRepo, tagNormalizer, logger)
+	syncService := sync.NewService(policyService, artifactStore, &cfg.Sync, &cfg.Fetch, logger)
+	statsService := stats.NewService(statsRepo, logger)
+	eventService := events.NewService(eventRepo, &cfg.Events, logger)
+	feedService := feeds.NewService(feedRepo, &cfg.Feeds, logger)
+	bundleExporter, err := bundle.NewExporter(policyService, syncService, &cfg.Bundle, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize bundle exporter", zap.Error(err))
+	}
+	bundleImporter, err := bundle.NewImporter(policyService, artifactStore, &cfg.Bundle, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize bundle importer", zap.Error(err))
+	}
+
+	// Start sync workers (syncs run as persistent jobs outside the request path)
+	workerPool := jobs.NewWorkerPool(jobRepo, syncService, &cfg.SyncJobs, logger)
+	jobService := jobs.NewService(jobRepo, workerPool, &cfg.SyncJobs, logger)
+	workerPool.Start()
+
+	// Start usage recorder (buffered, batched writes off the request path)
+	recorder := stats.NewRecorder(statsRepo, &cfg.Stats, logger)
+	recorder.Start()
+
+	// Start catalog crawler (scheduled only when an interval is configured)
+	catalogCrawler := crawler.NewCrawler(policyService, syncService, &cfg.Crawler, logger)
+	catalogCrawler.Start()
+
+	// Start reconciler (refreshes docs of synced versions from their sources and flags upstream drift)
+	reconciler := reconcile.NewReconciler(policyService, syncService, &cfg.Reconcile, logger)
+	reconciler.Start()
+
+	// Repository webhooks queue syncs for newly released versions
+	webhookReceiver := webhook.NewReceiver(policyService, syncService, jobService, &cfg.Webhook, logger)
+
+	// Start webhook dispatcher (delivers catalog events recorded in the outbox to subscribers)
+	dispatcher := events.NewDispatcher(eventRepo, &cfg.Events, logger)
+	dispatcher.Start()
+
+	// Start change feed watcher (wakes long-polling and streaming feed clients)
+	changeWatcher := changes.NewWatcher(changeRepo, &cfg.Changes, logger)
+	changeService := changes.NewService(changeRepo, changeWatcher, &cfg.Changes, logger)
+	changeWatcher.Start()
 
 	// Setup HTTP router
-	router := httpPkg.SetupRouter(cfg, policyService, syncService, logger)
+	router := httpPkg.SetupRouter(cfg, policyService, syncService, jobService, statsService, recorder, catalogCrawler, reconciler, webhookReceiver, eventService, changeService, feedService, bundleExporter, bundleImporter, artifactStore, logger)
 
 	// Create HTTP server
 	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
@@ -80,6 +143,9 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Feed clients waiting for changes are released so that shutdown does not wait on them
+	srv.RegisterOnShutdown(changeWatcher.Stop)
+
 	// Start server in a goroutine
 	go func() {
 		logger.Info("HTTP server starting", zap.String("address", addr))
@@ -105,5 +171,12 @@ func main() {
 		logger.Fatal("Server forced to shutdown", zap.Error(err))
 	}
 
+	// Abort a running crawl and reconciliation, let running sync jobs and webhook deliveries finish and flush buffered usage counts
+	catalogCrawler.Stop(ctx)
+	reconciler.Stop(ctx)
+	workerPool.Stop(ctx)
+	dispatcher.Stop(ctx)
+	recorder.Close(ctx)
+
 	logger.Info("Server exited gracefully")
 }